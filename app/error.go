@@ -12,10 +12,6 @@ type ErrorExccedMaxTrainingRecord struct {
 	error
 }
 
-type ErrorOnlyOneRunningTraining struct {
-	error
-}
-
 type ErrorUnavailableRepoFile struct {
 	error
 }
@@ -56,8 +52,36 @@ const (
 	ErrorTrainNoLog        = "train_no_log"
 	ErrorTrainNoOutput     = "train_no_output"
 	ErrorTrainNotFound     = "train_not_found"
+	ErrorTrainNotQueued    = "train_not_queued"
+	ErrorTrainNotDone      = "train_not_done"
+	ErrorTrainNotCompleted = "train_not_completed"
+	ErrorTrainOutputTooBig = "train_output_too_big"
+	ErrorTrainPublished    = "train_published"
 	ErrorTrainExccedMaxNum = "train_excced_max_num" // excced max training num for a user
 
+	ErrorSweepNotFound     = "sweep_not_found"
+	ErrorSweepInvalidParam = "sweep_invalid_param"
+
+	ErrorLineageNodeNotFound = "lineage_node_not_found"
+
+	ErrorTrainingScheduleNotFound     = "training_schedule_not_found"
+	ErrorTrainingScheduleExccedMaxNum = "training_schedule_excced_max_num"
+
+	ErrorEvaluateNotFound        = "evaluate_not_found"
+	ErrorEvaluateInvalidParms    = "evaluate_invalid_parms"
+	ErrorEvaluateReportNotFound  = "evaluate_report_not_found"
+	ErrorEvaluateInvalidArtifact = "evaluate_invalid_artifact"
+
+	ErrorInferenceNotFound   = "inference_not_found"
+	ErrorInferenceNotRunning = "inference_not_running"
+
+	ErrorInferenceDeploymentNotFound     = "inference_deployment_not_found"
+	ErrorInferenceDeploymentNotReady     = "inference_deployment_not_ready"
+	ErrorInferenceDeploymentSameCommit   = "inference_deployment_same_commit"
+	ErrorInferenceDeploymentExccedMaxNum = "inference_deployment_excced_max_num"
+	ErrorInferenceDeploymentStopped      = "inference_deployment_stopped"
+	ErrorInferenceDeploymentNotStopped   = "inference_deployment_not_stopped"
+
 	ErrorWuKongInvalidId        = "wukong_invalid_id"
 	ErrorWuKongInvalidOwner     = "wukong_invalid_owner"
 	ErrorWuKongInvalidPath      = "wukong_invalid_path"
@@ -68,6 +92,12 @@ const (
 
 	ErrorFinetuneExpiry           = "finetune_expiry"
 	ErrorFinetuneNotFound         = "finetune_not_found"
+	ErrorFinetuneNotDone          = "finetune_not_done"
+	ErrorFinetuneNoOutput         = "finetune_no_output"
+	ErrorFinetuneModelExists      = "finetune_model_exists"
+	ErrorFinetuneModelNotAllowed  = "finetune_model_not_allowed"
+	ErrorFinetuneExccedGPUHours   = "finetune_excced_gpu_hours"
+	ErrorFinetuneUserExists       = "finetune_user_exists"
 	ErrorFinetuneExccedMaxNum     = "finetune_excced_max_num"
 	ErrorFinetuneNoPermission     = "finetune_no_permission"
 	ErrorFinetuneRunningJobExists = "finetune_running_job_exists"
@@ -0,0 +1,374 @@
+package app
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	EvaluateReportFormatJSON = "json"
+	EvaluateReportFormatCSV  = "csv"
+)
+
+// EvaluateReportAddCmd is reported by the evaluation itself, so the
+// results are trusted as they are and only the scopes are checked.
+type EvaluateReportAddCmd struct {
+	Index     EvaluateIndex
+	Results   []domain.EvaluateResult
+	Artifacts []domain.EvaluateArtifact
+}
+
+func (cmd *EvaluateReportAddCmd) Validate() error {
+	if len(cmd.Results) == 0 && len(cmd.Artifacts) == 0 {
+		return errors.New("nothing to add")
+	}
+
+	for i := range cmd.Results {
+		if len(cmd.Results[i].Metrics) == 0 {
+			return errors.New("no metrics")
+		}
+	}
+
+	for i := range cmd.Artifacts {
+		item := &cmd.Artifacts[i]
+
+		if item.Name == "" || item.Path == "" || path.IsAbs(item.Path) ||
+			strings.HasPrefix(path.Clean(item.Path), "..") {
+			return errors.New("invalid artifact")
+		}
+	}
+
+	return nil
+}
+
+type EvaluateParmsDTO struct {
+	Momentum     string `json:"momentum,omitempty"`
+	BatchSize    string `json:"batch_size,omitempty"`
+	LearningRate string `json:"learning_rate,omitempty"`
+}
+
+type EvaluateResultDTO struct {
+	EvaluateParmsDTO
+
+	Metrics map[string]float64 `json:"metrics"`
+}
+
+type EvaluateArtifactDTO struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+type EvaluateReportDTO struct {
+	Type    string              `json:"type"`
+	Metrics []string            `json:"metrics"`
+	Results []EvaluateResultDTO `json:"results"`
+
+	// Pending is the combinations of hyperparameters which have no result.
+	Pending   []EvaluateParmsDTO    `json:"pending,omitempty"`
+	Artifacts []EvaluateArtifactDTO `json:"artifacts,omitempty"`
+	UpdatedAt string                `json:"updated_at"`
+}
+
+type EvaluateReportService interface {
+	// Add saves the results and artifacts reported by the evaluation.
+	Add(*EvaluateReportAddCmd) (string, error)
+	Get(*EvaluateIndex) (EvaluateReportDTO, string, error)
+	Export(index *EvaluateIndex, format string) ([]byte, string, error)
+}
+
+func NewEvaluateReportService(
+	train training.Training,
+	trainingRepo repository.Training,
+	evaluate repository.Evaluate,
+	repo repository.EvaluateReport,
+) EvaluateReportService {
+	return evaluateReportService{
+		train:        train,
+		trainingRepo: trainingRepo,
+		evaluate:     evaluate,
+		repo:         repo,
+	}
+}
+
+type evaluateReportService struct {
+	train        training.Training
+	trainingRepo repository.Training
+	evaluate     repository.Evaluate
+	repo         repository.EvaluateReport
+}
+
+func (s evaluateReportService) Add(cmd *EvaluateReportAddCmd) (code string, err error) {
+	parms, code, err := s.getParms(&cmd.Index)
+	if err != nil {
+		return
+	}
+
+	for i := range cmd.Results {
+		if !parms.Contains(&cmd.Results[i].Parms) {
+			code = ErrorEvaluateInvalidParms
+			err = errors.New("the hyperparameters are not in the scopes of evaluation")
+
+			return
+		}
+	}
+
+	if len(cmd.Artifacts) > 0 {
+		if code, err = s.checkArtifacts(cmd); err != nil {
+			return
+		}
+	}
+
+	report, err := s.repo.Get(&cmd.Index)
+	if err != nil {
+		if !repository.IsErrorResourceNotExists(err) {
+			return
+		}
+
+		report = domain.EvaluateReport{EvaluateIndex: cmd.Index}
+	}
+
+	report.AddResults(cmd.Results)
+	report.AddArtifacts(cmd.Artifacts)
+	report.UpdatedAt = utils.Now()
+
+	err = s.repo.Save(&report)
+
+	return
+}
+
+func (s evaluateReportService) Get(index *EvaluateIndex) (
+	dto EvaluateReportDTO, code string, err error,
+) {
+	if dto, code, err = s.get(index); err != nil {
+		return
+	}
+
+	report, err := s.repo.Get(index)
+	if err != nil {
+		return
+	}
+
+	if len(report.Artifacts) == 0 {
+		return
+	}
+
+	detail, endpoint, err := s.trainingRepo.GetJobDetail(&index.TrainingIndex)
+	if err != nil {
+		return
+	}
+
+	dirs := artifactDirs(&detail)
+
+	dto.Artifacts = make([]EvaluateArtifactDTO, 0, len(report.Artifacts))
+	for i := range report.Artifacts {
+		item := &report.Artifacts[i]
+
+		if !item.IsUnder(dirs...) {
+			logrus.Errorf("evaluate artifact:%s is not in the outputs of training", item.Path)
+
+			continue
+		}
+
+		link, err := s.train.GetFileDownloadURL(endpoint, item.Path)
+		if err != nil {
+			logrus.Errorf(
+				"get download url of evaluate artifact:%s failed, err:%s",
+				item.Path, err.Error(),
+			)
+		}
+
+		dto.Artifacts = append(dto.Artifacts, EvaluateArtifactDTO{
+			Name:        item.Name,
+			DownloadURL: link,
+		})
+	}
+
+	return
+}
+
+func (s evaluateReportService) Export(index *EvaluateIndex, format string) (
+	data []byte, code string, err error,
+) {
+	dto, code, err := s.get(index)
+	if err != nil {
+		return
+	}
+
+	switch format {
+	case EvaluateReportFormatJSON:
+		data, err = json.MarshalIndent(dto, "", "  ")
+
+	case EvaluateReportFormatCSV:
+		data, err = s.toCSV(&dto)
+
+	default:
+		err = errors.New("unknown format")
+	}
+
+	return
+}
+
+// get returns the report without the artifacts.
+func (s evaluateReportService) get(index *EvaluateIndex) (
+	dto EvaluateReportDTO, code string, err error,
+) {
+	parms, code, err := s.getParms(index)
+	if err != nil {
+		return
+	}
+
+	report, err := s.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorEvaluateReportNotFound
+		}
+
+		return
+	}
+
+	dto.Type = domain.EvaluateTypeCustom
+	if len(parms.MomentumScope)+len(parms.BatchSizeScope)+len(parms.LearningRateScope) > 0 {
+		dto.Type = domain.EvaluateTypeStandard
+	}
+
+	dto.Metrics = report.MetricNames()
+	dto.UpdatedAt = utils.ToDate(report.UpdatedAt)
+
+	done := make(map[string]bool, len(report.Results))
+	dto.Results = make([]EvaluateResultDTO, len(report.Results))
+
+	for i := range report.Results {
+		item := &report.Results[i]
+
+		metrics := make(map[string]float64, len(item.Metrics))
+		for _, m := range item.Metrics {
+			metrics[m.Name.CustomizedKey()] = m.Value
+		}
+
+		dto.Results[i] = EvaluateResultDTO{
+			EvaluateParmsDTO: toEvaluateParmsDTO(&item.Parms),
+			Metrics:          metrics,
+		}
+
+		done[item.Parms.Key()] = true
+	}
+
+	sort.Slice(dto.Results, func(i, j int) bool {
+		return dto.Results[i].key() < dto.Results[j].key()
+	})
+
+	for _, item := range parms.Combinations() {
+		if !done[item.Key()] {
+			dto.Pending = append(dto.Pending, toEvaluateParmsDTO(&item))
+		}
+	}
+
+	return
+}
+
+func (s evaluateReportService) getParms(index *EvaluateIndex) (
+	parms domain.StandardEvaluateParms, code string, err error,
+) {
+	if parms, err = s.evaluate.GetStandardEvaluateParms(index); err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorEvaluateNotFound
+		}
+	}
+
+	return
+}
+
+// checkArtifacts only accepts the files in the log or output directory
+// of the training, so that the other files can't be downloaded.
+func (s evaluateReportService) checkArtifacts(cmd *EvaluateReportAddCmd) (
+	code string, err error,
+) {
+	detail, _, err := s.trainingRepo.GetJobDetail(&cmd.Index.TrainingIndex)
+	if err != nil {
+		return
+	}
+
+	dirs := artifactDirs(&detail)
+
+	for i := range cmd.Artifacts {
+		if !cmd.Artifacts[i].IsUnder(dirs...) {
+			code = ErrorEvaluateInvalidArtifact
+			err = errors.New("the artifact is not in the outputs of training")
+
+			return
+		}
+	}
+
+	return
+}
+
+func artifactDirs(detail *domain.JobDetail) []string {
+	dirs := make([]string, 0, 2)
+
+	if detail.LogPath != "" {
+		dirs = append(dirs, path.Dir(path.Clean(detail.LogPath)))
+	}
+
+	if detail.OutputPath != "" {
+		dirs = append(dirs, path.Dir(path.Clean(detail.OutputPath)))
+	}
+
+	return dirs
+}
+
+func (s evaluateReportService) toCSV(dto *EvaluateReportDTO) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := csv.NewWriter(buf)
+
+	header := []string{"momentum", "batch_size", "learning_rate"}
+	if err := w.Write(append(header, dto.Metrics...)); err != nil {
+		return nil, err
+	}
+
+	for i := range dto.Results {
+		item := &dto.Results[i]
+
+		row := []string{item.Momentum, item.BatchSize, item.LearningRate}
+		for _, name := range dto.Metrics {
+			v, ok := item.Metrics[name]
+			if ok {
+				row = append(row, strconv.FormatFloat(v, 'f', -1, 64))
+			} else {
+				row = append(row, "")
+			}
+		}
+
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+func (dto *EvaluateResultDTO) key() string {
+	return dto.Momentum + "/" + dto.BatchSize + "/" + dto.LearningRate
+}
+
+func toEvaluateParmsDTO(v *domain.EvaluateParms) EvaluateParmsDTO {
+	return EvaluateParmsDTO{
+		Momentum:     v.Momentum,
+		BatchSize:    v.BatchSize,
+		LearningRate: v.LearningRate,
+	}
+}
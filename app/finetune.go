@@ -21,6 +21,7 @@ type FinetuneService interface {
 	Delete(*FinetuneIndex) error
 	Terminate(*FinetuneIndex) error
 	GetJobInfo(*FinetuneIndex) (FinetuneJobDTO, string, error)
+	GetArtifacts(*FinetuneIndex) ([]FinetuneArtifactDTO, string, error)
 }
 
 func NewFinetuneService(
@@ -73,15 +74,35 @@ func (s finetuneService) Create(cmd *FinetuneCreateCmd) (
 		return
 	}
 
+	if !v.Quota.IsModelAllowed(cmd.Param.Model()) {
+		code = ErrorFinetuneModelNotAllowed
+		err = errors.New("the model is not allowed to finetune")
+
+		return
+	}
+
+	used := v.UsedDuration(v.Datas, s.isJobDone, utils.Now())
+	if v.Quota.IsGPUHoursExhausted(used) {
+		code = ErrorFinetuneExccedGPUHours
+		err = errors.New("exceed max gpu hours")
+
+		return
+	}
+
+	running := 0
 	for i := range v.Datas {
 		if !s.isJobDone(v.Datas[i].Status) {
-			code = ErrorFinetuneRunningJobExists
-			err = errors.New("a job is running")
-
-			return
+			running++
 		}
 	}
 
+	if running >= v.Quota.ConcurrentJobs() {
+		code = ErrorFinetuneRunningJobExists
+		err = errors.New("exceed max concurrent jobs")
+
+		return
+	}
+
 	t := domain.Finetune{
 		CreatedAt:      utils.Now(),
 		FinetuneConfig: *cmd.toFinetuneConfig(),
@@ -183,6 +204,56 @@ func (s finetuneService) GetJobInfo(index *FinetuneIndex) (
 	return
 }
 
+func (s finetuneService) GetArtifacts(index *FinetuneIndex) (
+	dtos []FinetuneArtifactDTO, code string, err error,
+) {
+	job, err := s.repo.GetJob(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorFinetuneNotFound
+		}
+
+		return
+	}
+
+	if job.JobId == "" || !s.isJobDone(job.Status) {
+		code = ErrorFinetuneNotDone
+		err = errors.New("finetune is not done")
+
+		return
+	}
+
+	link, err := s.fs.GetLogPreviewURL(job.JobId)
+	if err != nil {
+		return
+	}
+
+	dtos = append(dtos, FinetuneArtifactDTO{
+		Name:        finetuneArtifactLog,
+		DownloadURL: link,
+	})
+
+	if !s.fs.IsJobSuccess(job.Status) {
+		return
+	}
+
+	if link, err = s.fs.GetOutputDownloadURL(job.JobId); err != nil {
+		// the log is still available when the output is not.
+		if finetune.IsErrorUnsupported(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	dtos = append(dtos, FinetuneArtifactDTO{
+		Name:        finetuneArtifactOutput,
+		DownloadURL: link,
+	})
+
+	return
+}
+
 // FinetuneInternalService
 type FinetuneInternalService interface {
 	UpdateJobDetail(*FinetuneIndex, *FinetuneJobDetail) error
@@ -7,6 +7,11 @@ import (
 	"github.com/opensourceways/xihe-server/utils"
 )
 
+const (
+	finetuneArtifactLog    = "log"
+	finetuneArtifactOutput = "output"
+)
+
 type FinetuneCreateCmd struct {
 	User domain.Account
 
@@ -65,3 +70,8 @@ type FinetuneJobDTO struct {
 	IsDone        bool
 	LogPreviewURL string
 }
+
+type FinetuneArtifactDTO struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"download_url"`
+}
@@ -0,0 +1,175 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/finetune"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type FinetuneGrantCmd struct {
+	User   domain.Account
+	Quota  domain.FinetuneQuota
+	Expiry int64
+}
+
+func (cmd *FinetuneGrantCmd) Validate() error {
+	if cmd.User == nil || cmd.Expiry <= utils.Now() {
+		return errors.New("invalid cmd of granting finetune")
+	}
+
+	return nil
+}
+
+type FinetuneExtendCmd struct {
+	User domain.Account
+	Days int
+}
+
+func (cmd *FinetuneExtendCmd) Validate() error {
+	if cmd.User == nil || cmd.Days <= 0 {
+		return errors.New("invalid cmd of extending finetune")
+	}
+
+	return nil
+}
+
+type FinetuneEntitlementDTO struct {
+	Account           string   `json:"account"`
+	Expiry            int64    `json:"expiry"`
+	IsExpiry          bool     `json:"is_expiry"`
+	MaxConcurrentJobs int      `json:"max_concurrent_jobs"`
+	MaxGPUHours       int      `json:"max_gpu_hours"`
+	Models            []string `json:"models"`
+	UsedGPUHours      float64  `json:"used_gpu_hours"`
+	RunningJobs       int      `json:"running_jobs"`
+}
+
+// FinetuneEntitlementService is used by the administrator
+// to manage the users who can finetune.
+type FinetuneEntitlementService interface {
+	// Grant grants the user or replaces the quota and expiry of it.
+	Grant(*FinetuneGrantCmd) (string, error)
+	Revoke(domain.Account) (string, error)
+	Extend(*FinetuneExtendCmd) (string, error)
+	Get(domain.Account) (FinetuneEntitlementDTO, string, error)
+}
+
+func NewFinetuneEntitlementService(
+	fs finetune.Finetune,
+	repo repository.Finetune,
+) FinetuneEntitlementService {
+	return finetuneEntitlementService{
+		fs:   finetuneService{fs: fs},
+		repo: repo,
+	}
+}
+
+type finetuneEntitlementService struct {
+	fs   finetuneService
+	repo repository.Finetune
+}
+
+func (s finetuneEntitlementService) Grant(cmd *FinetuneGrantCmd) (code string, err error) {
+	info := domain.FinetuneUserInfo{
+		Expiry: cmd.Expiry,
+		Quota:  cmd.Quota,
+	}
+
+	v, err := s.repo.List(cmd.User)
+	if err != nil {
+		if !repository.IsErrorResourceNotExists(err) {
+			return
+		}
+
+		if err = s.repo.AddUser(cmd.User, &info); err != nil {
+			if repository.IsErrorDuplicateCreating(err) {
+				code = ErrorFinetuneUserExists
+			}
+		}
+
+		return
+	}
+
+	err = s.repo.UpdateUser(cmd.User, &info, v.Version)
+
+	return
+}
+
+func (s finetuneEntitlementService) Revoke(user domain.Account) (string, error) {
+	return s.update(user, func(info *domain.FinetuneUserInfo) {
+		// the user without expiry is able to finetune forever.
+		if now := utils.Now(); info.Expiry <= 0 || info.Expiry > now {
+			info.Expiry = now
+		}
+	})
+}
+
+func (s finetuneEntitlementService) Extend(cmd *FinetuneExtendCmd) (string, error) {
+	return s.update(cmd.User, func(info *domain.FinetuneUserInfo) {
+		if info.Expiry <= 0 {
+			return
+		}
+
+		if now := utils.Now(); info.Expiry < now {
+			info.Expiry = now
+		}
+
+		info.Expiry += int64(cmd.Days) * 24 * 3600
+	})
+}
+
+func (s finetuneEntitlementService) update(
+	user domain.Account, f func(*domain.FinetuneUserInfo),
+) (code string, err error) {
+	v, err := s.repo.List(user)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorFinetuneNoPermission
+		}
+
+		return
+	}
+
+	info := v.FinetuneUserInfo
+	f(&info)
+
+	err = s.repo.UpdateUser(user, &info, v.Version)
+
+	return
+}
+
+func (s finetuneEntitlementService) Get(user domain.Account) (
+	dto FinetuneEntitlementDTO, code string, err error,
+) {
+	v, err := s.repo.List(user)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorFinetuneNoPermission
+		}
+
+		return
+	}
+
+	running := 0
+	for i := range v.Datas {
+		if !s.fs.isJobDone(v.Datas[i].Status) {
+			running++
+		}
+	}
+
+	dto = FinetuneEntitlementDTO{
+		Account:           user.Account(),
+		Expiry:            v.Expiry,
+		IsExpiry:          utils.IsExpiry(v.Expiry),
+		MaxConcurrentJobs: v.Quota.ConcurrentJobs(),
+		MaxGPUHours:       v.Quota.MaxGPUHours,
+		Models:            v.Quota.Models,
+		UsedGPUHours:      float64(v.UsedDuration(v.Datas, s.fs.isJobDone, utils.Now())) / 3600,
+		RunningJobs:       running,
+	}
+
+	return
+}
@@ -0,0 +1,248 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/finetune"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+const modelCardFile = "README.md"
+
+type FinetuneExportCmd struct {
+	Index FinetuneIndex
+
+	// User commits the output and model card to the repo of model.
+	User platform.UserInfo
+
+	Name     domain.ResourceName
+	Desc     domain.ResourceDesc
+	Title    domain.ResourceTitle
+	RepoType domain.RepoType
+	Protocol domain.ProtocolName
+}
+
+func (cmd *FinetuneExportCmd) Validate() error {
+	b := cmd.Index.Owner != nil &&
+		cmd.Index.Id != "" &&
+		cmd.Name != nil &&
+		cmd.RepoType != nil &&
+		cmd.Protocol != nil
+
+	if !b {
+		return errors.New("invalid cmd of exporting finetune")
+	}
+
+	return nil
+}
+
+type FinetuneExportDTO struct {
+	ModelId string `json:"model_id"`
+	Owner   string `json:"owner"`
+	Name    string `json:"name"`
+	// File is empty if the output is not available.
+	File string `json:"file"`
+}
+
+type FinetuneExportService interface {
+	// Export creates a model with the output of a successful finetune
+	// and records the parameters of finetune in the model card.
+	Export(*FinetuneExportCmd, platform.Repository) (FinetuneExportDTO, string, error)
+}
+
+func NewFinetuneExportService(
+	fs finetune.Finetune,
+	repo repository.Finetune,
+	rf platform.RepoFile,
+	model ModelService,
+) FinetuneExportService {
+	return finetuneExportService{
+		fs:    fs,
+		repo:  repo,
+		rf:    rf,
+		model: model,
+	}
+}
+
+type finetuneExportService struct {
+	fs    finetune.Finetune
+	repo  repository.Finetune
+	rf    platform.RepoFile
+	model ModelService
+}
+
+func (s finetuneExportService) Export(cmd *FinetuneExportCmd, pr platform.Repository) (
+	dto FinetuneExportDTO, code string, err error,
+) {
+	v, err := s.repo.Get(&cmd.Index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorFinetuneNotFound
+		}
+
+		return
+	}
+
+	if v.Job.JobId == "" || !s.fs.IsJobDone(v.JobDetail.Status) {
+		code = ErrorFinetuneNotDone
+		err = errors.New("finetune is not done")
+
+		return
+	}
+
+	if !s.fs.IsJobSuccess(v.JobDetail.Status) {
+		code = ErrorFinetuneNoOutput
+		err = errors.New("finetune is not successful")
+
+		return
+	}
+
+	owner := cmd.Index.Owner
+
+	if !s.model.CanApplyResourceName(owner, cmd.Name) {
+		code = ErrorFinetuneModelExists
+		err = errors.New("the name of model is not available")
+
+		return
+	}
+
+	// the model card is exported even if the finetune service can't
+	// provide the output, so that the model can be completed later.
+	name, data, err := s.fs.DownloadOutput(v.Job.JobId)
+	if err != nil {
+		if !finetune.IsErrorUnsupported(err) {
+			return
+		}
+
+		logrus.Warnf(
+			"export finetune(%s) without output, err:%s",
+			cmd.Index.Id, err.Error(),
+		)
+
+		err = nil
+	}
+
+	m, err := s.model.Create(
+		&ModelCreateCmd{
+			Owner:    owner,
+			Name:     cmd.Name,
+			Desc:     cmd.Desc,
+			Title:    cmd.Title,
+			RepoType: cmd.RepoType,
+			Protocol: cmd.Protocol,
+		},
+		pr,
+	)
+	if err != nil {
+		return
+	}
+
+	dto = FinetuneExportDTO{
+		ModelId: m.Id,
+		Owner:   m.Owner,
+		Name:    m.Name,
+	}
+
+	if data != nil {
+		if dto.File, err = s.uploadOutput(cmd, m.RepoId, name, data); err != nil {
+			return
+		}
+	}
+
+	// the model card is only for reference, so ignore the error.
+	if err1 := s.updateModelCard(cmd, &v, m.RepoId); err1 != nil {
+		logrus.Errorf(
+			"update model card of model(%s) exported from finetune(%s) failed, err:%s",
+			m.Id, cmd.Index.Id, err1.Error(),
+		)
+	}
+
+	return
+}
+
+func (s finetuneExportService) uploadOutput(
+	cmd *FinetuneExportCmd, repoId, name string, data []byte,
+) (string, error) {
+	file, err := domain.NewFilePath(name)
+	if err != nil {
+		return "", err
+	}
+
+	content := base64.StdEncoding.EncodeToString(data)
+
+	err = s.rf.Create(
+		&cmd.User,
+		&platform.RepoFileInfo{
+			RepoId: repoId,
+			Path:   file,
+		},
+		&platform.RepoFileContent{
+			Content:   &content,
+			IsEncoded: true,
+		},
+	)
+
+	return file.FilePath(), err
+}
+
+func (s finetuneExportService) updateModelCard(
+	cmd *FinetuneExportCmd, v *domain.Finetune, repoId string,
+) error {
+	file, err := domain.NewFilePath(modelCardFile)
+	if err != nil {
+		return err
+	}
+
+	content := genFinetuneModelCard(cmd.Name, v)
+
+	return s.rf.Update(
+		&cmd.User,
+		&platform.RepoFileInfo{
+			RepoId: repoId,
+			Path:   file,
+		},
+		&platform.RepoFileContent{
+			Content: &content,
+		},
+	)
+}
+
+func genFinetuneModelCard(name domain.ResourceName, v *domain.Finetune) string {
+	p := v.Param
+
+	b := new(strings.Builder)
+
+	fmt.Fprintf(b, "# %s\n\n", name.ResourceName())
+	fmt.Fprintf(
+		b, "This model is exported from the finetune `%s`.\n\n",
+		v.Name.TrainingName(),
+	)
+
+	b.WriteString("## Finetune Parameters\n\n")
+	b.WriteString("| Parameter | Value |\n")
+	b.WriteString("| --- | --- |\n")
+	fmt.Fprintf(b, "| model | %s |\n", p.Model())
+	fmt.Fprintf(b, "| task | %s |\n", p.Task())
+
+	hs := p.Hyperparameters()
+
+	keys := make([]string, 0, len(hs))
+	for k := range hs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(b, "| %s | %s |\n", k, hs[k])
+	}
+
+	return b.String()
+}
@@ -59,6 +59,14 @@ func (s projectService) Fork(cmd *ProjectForkCmd, pr platform.Repository) (dto P
 
 	s.toProjectDTO(&p, &dto)
 
+	// record the fork for the lineage
+	if s.fork != nil {
+		_ = s.fork.Save(&domain.ProjectFork{
+			From: cmd.From.ResourceIndex(),
+			To:   p.ResourceIndex(),
+		})
+	}
+
 	// create activity
 	r, repoType := p.ResourceObject()
 	ua := genActivityForCreatingResource(r, repoType)
@@ -48,9 +48,32 @@ func (cmd *InferenceCreateCmd) toInference(v *domain.Inference, lastCommit strin
 	v.Project.Owner = cmd.ProjectOwner
 }
 
+type InferenceRestartCmd struct {
+	Index         InferenceIndex
+	ProjectName   domain.ResourceName
+	ResourceLevel string
+}
+
+func (cmd *InferenceRestartCmd) Validate() error {
+	b := cmd.Index.Project.Owner != nil &&
+		cmd.Index.Project.Id != "" &&
+		cmd.Index.LastCommit != "" &&
+		cmd.Index.Id != "" &&
+		cmd.ProjectName != nil
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	return nil
+}
+
 type InferenceService interface {
 	Create(*UserInfo, *InferenceCreateCmd) (InferenceDTO, string, error)
 	Get(info *InferenceIndex) (InferenceDTO, error)
+	Stop(*InferenceIndex) (string, error)
+	Restart(*InferenceRestartCmd) (InferenceDTO, string, error)
+	ListRunning(owner domain.Account) ([]InferenceInstanceDTO, error)
 }
 
 func NewInferenceService(
@@ -81,6 +104,15 @@ type InferenceDTO struct {
 	InstanceId string `json:"inference_id"`
 }
 
+type InferenceInstanceDTO struct {
+	ProjectId   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	LastCommit  string `json:"last_commit"`
+	InstanceId  string `json:"inference_id"`
+	AccessURL   string `json:"access_url"`
+	Expiry      int64  `json:"expiry"`
+}
+
 func (dto *InferenceDTO) hasResult() bool {
 	return dto.InstanceId != ""
 }
@@ -110,6 +142,13 @@ func (s inferenceService) Create(u *UserInfo, cmd *InferenceCreateCmd) (
 	instance := new(domain.Inference)
 	cmd.toInference(instance, sha)
 
+	dto, err = s.start(instance)
+
+	return
+}
+
+// start reuses the available instance of the same commit, or creates a new one.
+func (s inferenceService) start(instance *domain.Inference) (dto InferenceDTO, err error) {
 	dto, version, err := s.check(instance)
 	if err != nil {
 		return
@@ -159,6 +198,120 @@ func (s inferenceService) Get(index *InferenceIndex) (dto InferenceDTO, err erro
 	return
 }
 
+func (s inferenceService) Stop(index *InferenceIndex) (code string, err error) {
+	v, err := s.repo.FindInstance(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceNotFound
+		}
+
+		return
+	}
+
+	if !isInferenceRunning(&v.InferenceDetail) {
+		code = ErrorInferenceNotRunning
+		err = errors.New("the inference instance is not running")
+
+		return
+	}
+
+	err = s.stop(index)
+
+	return
+}
+
+// stop makes the instance expire at once, so that it will not be reused,
+// and then notifies the container manager to release the instance.
+func (s inferenceService) stop(index *InferenceIndex) error {
+	err := s.repo.UpdateDetail(index, &domain.InferenceDetail{Expiry: utils.Now()})
+	if err != nil {
+		return err
+	}
+
+	return s.sender.StopInference(index)
+}
+
+func (s inferenceService) Restart(cmd *InferenceRestartCmd) (
+	dto InferenceDTO, code string, err error,
+) {
+	v, err := s.repo.FindInstance(&cmd.Index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceNotFound
+		}
+
+		return
+	}
+
+	if isInferenceRunning(&v.InferenceDetail) {
+		if err = s.stop(&cmd.Index); err != nil {
+			return
+		}
+	}
+
+	_, version, err := s.repo.FindInstances(&cmd.Index.Project, cmd.Index.LastCommit)
+	if err != nil {
+		return
+	}
+
+	instance := domain.Inference{
+		InferenceInfo: domain.InferenceInfo{
+			InferenceIndex: InferenceIndex{
+				Project:    cmd.Index.Project,
+				LastCommit: cmd.Index.LastCommit,
+			},
+			ProjectName:   cmd.ProjectName,
+			ResourceLevel: cmd.ResourceLevel,
+		},
+	}
+
+	// create a new instance directly, because the failed one
+	// which is restarted will be found by check.
+	if dto.InstanceId, err = s.repo.Save(&instance, version); err != nil {
+		if repository.IsErrorDuplicateCreating(err) {
+			dto, _, err = s.check(&instance)
+		}
+
+		return
+	}
+
+	instance.Id = dto.InstanceId
+	err = s.sender.CreateInference(&instance.InferenceInfo)
+
+	return
+}
+
+func (s inferenceService) ListRunning(owner domain.Account) ([]InferenceInstanceDTO, error) {
+	v, err := s.repo.FindRunningInstances(owner, utils.Now())
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]InferenceInstanceDTO, 0, len(v))
+	for i := range v {
+		item := &v[i]
+
+		if item.Error != "" {
+			continue
+		}
+
+		r = append(r, InferenceInstanceDTO{
+			ProjectId:   item.Project.Id,
+			ProjectName: item.ProjectName.ResourceName(),
+			LastCommit:  item.LastCommit,
+			InstanceId:  item.Id,
+			AccessURL:   item.AccessURL,
+			Expiry:      item.Expiry,
+		})
+	}
+
+	return r, nil
+}
+
+func isInferenceRunning(detail *domain.InferenceDetail) bool {
+	return detail.Error == "" && detail.Expiry > utils.Now()
+}
+
 func (s inferenceService) check(instance *domain.Inference) (
 	dto InferenceDTO, version int, err error,
 ) {
@@ -172,7 +325,9 @@ func (s inferenceService) check(instance *domain.Inference) (
 	for i := range v {
 		item := &v[i]
 
-		if item.Error != "" {
+		// only the failure of the latest instance matters, because
+		// the failed instance may have been restarted.
+		if item.Error != "" && i == len(v)-1 {
 			dto.Error = item.Error
 			dto.InstanceId = item.Id
 
@@ -219,6 +374,7 @@ func (s inferenceInternalService) UpdateDetail(index *InferenceIndex, detail *In
 type InferenceMessageService interface {
 	CreateInferenceInstance(*domain.InferenceInfo) error
 	ExtendSurvivalTime(*message.InferenceExtendInfo) error
+	StopInferenceInstance(*domain.InferenceIndex) error
 }
 
 func NewInferenceMessageService(
@@ -288,3 +444,7 @@ func (s inferenceMessageService) ExtendSurvivalTime(info *message.InferenceExten
 
 	return s.repo.UpdateDetail(&info.InferenceIndex, &domain.InferenceDetail{Expiry: n})
 }
+
+func (s inferenceMessageService) StopInferenceInstance(index *domain.InferenceIndex) error {
+	return s.manager.Stop(index)
+}
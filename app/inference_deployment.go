@@ -0,0 +1,537 @@
+package app
+
+import (
+	"errors"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/inference"
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	inferenceDeploymentStatusStarting = "starting"
+	inferenceDeploymentStatusRunning  = "running"
+	inferenceDeploymentStatusFailed   = "failed"
+	inferenceDeploymentStatusStopped  = "stopped"
+)
+
+var reCommit = regexp.MustCompile("^[0-9a-f]{7,40}$")
+
+type InferenceDeploymentIndex = domain.InferenceDeploymentIndex
+
+type InferenceDeploymentCreateCmd struct {
+	Project       domain.ResourceIndex
+	ProjectName   domain.ResourceName
+	ResourceLevel string
+
+	// Commit is the latest commit of the inference directory if it is empty.
+	Commit       string
+	InferenceDir domain.Directory
+	BootFile     domain.FilePath
+}
+
+func (cmd *InferenceDeploymentCreateCmd) Validate() error {
+	b := cmd.Project.Owner != nil &&
+		cmd.Project.Id != "" &&
+		cmd.ProjectName != nil &&
+		cmd.InferenceDir != nil &&
+		cmd.BootFile != nil
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	return checkCommit(cmd.Commit)
+}
+
+func (cmd *InferenceDeploymentCreateCmd) repoDirFile() platform.RepoDirFile {
+	return platform.RepoDirFile{
+		RepoName: cmd.ProjectName,
+		Dir:      cmd.InferenceDir,
+		File:     cmd.BootFile,
+	}
+}
+
+type InferenceDeploymentRollCmd struct {
+	Index InferenceDeploymentIndex
+
+	// Commit is the latest commit of the inference directory if it is empty.
+	Commit       string
+	InferenceDir domain.Directory
+	BootFile     domain.FilePath
+}
+
+func (cmd *InferenceDeploymentRollCmd) Validate() error {
+	if cmd.InferenceDir == nil || cmd.BootFile == nil {
+		return errors.New("invalid cmd")
+	}
+
+	return checkCommit(cmd.Commit)
+}
+
+func checkCommit(v string) error {
+	if v != "" && !reCommit.MatchString(v) {
+		return errors.New("invalid commit")
+	}
+
+	return nil
+}
+
+type InferenceDeploymentDTO struct {
+	Id            string `json:"id"`
+	Commit        string `json:"commit"`
+	Status        string `json:"status"`
+	AccessURL     string `json:"access_url,omitempty"`
+	PendingCommit string `json:"pending_commit,omitempty"`
+	Error         string `json:"error,omitempty"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+type InferenceDeploymentService interface {
+	Create(*UserInfo, *InferenceDeploymentCreateCmd) (string, string, error)
+	Roll(*UserInfo, *InferenceDeploymentRollCmd) (string, error)
+	Get(*InferenceDeploymentIndex) (InferenceDeploymentDTO, string, error)
+	List(*domain.ResourceIndex) ([]InferenceDeploymentDTO, error)
+
+	// Start recreates the instance of the deployment after it exits or fails.
+	Start(*InferenceDeploymentIndex) (string, error)
+
+	// Predict returns the status code and body of the response of inference app,
+	// and records the invocation of caller if it is proxied to the instance.
+	Predict(*InferenceDeploymentIndex, domain.Account, []byte) (int, []byte, string, error)
+}
+
+func NewInferenceDeploymentService(
+	p platform.RepoFile,
+	instanceRepo repository.Inference,
+	repo repository.InferenceDeployment,
+	predictor inference.Predictor,
+	usage InferenceUsageService,
+	sender message.Sender,
+	minSurvivalTime int,
+	maxDeploymentNum int,
+) InferenceDeploymentService {
+	return inferenceDeploymentService{
+		is: inferenceService{
+			p:               p,
+			repo:            instanceRepo,
+			sender:          sender,
+			minSurvivalTime: int64(minSurvivalTime),
+		},
+		repo:             repo,
+		usage:            usage,
+		predictor:        predictor,
+		maxDeploymentNum: maxDeploymentNum,
+	}
+}
+
+type inferenceDeploymentService struct {
+	is               inferenceService
+	repo             repository.InferenceDeployment
+	usage            InferenceUsageService
+	predictor        inference.Predictor
+	maxDeploymentNum int
+}
+
+func (s inferenceDeploymentService) Create(u *UserInfo, cmd *InferenceDeploymentCreateCmd) (
+	id string, code string, err error,
+) {
+	v, version, err := s.repo.List(&cmd.Project)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= s.maxDeploymentNum {
+		code = ErrorInferenceDeploymentExccedMaxNum
+		err = errors.New("exceed max inference deployment num")
+
+		return
+	}
+
+	commit, err := s.getCommit(u, cmd.repoDirFile(), cmd.Commit)
+	if err != nil {
+		return
+	}
+
+	now := utils.Now()
+
+	d := domain.InferenceDeployment{
+		Project:       cmd.Project,
+		ProjectName:   cmd.ProjectName,
+		ResourceLevel: cmd.ResourceLevel,
+		Commit:        commit,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	if d.InstanceId, err = s.startInstance(&d, commit); err != nil {
+		return
+	}
+
+	id, err = s.repo.Save(&d, version)
+
+	return
+}
+
+func (s inferenceDeploymentService) Roll(u *UserInfo, cmd *InferenceDeploymentRollCmd) (
+	code string, err error,
+) {
+	d, err := s.repo.Get(&cmd.Index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceDeploymentNotFound
+		}
+
+		return
+	}
+
+	commit, err := s.getCommit(
+		u,
+		platform.RepoDirFile{
+			RepoName: d.ProjectName,
+			Dir:      cmd.InferenceDir,
+			File:     cmd.BootFile,
+		},
+		cmd.Commit,
+	)
+	if err != nil {
+		return
+	}
+
+	if commit == d.Commit {
+		code = ErrorInferenceDeploymentSameCommit
+		err = errors.New("the deployment is serving the commit")
+
+		return
+	}
+
+	instanceId, err := s.startInstance(&d, commit)
+	if err != nil {
+		return
+	}
+
+	d.PendingCommit = commit
+	d.PendingInstanceId = instanceId
+	d.Error = ""
+	d.UpdatedAt = utils.Now()
+
+	err = s.repo.Update(&d)
+
+	return
+}
+
+func (s inferenceDeploymentService) Get(index *InferenceDeploymentIndex) (
+	dto InferenceDeploymentDTO, code string, err error,
+) {
+	d, err := s.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceDeploymentNotFound
+		}
+
+		return
+	}
+
+	instance, err := s.refresh(&d)
+	if err != nil {
+		return
+	}
+
+	dto = s.toInferenceDeploymentDTO(&d, &instance)
+
+	return
+}
+
+func (s inferenceDeploymentService) List(project *domain.ResourceIndex) (
+	[]InferenceDeploymentDTO, error,
+) {
+	v, _, err := s.repo.List(project)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]InferenceDeploymentDTO, len(v))
+	for i := range v {
+		instance, err := s.refresh(&v[i])
+		if err != nil {
+			return nil, err
+		}
+
+		r[i] = s.toInferenceDeploymentDTO(&v[i], &instance)
+	}
+
+	return r, nil
+}
+
+func (s inferenceDeploymentService) Predict(
+	index *InferenceDeploymentIndex, caller domain.Account, body []byte,
+) (
+	status int, resp []byte, code string, err error,
+) {
+	d, err := s.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceDeploymentNotFound
+		}
+
+		return
+	}
+
+	instance, err := s.refresh(&d)
+	if err != nil {
+		return
+	}
+
+	if instance.Id == "" {
+		code = ErrorInferenceDeploymentStopped
+		err = errors.New("the deployment is stopped, start it first")
+
+		return
+	}
+
+	if instance.AccessURL == "" {
+		code = ErrorInferenceDeploymentNotReady
+		err = errors.New("the deployment is not ready")
+
+		return
+	}
+
+	s.extend(&d, &instance)
+
+	invocation := domain.InferenceInvocation{
+		Project:    index.Project,
+		InstanceId: instance.Id,
+		Caller:     caller,
+		CalledAt:   utils.Now(),
+		Status:     domain.InferenceInvocationFailed,
+	}
+
+	start := time.Now()
+
+	status, resp, err = s.predictor.Predict(instance.AccessURL, body)
+
+	invocation.Latency = time.Since(start).Milliseconds()
+	if err == nil && status < http.StatusBadRequest {
+		invocation.Status = domain.InferenceInvocationSuccess
+	}
+
+	s.usage.Record(&invocation)
+
+	return
+}
+
+func (s inferenceDeploymentService) Start(index *InferenceDeploymentIndex) (
+	code string, err error,
+) {
+	d, err := s.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorInferenceDeploymentNotFound
+		}
+
+		return
+	}
+
+	instance, err := s.refresh(&d)
+	if err != nil {
+		return
+	}
+
+	if instance.Id != "" && instance.Error == "" {
+		code = ErrorInferenceDeploymentNotStopped
+		err = errors.New("the deployment is not stopped")
+
+		return
+	}
+
+	if d.InstanceId, err = s.startInstance(&d, d.Commit); err != nil {
+		return
+	}
+
+	d.UpdatedAt = utils.Now()
+
+	err = s.repo.Update(&d)
+
+	return
+}
+
+// refresh promotes the pending instance when it is ready and returns the
+// current instance. The instance is empty if it exits, and it will not be
+// recreated until the deployment is started again.
+func (s inferenceDeploymentService) refresh(d *domain.InferenceDeployment) (
+	instance repository.InferenceSummary, err error,
+) {
+	changed := false
+
+	if d.IsRolling() {
+		if changed, err = s.checkPending(d); err != nil {
+			return
+		}
+	}
+
+	if changed {
+		d.UpdatedAt = utils.Now()
+
+		// the deployment may be refreshed by other request at the same time.
+		if err = s.repo.Update(d); err != nil && !repository.IsErrorConcurrentUpdating(err) {
+			return
+		}
+	}
+
+	instance, err = s.is.repo.FindInstance(&InferenceIndex{
+		Project:    d.Project,
+		Id:         d.InstanceId,
+		LastCommit: d.Commit,
+	})
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			instance, err = repository.InferenceSummary{}, nil
+		}
+
+		return
+	}
+
+	if instance.Expiry > 0 && instance.Expiry <= utils.Now() {
+		instance = repository.InferenceSummary{}
+	}
+
+	return
+}
+
+// extend keeps the instance alive when it is about to exit.
+func (s inferenceDeploymentService) extend(
+	d *domain.InferenceDeployment, instance *repository.InferenceSummary,
+) {
+	if instance.Expiry >= utils.Now()+s.is.minSurvivalTime {
+		return
+	}
+
+	info := d.InferenceInfo(d.Commit)
+	info.Id = d.InstanceId
+
+	err := s.is.sender.ExtendInferenceSurvivalTime(&message.InferenceExtendInfo{
+		InferenceInfo: info,
+		Expiry:        instance.Expiry,
+	})
+	if err != nil {
+		logrus.Errorf(
+			"extend instance(%s) of inference deployment(%s) failed, err:%s",
+			d.InstanceId, d.Id, err.Error(),
+		)
+	}
+}
+
+func (s inferenceDeploymentService) checkPending(d *domain.InferenceDeployment) (bool, error) {
+	v, err := s.is.repo.FindInstance(&InferenceIndex{
+		Project:    d.Project,
+		Id:         d.PendingInstanceId,
+		LastCommit: d.PendingCommit,
+	})
+	if err != nil && !repository.IsErrorResourceNotExists(err) {
+		return false, err
+	}
+
+	switch {
+	case err != nil:
+		d.Error = "the instance of pending commit is not found"
+
+	case v.Error != "":
+		d.Error = v.Error
+
+	case v.AccessURL != "":
+		d.Commit = d.PendingCommit
+		d.InstanceId = d.PendingInstanceId
+
+	default:
+		return false, nil
+	}
+
+	d.PendingCommit = ""
+	d.PendingInstanceId = ""
+
+	return true, nil
+}
+
+// getCommit checks the boot file at the commit and returns it,
+// or the latest commit of the inference directory if it is empty.
+func (s inferenceDeploymentService) getCommit(
+	u *UserInfo, f platform.RepoDirFile, commit string,
+) (string, error) {
+	f.Ref = commit
+
+	sha, b, err := s.is.p.GetDirFileInfo(u, &f)
+	if err != nil {
+		return "", err
+	}
+
+	if !b {
+		return "", ErrorUnavailableRepoFile{
+			errors.New("no boot file"),
+		}
+	}
+
+	if commit == "" {
+		return sha, nil
+	}
+
+	return commit, nil
+}
+
+func (s inferenceDeploymentService) startInstance(
+	d *domain.InferenceDeployment, commit string,
+) (string, error) {
+	instance := domain.Inference{
+		InferenceInfo: d.InferenceInfo(commit),
+	}
+
+	dto, err := s.is.start(&instance)
+	if err != nil {
+		return "", err
+	}
+
+	if dto.Error != "" {
+		return "", errors.New(dto.Error)
+	}
+
+	return dto.InstanceId, nil
+}
+
+func (s inferenceDeploymentService) toInferenceDeploymentDTO(
+	d *domain.InferenceDeployment, instance *repository.InferenceSummary,
+) InferenceDeploymentDTO {
+	dto := InferenceDeploymentDTO{
+		Id:            d.Id,
+		Commit:        d.Commit,
+		AccessURL:     instance.AccessURL,
+		PendingCommit: d.PendingCommit,
+		Error:         d.Error,
+		CreatedAt:     utils.ToDate(d.CreatedAt),
+		UpdatedAt:     utils.ToDate(d.UpdatedAt),
+	}
+
+	switch {
+	case instance.Id == "":
+		dto.Status = inferenceDeploymentStatusStopped
+
+	case instance.Error != "":
+		dto.Status = inferenceDeploymentStatusFailed
+		dto.Error = instance.Error
+
+	case instance.AccessURL != "":
+		dto.Status = inferenceDeploymentStatusRunning
+
+	default:
+		dto.Status = inferenceDeploymentStatusStarting
+	}
+
+	return dto
+}
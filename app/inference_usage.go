@@ -0,0 +1,183 @@
+package app
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	topInferenceCallerNum   = 10
+	inferenceVisitorAccount = "visitor"
+)
+
+type InferenceUsageCmd struct {
+	Project domain.ResourceIndex
+	Days    int
+}
+
+func (cmd *InferenceUsageCmd) Validate(maxDays int) error {
+	if cmd.Days <= 0 || cmd.Days > maxDays {
+		return errors.New("invalid days")
+	}
+
+	return nil
+}
+
+type InferenceUsageDTO struct {
+	Daily      []InferenceDailyUsageDTO  `json:"daily"`
+	TopCallers []InferenceCallerUsageDTO `json:"top_callers"`
+}
+
+type InferenceDailyUsageDTO struct {
+	Date    string `json:"date"`
+	Total   int    `json:"total"`
+	Failed  int    `json:"failed"`
+	Callers int    `json:"callers"`
+
+	// the unit of latency is millisecond
+	AvgLatency int64 `json:"avg_latency"`
+	MaxLatency int64 `json:"max_latency"`
+}
+
+type InferenceCallerUsageDTO struct {
+	Account string `json:"account"`
+	Total   int    `json:"total"`
+	Failed  int    `json:"failed"`
+}
+
+type InferenceUsageService interface {
+	// Record saves the invocation and it will not block the inference if failed.
+	Record(*domain.InferenceInvocation)
+	Get(*InferenceUsageCmd) (InferenceUsageDTO, error)
+}
+
+func NewInferenceUsageService(
+	repo repository.InferenceUsage,
+	sender message.Sender,
+) InferenceUsageService {
+	return inferenceUsageService{
+		repo:   repo,
+		sender: sender,
+	}
+}
+
+type inferenceUsageService struct {
+	repo   repository.InferenceUsage
+	sender message.Sender
+}
+
+func (s inferenceUsageService) Record(v *domain.InferenceInvocation) {
+	if err := s.repo.Add(v); err != nil {
+		logrus.Errorf(
+			"record invocation of inference(%s/%s) failed, err:%s",
+			v.Project.Owner.Account(), v.Project.Id, err.Error(),
+		)
+	}
+
+	_ = s.sender.AddOperateLogForInference(v)
+}
+
+func (s inferenceUsageService) Get(cmd *InferenceUsageCmd) (dto InferenceUsageDTO, err error) {
+	now := time.Unix(utils.Now(), 0)
+	start := time.Date(
+		now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location(),
+	).AddDate(0, 0, 1-cmd.Days)
+
+	v, err := s.repo.List(&cmd.Project, start.Unix())
+	if err != nil {
+		return
+	}
+
+	dto.Daily = make([]InferenceDailyUsageDTO, cmd.Days)
+	days := make(map[string]*InferenceDailyUsageDTO, cmd.Days)
+	for i := range dto.Daily {
+		item := &dto.Daily[i]
+		item.Date = utils.ToDate(start.AddDate(0, 0, i).Unix())
+		days[item.Date] = item
+	}
+
+	latency := make(map[string]int64, cmd.Days)
+	dailyCallers := make(map[string]map[string]bool, cmd.Days)
+	callers := map[string]*InferenceCallerUsageDTO{}
+
+	for i := range v {
+		item := &v[i]
+
+		date := utils.ToDate(item.CalledAt)
+		day, ok := days[date]
+		if !ok {
+			continue
+		}
+
+		account := inferenceVisitorAccount
+		if item.Caller != nil {
+			account = item.Caller.Account()
+		}
+
+		day.Total++
+		latency[date] += item.Latency
+		if item.Latency > day.MaxLatency {
+			day.MaxLatency = item.Latency
+		}
+
+		if dailyCallers[date] == nil {
+			dailyCallers[date] = map[string]bool{}
+		}
+		dailyCallers[date][account] = true
+
+		caller := callers[account]
+		if caller == nil {
+			caller = &InferenceCallerUsageDTO{Account: account}
+			callers[account] = caller
+		}
+		caller.Total++
+
+		if !item.IsSuccess() {
+			day.Failed++
+			caller.Failed++
+		}
+	}
+
+	for date, day := range days {
+		day.Callers = len(dailyCallers[date])
+
+		if day.Total > 0 {
+			day.AvgLatency = latency[date] / int64(day.Total)
+		}
+	}
+
+	dto.TopCallers = s.topCallers(callers)
+
+	return
+}
+
+func (s inferenceUsageService) topCallers(
+	callers map[string]*InferenceCallerUsageDTO,
+) []InferenceCallerUsageDTO {
+	r := make([]InferenceCallerUsageDTO, 0, len(callers))
+	for _, v := range callers {
+		r = append(r, *v)
+	}
+
+	sort.Slice(r, func(i, j int) bool {
+		if r[i].Total != r[j].Total {
+			return r[i].Total > r[j].Total
+		}
+
+		return r[i].Account < r[j].Account
+	})
+
+	if len(r) > topInferenceCallerNum {
+		r = r[:topInferenceCallerNum]
+	}
+
+	return r
+}
@@ -0,0 +1,734 @@
+package app
+
+import (
+	"errors"
+	"strings"
+
+	competitionrepo "github.com/opensourceways/xihe-server/competition/domain/repository"
+	courserepo "github.com/opensourceways/xihe-server/course/domain/repository"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+const (
+	LineageNodeTypeCompetition = "competition"
+	LineageNodeTypeCourse      = "course"
+
+	lineageRelationRelated     = "related"
+	lineageRelationTraining    = "training_input"
+	lineageRelationFork        = "fork"
+	lineageRelationPublished   = "published"
+	lineageRelationCompetition = "competition"
+	lineageRelationCourse      = "course"
+
+	defaultLineageDepth = 2
+	maxLineageNodeNum   = 200
+)
+
+// LineageNode is a project, model, dataset, competition or course.
+// The Owner is nil for the competition and course.
+type LineageNode struct {
+	Type  string
+	Owner domain.Account
+	Id    string
+}
+
+func (n *LineageNode) key() string {
+	if n.Owner == nil {
+		return n.Type + "/" + n.Id
+	}
+
+	return n.Type + "/" + n.Owner.Account() + "/" + n.Id
+}
+
+func newResourceLineageNode(t domain.ResourceType, index *domain.ResourceIndex) LineageNode {
+	return LineageNode{
+		Type:  t.ResourceType(),
+		Owner: index.Owner,
+		Id:    index.Id,
+	}
+}
+
+type LineageGetCmd struct {
+	Node LineageNode
+
+	// CanRead checks whether the visitor can read the private resource,
+	// it is nil if the visitor does not login.
+	CanRead    func(*domain.ResourceObject) bool
+	Upstream   bool
+	Downstream bool
+	Depth      int
+}
+
+func (cmd *LineageGetCmd) Validate(maxDepth int) error {
+	if cmd.Node.Id == "" || (!cmd.Upstream && !cmd.Downstream) {
+		return errors.New("invalid cmd of getting lineage")
+	}
+
+	switch cmd.Node.Type {
+	case LineageNodeTypeCompetition, LineageNodeTypeCourse:
+		cmd.Node.Owner = nil
+
+	case domain.ResourceTypeProject.ResourceType(),
+		domain.ResourceTypeModel.ResourceType(),
+		domain.ResourceTypeDataset.ResourceType():
+
+		if cmd.Node.Owner == nil {
+			return errors.New("missing owner")
+		}
+
+	default:
+		return errors.New("unknown type of lineage node")
+	}
+
+	if cmd.Depth <= 0 {
+		cmd.Depth = defaultLineageDepth
+	}
+
+	if cmd.Depth > maxDepth {
+		return errors.New("exceed max depth")
+	}
+
+	return nil
+}
+
+type LineageGraphDTO struct {
+	Root  string           `json:"root"`
+	Nodes []LineageNodeDTO `json:"nodes"`
+	Edges []LineageEdgeDTO `json:"edges"`
+
+	// Truncated is true if the graph is too large to return completely.
+	Truncated bool `json:"truncated"`
+}
+
+type LineageNodeDTO struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Owner string `json:"owner,omitempty"`
+	Id    string `json:"id"`
+	Name  string `json:"name"`
+}
+
+// LineageEdgeDTO points from the upstream node to the downstream one.
+type LineageEdgeDTO struct {
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Relation string `json:"relation"`
+}
+
+type LineageService interface {
+	Get(*LineageGetCmd) (LineageGraphDTO, string, error)
+}
+
+func NewLineageService(
+	project repository.Project,
+	model repository.Model,
+	dataset repository.Dataset,
+	training repository.Training,
+	lineage repository.ModelLineage,
+	fork repository.ProjectFork,
+	competition competitionrepo.Competition,
+	work competitionrepo.Work,
+	course courserepo.Course,
+	coursePlayer courserepo.Player,
+) LineageService {
+	return lineageService{
+		project:      project,
+		model:        model,
+		dataset:      dataset,
+		training:     training,
+		lineage:      lineage,
+		fork:         fork,
+		competition:  competition,
+		work:         work,
+		course:       course,
+		coursePlayer: coursePlayer,
+	}
+}
+
+type lineageService struct {
+	project      repository.Project
+	model        repository.Model
+	dataset      repository.Dataset
+	training     repository.Training
+	lineage      repository.ModelLineage
+	fork         repository.ProjectFork
+	competition  competitionrepo.Competition
+	work         competitionrepo.Work
+	course       courserepo.Course
+	coursePlayer courserepo.Player
+}
+
+// lineageResource is the loaded lineage node.
+type lineageResource struct {
+	LineageNode
+
+	name    string
+	private bool
+
+	project *domain.Project
+	model   *domain.Model
+	dataset *domain.Dataset
+}
+
+type lineageNeighbor struct {
+	node     LineageNode
+	relation string
+}
+
+type lineageGraph struct {
+	canRead func(*domain.ResourceObject) bool
+
+	nodes     map[string]*lineageResource
+	invisible map[string]bool
+	edges     map[LineageEdgeDTO]bool
+	order     []string
+	truncated bool
+}
+
+func (s lineageService) Get(cmd *LineageGetCmd) (dto LineageGraphDTO, code string, err error) {
+	g := lineageGraph{
+		canRead:   cmd.CanRead,
+		nodes:     map[string]*lineageResource{},
+		invisible: map[string]bool{},
+		edges:     map[LineageEdgeDTO]bool{},
+	}
+
+	root, err := s.visit(&g, &cmd.Node)
+	if err != nil {
+		return
+	}
+
+	if root == nil {
+		code = ErrorLineageNodeNotFound
+		err = errors.New("lineage node not found")
+
+		return
+	}
+
+	if cmd.Upstream {
+		if err = s.traverse(&g, root, cmd.Depth, true); err != nil {
+			return
+		}
+	}
+
+	if cmd.Downstream {
+		if err = s.traverse(&g, root, cmd.Depth, false); err != nil {
+			return
+		}
+	}
+
+	dto.Root = root.key()
+	dto.Truncated = g.truncated
+
+	dto.Nodes = make([]LineageNodeDTO, 0, len(g.order))
+	for _, k := range g.order {
+		n := g.nodes[k]
+
+		item := LineageNodeDTO{
+			Key:  k,
+			Type: n.Type,
+			Id:   n.Id,
+			Name: n.name,
+		}
+
+		if n.Owner != nil {
+			item.Owner = n.Owner.Account()
+		}
+
+		dto.Nodes = append(dto.Nodes, item)
+	}
+
+	dto.Edges = make([]LineageEdgeDTO, 0, len(g.edges))
+	for e := range g.edges {
+		dto.Edges = append(dto.Edges, e)
+	}
+
+	return
+}
+
+// traverse walks the graph breadth first in one direction.
+func (s lineageService) traverse(
+	g *lineageGraph, root *lineageResource, depth int, upstream bool,
+) error {
+	visited := map[string]bool{root.key(): true}
+	current := []*lineageResource{root}
+
+	for i := 0; i < depth && len(current) > 0; i++ {
+		next := []*lineageResource{}
+
+		for _, r := range current {
+			var neighbors []lineageNeighbor
+			var err error
+
+			if upstream {
+				neighbors, err = s.upstream(r)
+			} else {
+				neighbors, err = s.downstream(r)
+			}
+
+			if err != nil {
+				return err
+			}
+
+			for j := range neighbors {
+				item := &neighbors[j]
+
+				v, err := s.visit(g, &item.node)
+				if err != nil {
+					return err
+				}
+
+				if v == nil {
+					continue
+				}
+
+				if upstream {
+					g.addEdge(v, r, item.relation)
+				} else {
+					g.addEdge(r, v, item.relation)
+				}
+
+				if k := v.key(); !visited[k] {
+					visited[k] = true
+					next = append(next, v)
+				}
+			}
+		}
+
+		current = next
+	}
+
+	return nil
+}
+
+func (g *lineageGraph) addEdge(from, to *lineageResource, relation string) {
+	g.edges[LineageEdgeDTO{
+		From:     from.key(),
+		To:       to.key(),
+		Relation: relation,
+	}] = true
+}
+
+// visit loads the node and returns nil if the node does not exist,
+// is invisible to the visitor or the graph is full.
+func (s lineageService) visit(g *lineageGraph, node *LineageNode) (*lineageResource, error) {
+	k := node.key()
+
+	if v, ok := g.nodes[k]; ok {
+		return v, nil
+	}
+
+	if g.invisible[k] {
+		return nil, nil
+	}
+
+	if len(g.nodes) >= maxLineageNodeNum {
+		g.truncated = true
+
+		return nil, nil
+	}
+
+	v, err := s.load(node)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			g.invisible[k] = true
+
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if v.private && !g.isVisible(&v) {
+		g.invisible[k] = true
+
+		return nil, nil
+	}
+
+	g.nodes[k] = &v
+	g.order = append(g.order, k)
+
+	return &v, nil
+}
+
+// isVisible checks whether the visitor can read the private resource.
+func (g *lineageGraph) isVisible(v *lineageResource) bool {
+	if g.canRead == nil {
+		return false
+	}
+
+	t, err := domain.NewResourceType(v.Type)
+	if err != nil {
+		return false
+	}
+
+	return g.canRead(&domain.ResourceObject{
+		Type: t,
+		ResourceIndex: domain.ResourceIndex{
+			Owner: v.Owner,
+			Id:    v.Id,
+		},
+	})
+}
+
+func (s lineageService) load(node *LineageNode) (r lineageResource, err error) {
+	r.LineageNode = *node
+
+	switch node.Type {
+	case domain.ResourceTypeProject.ResourceType():
+		var v domain.Project
+		if v, err = s.project.Get(node.Owner, node.Id); err == nil {
+			r.name = v.Name.ResourceName()
+			r.private = v.IsPrivate()
+			r.project = &v
+		}
+
+	case domain.ResourceTypeModel.ResourceType():
+		var v domain.Model
+		if v, err = s.model.Get(node.Owner, node.Id); err == nil {
+			r.name = v.Name.ResourceName()
+			r.private = v.IsPrivate()
+			r.model = &v
+		}
+
+	case domain.ResourceTypeDataset.ResourceType():
+		var v domain.Dataset
+		if v, err = s.dataset.Get(node.Owner, node.Id); err == nil {
+			r.name = v.Name.ResourceName()
+			r.private = v.IsPrivate()
+			r.dataset = &v
+		}
+
+	case LineageNodeTypeCompetition:
+		v, err1 := s.competition.FindCompetition(node.Id)
+		if err = err1; err == nil {
+			r.name = v.Name.CompetitionName()
+		}
+
+	case LineageNodeTypeCourse:
+		v, err1 := s.course.FindCourse(node.Id)
+		if err = err1; err == nil {
+			r.name = v.Name.CourseName()
+		}
+
+	default:
+		err = errors.New("unknown type of lineage node")
+	}
+
+	return
+}
+
+func (s lineageService) upstream(r *lineageResource) (v []lineageNeighbor, err error) {
+	switch {
+	case r.project != nil:
+		p := r.project
+
+		v = s.appendRelated(v, domain.ResourceTypeModel, p.RelatedModels)
+		v = s.appendRelated(v, domain.ResourceTypeDataset, p.RelatedDatasets)
+
+		if v, err = s.appendTrainingInputs(v, p); err != nil {
+			return
+		}
+
+		if v, err = s.appendForkSource(v, p); err != nil {
+			return
+		}
+
+		v, err = s.appendActivities(v, p)
+
+	case r.model != nil:
+		m := r.model
+
+		v = s.appendRelated(v, domain.ResourceTypeDataset, m.RelatedDatasets)
+
+		index := m.ResourceIndex()
+
+		var lineages []domain.ModelLineage
+		if lineages, _, err = s.lineage.List(&index); err != nil {
+			return
+		}
+
+		for i := range lineages {
+			v = append(v, lineageNeighbor{
+				node:     newResourceLineageNode(domain.ResourceTypeProject, &lineages[i].Project),
+				relation: lineageRelationPublished,
+			})
+		}
+	}
+
+	return
+}
+
+func (s lineageService) downstream(r *lineageResource) (v []lineageNeighbor, err error) {
+	switch {
+	case r.dataset != nil:
+		d := r.dataset
+
+		v = s.appendRelated(v, domain.ResourceTypeModel, d.RelatedModels)
+		v = s.appendRelated(v, domain.ResourceTypeProject, d.RelatedProjects)
+		v, err = s.appendTrainingUsers(v, d.Owner, d.RepoId)
+
+	case r.model != nil:
+		m := r.model
+
+		v = s.appendRelated(v, domain.ResourceTypeProject, m.RelatedProjects)
+		v, err = s.appendTrainingUsers(v, m.Owner, m.RepoId)
+
+	case r.project != nil:
+		index := r.project.ResourceIndex()
+
+		var forks, models []domain.ResourceIndex
+
+		if forks, err = s.fork.FindForks(&index); err != nil {
+			return
+		}
+
+		for i := range forks {
+			v = append(v, lineageNeighbor{
+				node:     newResourceLineageNode(domain.ResourceTypeProject, &forks[i]),
+				relation: lineageRelationFork,
+			})
+		}
+
+		if models, err = s.lineage.FindModels(&index); err != nil {
+			return
+		}
+
+		for i := range models {
+			v = append(v, lineageNeighbor{
+				node:     newResourceLineageNode(domain.ResourceTypeModel, &models[i]),
+				relation: lineageRelationPublished,
+			})
+		}
+
+	case r.Type == LineageNodeTypeCompetition:
+		var repos []string
+		if repos, err = s.findCompetitionRepos(r.Id); err != nil {
+			return
+		}
+
+		v, err = s.appendProjectsOfRepos(v, repos, lineageRelationCompetition)
+
+	case r.Type == LineageNodeTypeCourse:
+		var repos []string
+		if repos, err = s.coursePlayer.FindReposOfCourse(r.Id); err != nil {
+			return
+		}
+
+		v, err = s.appendProjectsOfRepos(v, repos, lineageRelationCourse)
+	}
+
+	return
+}
+
+func (s lineageService) findCompetitionRepos(cid string) ([]string, error) {
+	works, err := s.work.FindWorks(cid)
+	if err != nil {
+		return nil, err
+	}
+
+	r := make([]string, 0, len(works))
+	for i := range works {
+		if works[i].Repo != "" {
+			r = append(r, works[i].Repo)
+		}
+	}
+
+	return r, nil
+}
+
+func (s lineageService) appendRelated(
+	v []lineageNeighbor, t domain.ResourceType, related domain.RelatedResources,
+) []lineageNeighbor {
+	for i := range related {
+		v = append(v, lineageNeighbor{
+			node:     newResourceLineageNode(t, &related[i]),
+			relation: lineageRelationRelated,
+		})
+	}
+
+	return v
+}
+
+// appendTrainingInputs appends the models and datasets used by the trainings of project.
+func (s lineageService) appendTrainingInputs(
+	v []lineageNeighbor, p *domain.Project,
+) ([]lineageNeighbor, error) {
+	trainings, _, err := s.training.List(p.Owner, p.Id)
+	if err != nil {
+		return v, err
+	}
+
+	for i := range trainings {
+		cfg, err := s.training.GetTrainingConfig(&domain.TrainingIndex{
+			Project:    p.ResourceIndex(),
+			TrainingId: trainings[i].Id,
+		})
+		if err != nil {
+			if repository.IsErrorResourceNotExists(err) {
+				continue
+			}
+
+			return v, err
+		}
+
+		for j := range cfg.Inputs {
+			ref := &cfg.Inputs[j].ResourceRef
+
+			var summary domain.ResourceSummary
+
+			t := ref.Type.ResourceType()
+			switch t {
+			case domain.ResourceTypeModel.ResourceType():
+				summary, err = s.model.GetSummaryByRepoId(ref.User, ref.RepoId)
+
+			case domain.ResourceTypeDataset.ResourceType():
+				summary, err = s.dataset.GetSummaryByRepoId(ref.User, ref.RepoId)
+
+			default:
+				continue
+			}
+
+			if err != nil {
+				if repository.IsErrorResourceNotExists(err) {
+					continue
+				}
+
+				return v, err
+			}
+
+			v = append(v, lineageNeighbor{
+				node: LineageNode{
+					Type:  t,
+					Owner: summary.Owner,
+					Id:    summary.Id,
+				},
+				relation: lineageRelationTraining,
+			})
+		}
+	}
+
+	return v, nil
+}
+
+// appendTrainingUsers appends the projects whose trainings use the repo.
+func (s lineageService) appendTrainingUsers(
+	v []lineageNeighbor, owner domain.Account, repoId string,
+) ([]lineageNeighbor, error) {
+	projects, err := s.training.FindProjectsByInput(owner, repoId)
+	if err != nil {
+		return v, err
+	}
+
+	for i := range projects {
+		v = append(v, lineageNeighbor{
+			node:     newResourceLineageNode(domain.ResourceTypeProject, &projects[i]),
+			relation: lineageRelationTraining,
+		})
+	}
+
+	return v, nil
+}
+
+func (s lineageService) appendForkSource(
+	v []lineageNeighbor, p *domain.Project,
+) ([]lineageNeighbor, error) {
+	index := p.ResourceIndex()
+
+	src, err := s.fork.FindSource(&index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			err = nil
+		}
+
+		return v, err
+	}
+
+	return append(v, lineageNeighbor{
+		node:     newResourceLineageNode(domain.ResourceTypeProject, &src),
+		relation: lineageRelationFork,
+	}), nil
+}
+
+// appendActivities appends the competitions and courses which the project is related to.
+func (s lineageService) appendActivities(
+	v []lineageNeighbor, p *domain.Project,
+) ([]lineageNeighbor, error) {
+	repo := p.Owner.Account() + "/" + p.Name.ResourceName()
+
+	works, err := s.work.FindWorksByRepo(repo)
+	if err != nil {
+		return v, err
+	}
+
+	for i := range works {
+		v = append(v, lineageNeighbor{
+			node: LineageNode{
+				Type: LineageNodeTypeCompetition,
+				Id:   works[i].CompetitionId,
+			},
+			relation: lineageRelationCompetition,
+		})
+	}
+
+	courses, err := s.coursePlayer.FindCoursesOfRepo(repo)
+	if err != nil {
+		return v, err
+	}
+
+	for _, cid := range courses {
+		v = append(v, lineageNeighbor{
+			node: LineageNode{
+				Type: LineageNodeTypeCourse,
+				Id:   cid,
+			},
+			relation: lineageRelationCourse,
+		})
+	}
+
+	return v, nil
+}
+
+// appendProjectsOfRepos appends the projects specified by the repos
+// which are in the format of "owner/name".
+func (s lineageService) appendProjectsOfRepos(
+	v []lineageNeighbor, repos []string, relation string,
+) ([]lineageNeighbor, error) {
+	for _, repo := range repos {
+		items := strings.Split(repo, "/")
+		if len(items) != 2 {
+			continue
+		}
+
+		owner, err := domain.NewAccount(items[0])
+		if err != nil {
+			continue
+		}
+
+		name, err := domain.NewResourceName(items[1])
+		if err != nil {
+			continue
+		}
+
+		p, err := s.project.GetSummaryByName(owner, name)
+		if err != nil {
+			if repository.IsErrorResourceNotExists(err) {
+				continue
+			}
+
+			return v, err
+		}
+
+		v = append(v, lineageNeighbor{
+			node: LineageNode{
+				Type:  domain.ResourceTypeProject.ResourceType(),
+				Owner: p.Owner,
+				Id:    p.Id,
+			},
+			relation: relation,
+		})
+	}
+
+	return v, nil
+}
@@ -155,11 +155,13 @@ func NewProjectService(
 	model repository.Model,
 	dataset repository.Dataset,
 	activity repository.Activity,
+	fork repository.ProjectFork,
 	pr platform.Repository,
 	sender message.Sender,
 ) ProjectService {
 	return projectService{
 		repo:     repo,
+		fork:     fork,
 		activity: activity,
 		sender:   sender,
 		rs: resourceService{
@@ -173,6 +175,7 @@ func NewProjectService(
 
 type projectService struct {
 	repo repository.Project
+	fork repository.ProjectFork
 	//pr       platform.Repository
 	activity repository.Activity
 	sender   message.Sender
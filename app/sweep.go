@@ -0,0 +1,336 @@
+package app
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type SweepIndex = domain.SweepIndex
+
+type SweepService interface {
+	Create(*SweepCreateCmd) (string, string, error)
+	List(user domain.Account, projectId string) ([]SweepSummaryDTO, error)
+	Get(*SweepIndex) (SweepDTO, string, error)
+}
+
+func NewSweepService(
+	log *logrus.Entry,
+	train training.Training,
+	trainingRepo repository.Training,
+	repo repository.Sweep,
+	metric repository.TrainingMetric,
+	sender message.Sender,
+	maxTrainingRecordNum int,
+) SweepService {
+	s := newSweepService(log, train, trainingRepo, repo, sender, maxTrainingRecordNum)
+	s.metric = metric
+
+	return s
+}
+
+type SweepMessageService interface {
+	HandleTrainingDone(*TrainingIndex) error
+}
+
+func NewSweepMessageService(
+	log *logrus.Entry,
+	train training.Training,
+	trainingRepo repository.Training,
+	repo repository.Sweep,
+	sender message.Sender,
+) SweepMessageService {
+	return newSweepService(log, train, trainingRepo, repo, sender, 0)
+}
+
+func newSweepService(
+	log *logrus.Entry,
+	train training.Training,
+	trainingRepo repository.Training,
+	repo repository.Sweep,
+	sender message.Sender,
+	maxTrainingRecordNum int,
+) sweepService {
+	return sweepService{
+		log:  log,
+		repo: repo,
+		ts: trainingService{
+			log:    log,
+			train:  train,
+			repo:   trainingRepo,
+			sender: sender,
+
+			maxTrainingRecordNum: maxTrainingRecordNum,
+		},
+	}
+}
+
+type sweepService struct {
+	log    *logrus.Entry
+	repo   repository.Sweep
+	metric repository.TrainingMetric
+	ts     trainingService
+}
+
+func (s sweepService) Create(cmd *SweepCreateCmd) (id string, code string, err error) {
+	sweep := cmd.toSweep()
+
+	num := domain.DomainConfig.MaxSweepTrialNum
+	if !sweep.Strategy.IsGrid() {
+		num = cmd.MaxTrials
+	}
+
+	if err = sweep.GenTrials(num); err != nil {
+		code = ErrorSweepInvalidParam
+
+		return
+	}
+
+	// make sure the name of every trial is valid
+	if _, err = sweep.TrialConfig(len(sweep.Trials) - 1); err != nil {
+		code = ErrorSweepInvalidParam
+
+		return
+	}
+
+	v, _, err := s.ts.repo.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return
+	}
+
+	// every trial will be a training of project, so the trials are
+	// counted in the max training num of project.
+	if len(v)+len(sweep.Trials) > s.ts.maxTrainingRecordNum {
+		code = ErrorTrainExccedMaxNum
+		err = errors.New("exceed max training num")
+
+		return
+	}
+
+	_, version, err := s.repo.List(cmd.User, cmd.ProjectId)
+	if err != nil {
+		return
+	}
+
+	if id, err = s.repo.Save(&sweep, version); err != nil {
+		return
+	}
+
+	sweep.Id = id
+
+	if err1 := s.launch(&sweep); err1 != nil {
+		s.log.Errorf("launch trials of sweep(%s) failed, err:%s", id, err1.Error())
+	}
+
+	return
+}
+
+// launch creates the trainings for the pending trials as many as
+// the concurrency allows and saves the trials of sweep.
+// The trainings are scheduled at once without waiting in the queue of
+// project, because the concurrency of sweep has limited them.
+func (s sweepService) launch(sweep *domain.Sweep) (err error) {
+	for _, i := range sweep.TrialsToLaunch() {
+		var cfg TrainingConfig
+		if cfg, err = sweep.TrialConfig(i); err != nil {
+			break
+		}
+
+		var version int
+		if _, version, err = s.ts.repo.List(sweep.Owner, sweep.ProjectId); err != nil {
+			break
+		}
+
+		var tid string
+		tid, err = s.ts.save(sweep.Owner, sweep.ProjectId, &cfg, version, false)
+		if err != nil {
+			break
+		}
+
+		sweep.Trials[i].TrainingId = tid
+	}
+
+	if err1 := s.repo.UpdateTrials(sweep); err1 != nil {
+		return err1
+	}
+
+	return
+}
+
+func (s sweepService) HandleTrainingDone(info *TrainingIndex) error {
+	v, _, err := s.repo.List(info.Project.Owner, info.Project.Id)
+	if err != nil {
+		return err
+	}
+
+	for i := range v {
+		sweep := &v[i]
+
+		if sweep.MarkTrialDone(info.TrainingId) {
+			return s.launch(sweep)
+		}
+	}
+
+	return nil
+}
+
+func (s sweepService) List(user domain.Account, projectId string) ([]SweepSummaryDTO, error) {
+	v, _, err := s.repo.List(user, projectId)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]SweepSummaryDTO, len(v))
+	for i := range v {
+		s.toSweepSummaryDTO(&v[i], &r[i])
+	}
+
+	return r, nil
+}
+
+func (s sweepService) Get(index *SweepIndex) (dto SweepDTO, code string, err error) {
+	sweep, err := s.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorSweepNotFound
+		}
+
+		return
+	}
+
+	v, _, err := s.ts.repo.List(sweep.Owner, sweep.ProjectId)
+	if err != nil {
+		return
+	}
+
+	trainings := make(map[string]*domain.TrainingSummary, len(v))
+	for i := range v {
+		trainings[v[i].Id] = &v[i]
+	}
+
+	s.toSweepSummaryDTO(&sweep, &dto.SweepSummaryDTO)
+
+	dto.Concurrency = sweep.Concurrency
+	dto.Metric = sweep.Metric.Name.CustomizedKey()
+	dto.Goal = sweep.Metric.Goal.SweepGoal()
+	dto.Trials = make([]SweepTrialDTO, len(sweep.Trials))
+
+	for i := range sweep.Trials {
+		trial := &sweep.Trials[i]
+		item := &dto.Trials[i]
+
+		item.Hyperparameters = toKeyValueDTOs(trial.Hyperparameters)
+
+		if trial.IsPending() {
+			item.Status = sweepTrialStatusQueued
+
+			continue
+		}
+
+		t, ok := trainings[trial.TrainingId]
+		if !ok {
+			item.Id = trial.TrainingId
+			item.Status = sweepTrialStatusDeleted
+			item.IsDone = true
+
+			continue
+		}
+
+		s.ts.toTrainingSummaryDTO(t, &item.TrainingSummaryDTO)
+
+		if item.IsDone {
+			item.Value = s.metricValue(&sweep.Metric, &domain.TrainingIndex{
+				Project:    index.Project,
+				TrainingId: t.Id,
+			}, t)
+		}
+	}
+
+	s.rank(&sweep.Metric, dto.Trials)
+
+	return
+}
+
+// metricValue returns nil if the trial has not the metric.
+// The value of a reported metric is the last one of its series.
+func (s sweepService) metricValue(
+	metric *domain.SweepMetric, index *domain.TrainingIndex, t *domain.TrainingSummary,
+) *float64 {
+	name := metric.Name.CustomizedKey()
+
+	if name == domain.SweepMetricDuration {
+		v := float64(t.Duration)
+
+		return &v
+	}
+
+	v, err := s.metric.FindLast(index)
+	if err != nil {
+		s.log.Errorf("find metrics of training(%s) failed, err:%s", t.Id, err.Error())
+
+		return nil
+	}
+
+	for i := range v {
+		if v[i].Name.CustomizedKey() == name {
+			value := v[i].Value
+
+			return &value
+		}
+	}
+
+	return nil
+}
+
+func (s sweepService) rank(metric *domain.SweepMetric, trials []SweepTrialDTO) {
+	ranked := make([]*SweepTrialDTO, 0, len(trials))
+	for i := range trials {
+		if trials[i].Value != nil {
+			ranked = append(ranked, &trials[i])
+		}
+	}
+
+	maximize := metric.Goal.IsMaximize()
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if maximize {
+			return *ranked[i].Value > *ranked[j].Value
+		}
+
+		return *ranked[i].Value < *ranked[j].Value
+	})
+
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+}
+
+func (s sweepService) toSweepSummaryDTO(sweep *domain.Sweep, dto *SweepSummaryDTO) {
+	done := 0
+	for i := range sweep.Trials {
+		if sweep.Trials[i].Done {
+			done++
+		}
+	}
+
+	*dto = SweepSummaryDTO{
+		Id:        sweep.Id,
+		Name:      sweep.Name.TrainingName(),
+		Strategy:  sweep.Strategy.SweepStrategy(),
+		TrialNum:  len(sweep.Trials),
+		DoneNum:   done,
+		IsDone:    sweep.IsDone(),
+		CreatedAt: utils.ToDate(sweep.CreatedAt),
+	}
+
+	if sweep.Desc != nil {
+		dto.Desc = sweep.Desc.TrainingDesc()
+	}
+}
@@ -0,0 +1,124 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	sweepTrialStatusQueued  = "queued"
+	sweepTrialStatusDeleted = "deleted"
+)
+
+type SweepCreateCmd struct {
+	TrainingCreateCmd
+
+	Strategy    domain.SweepStrategy
+	Parameters  []domain.SweepParameter
+	Metric      domain.SweepMetric
+	MaxTrials   int
+	Concurrency int
+}
+
+func (cmd *SweepCreateCmd) Validate() error {
+	if err := cmd.TrainingCreateCmd.Validate(); err != nil {
+		return err
+	}
+
+	err := errors.New("invalid cmd of creating sweep")
+
+	if cmd.Strategy == nil || cmd.Metric.Name == nil || cmd.Metric.Goal == nil {
+		return err
+	}
+
+	if len(cmd.Parameters) == 0 {
+		return err
+	}
+
+	keys := map[string]bool{}
+	for i := range cmd.Parameters {
+		p := &cmd.Parameters[i]
+
+		if p.Key == nil || len(p.Values) == 0 || keys[p.Key.CustomizedKey()] {
+			return err
+		}
+
+		keys[p.Key.CustomizedKey()] = true
+	}
+
+	cfg := &domain.DomainConfig
+
+	if cmd.Concurrency <= 0 || cmd.Concurrency > cfg.MaxSweepConcurrency {
+		return errors.New("invalid concurrency of sweep")
+	}
+
+	if !cmd.Strategy.IsGrid() && (cmd.MaxTrials <= 0 || cmd.MaxTrials > cfg.MaxSweepTrialNum) {
+		return errors.New("invalid max trials of sweep")
+	}
+
+	return nil
+}
+
+func (cmd *SweepCreateCmd) toSweep() domain.Sweep {
+	return domain.Sweep{
+		Owner:          cmd.User,
+		ProjectId:      cmd.ProjectId,
+		TrainingConfig: cmd.TrainingConfig,
+		Strategy:       cmd.Strategy,
+		Parameters:     cmd.Parameters,
+		Metric:         cmd.Metric,
+		Concurrency:    cmd.Concurrency,
+		CreatedAt:      utils.Now(),
+	}
+}
+
+type SweepSummaryDTO struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	Desc      string `json:"desc"`
+	Strategy  string `json:"strategy"`
+	TrialNum  int    `json:"trial_num"`
+	DoneNum   int    `json:"done_num"`
+	IsDone    bool   `json:"is_done"`
+	CreatedAt string `json:"created_at"`
+}
+
+type SweepDTO struct {
+	SweepSummaryDTO
+
+	Metric      string          `json:"metric"`
+	Goal        string          `json:"goal"`
+	Concurrency int             `json:"concurrency"`
+	Trials      []SweepTrialDTO `json:"trials"`
+}
+
+type SweepTrialDTO struct {
+	TrainingSummaryDTO
+
+	Hyperparameters []KeyValueDTO `json:"hyperparameters"`
+
+	// Rank is 0 if the trial has not the value of metric.
+	Rank  int      `json:"rank"`
+	Value *float64 `json:"value,omitempty"`
+}
+
+type KeyValueDTO struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func toKeyValueDTOs(kv []domain.KeyValue) []KeyValueDTO {
+	r := make([]KeyValueDTO, len(kv))
+
+	for i := range kv {
+		r[i].Key = kv[i].Key.CustomizedKey()
+
+		if kv[i].Value != nil {
+			r[i].Value = kv[i].Value.CustomizedValue()
+		}
+	}
+
+	return r
+}
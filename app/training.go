@@ -13,8 +13,14 @@ import (
 )
 
 const (
+	trainingStatusQueued         = "queued"
+	trainingStatusCanceled       = "canceled"
 	trainingStatusScheduling     = "scheduling"
 	trainingStatusScheduleFailed = "schedule_failed"
+
+	// trainingStatusCompleted is the status reported by the training
+	// center when the job exits successfully.
+	trainingStatusCompleted = "Completed"
 )
 
 type JobDetail = domain.JobDetail
@@ -29,6 +35,8 @@ type TrainingService interface {
 	Get(*TrainingIndex) (TrainingDTO, string, error)
 	Delete(*TrainingIndex) error
 	Terminate(*TrainingIndex) error
+	Cancel(*TrainingIndex) (string, error)
+	DispatchQueued(*domain.ResourceIndex) error
 	GetLogDownloadURL(*TrainingIndex) (string, string, error)
 	GetOutputDownloadURL(*TrainingIndex) (string, string, error)
 	CreateTrainingJob(*TrainingIndex, string, bool) (bool, error)
@@ -38,6 +46,7 @@ func NewTrainingService(
 	log *logrus.Entry,
 	train training.Training,
 	repo repository.Training,
+	metric repository.TrainingMetric,
 	sender message.Sender,
 	maxTrainingRecordNum int,
 ) TrainingService {
@@ -45,6 +54,7 @@ func NewTrainingService(
 		log:    log,
 		train:  train,
 		repo:   repo,
+		metric: metric,
 		sender: sender,
 
 		maxTrainingRecordNum: maxTrainingRecordNum,
@@ -55,13 +65,20 @@ type trainingService struct {
 	log    *logrus.Entry
 	train  training.Training
 	repo   repository.Training
+	metric repository.TrainingMetric
 	sender message.Sender
 
 	maxTrainingRecordNum int
 }
 
 func (s trainingService) isJobDone(status string) bool {
-	return status != "" && (s.train.IsJobDone(status) || status == trainingStatusScheduleFailed)
+	if status == "" || status == trainingStatusQueued {
+		return false
+	}
+
+	return status == trainingStatusScheduleFailed ||
+		status == trainingStatusCanceled ||
+		s.train.IsJobDone(status)
 }
 
 func (s trainingService) Create(cmd *TrainingCreateCmd) (string, error) {
@@ -74,14 +91,25 @@ func (s trainingService) Recreate(info *TrainingIndex) (string, error) {
 		return "", err
 	}
 
-	v.Name, err = domain.NewTrainingName(
+	return s.recreate(info.Project.Owner, info.Project.Id, &v)
+}
+
+// recreate creates the training by the config whose name is suffixed by the time.
+func (s trainingService) recreate(
+	user domain.Account, projectId string, config *TrainingConfig,
+) (string, error) {
+	v := *config
+
+	name, err := domain.NewTrainingName(
 		v.Name.TrainingName() + "-" + strconv.FormatInt(utils.Now(), 10),
 	)
 	if err != nil {
 		return "", err
 	}
 
-	return s.create(info.Project.Owner, info.Project.Id, &v)
+	v.Name = name
+
+	return s.create(user, projectId, &v)
 }
 
 func (s trainingService) create(
@@ -98,14 +126,26 @@ func (s trainingService) create(
 		}
 	}
 
+	// the training will wait in the queue if another one is not done.
+	return s.save(user, projectId, config, version, s.hasUndoneJob(v))
+}
+
+func (s trainingService) hasUndoneJob(v []domain.TrainingSummary) bool {
 	for i := range v {
 		if !s.isJobDone(v[i].Status) {
-			return "", ErrorOnlyOneRunningTraining{
-				errors.New("a training is running"),
-			}
+			return true
 		}
 	}
 
+	return false
+}
+
+// save saves the training and sends the message to schedule its job
+// unless it is queued.
+func (s trainingService) save(
+	user domain.Account, projectId string, config *TrainingConfig,
+	version int, queued bool,
+) (string, error) {
 	t := domain.UserTraining{
 		Owner:          user,
 		ProjectId:      projectId,
@@ -113,28 +153,136 @@ func (s trainingService) create(
 		TrainingConfig: *config,
 	}
 
+	if queued {
+		t.JobDetail.Status = trainingStatusQueued
+	}
+
 	r, err := s.repo.Save(&t, version)
-	if err != nil {
-		return "", err
+	if err != nil || queued {
+		return r, err
 	}
 
-	// send message
-	index := TrainingIndex{
+	s.schedule(&TrainingIndex{
 		Project: domain.ResourceIndex{
 			Owner: user,
 			Id:    projectId,
 		},
 		TrainingId: r,
-	}
+	}, config.Inputs)
+
+	return r, nil
+}
 
+func (s trainingService) schedule(index *TrainingIndex, inputs []domain.Input) {
 	msg := new(message.MsgTraining)
-	msg.TrainingCreate(user, index, config.Inputs)
+	msg.TrainingCreate(index.Project.Owner, *index, inputs)
 
-	if err = s.sender.CreateTraining(msg); err != nil {
+	if err := s.sender.CreateTraining(msg); err != nil {
 		s.log.Errorf("send message of creating training failed, err:%s", err.Error())
 	}
+}
 
-	return r, nil
+// DispatchQueued schedules the earliest queued training of the project
+// if there is no other training which is not done.
+func (s trainingService) DispatchQueued(project *domain.ResourceIndex) (err error) {
+	// retry if the trainings are changed concurrently, such as being canceled.
+	for i := 0; i < 3; i++ {
+		if err = s.dispatchQueued(project); err == nil ||
+			!repository.IsErrorConcurrentUpdating(err) {
+			break
+		}
+	}
+
+	return
+}
+
+func (s trainingService) dispatchQueued(project *domain.ResourceIndex) error {
+	v, version, err := s.repo.List(project.Owner, project.Id)
+	if err != nil {
+		return err
+	}
+
+	var next *domain.TrainingSummary
+	for i := range v {
+		item := &v[i]
+
+		if item.Status == trainingStatusQueued {
+			if next == nil || item.CreatedAt < next.CreatedAt {
+				next = item
+			}
+
+			continue
+		}
+
+		if !s.isJobDone(item.Status) {
+			return nil
+		}
+	}
+
+	if next == nil {
+		return nil
+	}
+
+	index := TrainingIndex{
+		Project:    *project,
+		TrainingId: next.Id,
+	}
+
+	cfg, err := s.repo.GetTrainingConfig(&index)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateJobDetailWithVersion(&index, &JobDetail{}, version); err != nil {
+		return err
+	}
+
+	s.schedule(&index, cfg.Inputs)
+
+	return nil
+}
+
+func (s trainingService) Cancel(info *TrainingIndex) (code string, err error) {
+	v, version, err := s.repo.List(info.Project.Owner, info.Project.Id)
+	if err != nil {
+		return
+	}
+
+	var item *domain.TrainingSummary
+	for i := range v {
+		if v[i].Id == info.TrainingId {
+			item = &v[i]
+
+			break
+		}
+	}
+
+	if item == nil {
+		code = ErrorTrainNotFound
+		err = errors.New("the training does not exist")
+
+		return
+	}
+
+	if item.Status != trainingStatusQueued {
+		code = ErrorTrainNotQueued
+		err = errors.New("the training is not queued")
+
+		return
+	}
+
+	// it fails if the training is dispatched concurrently.
+	err = s.repo.UpdateJobDetailWithVersion(
+		info, &JobDetail{Status: trainingStatusCanceled}, version,
+	)
+	if err != nil {
+		return
+	}
+
+	// the canceled training is done, such as the trial of sweep.
+	s.notifyTrainingDone(info)
+
+	return
 }
 
 func (s trainingService) List(user domain.Account, projectId string) ([]TrainingSummaryDTO, error) {
@@ -148,6 +296,15 @@ func (s trainingService) List(user domain.Account, projectId string) ([]Training
 		s.toTrainingSummaryDTO(&v[i], &r[i])
 	}
 
+	// the trainings are saved in the order of creation.
+	position := 0
+	for i := range r {
+		if r[i].Status == trainingStatusQueued {
+			position++
+			r[i].QueuePosition = position
+		}
+	}
+
 	return r, nil
 }
 
@@ -175,7 +332,28 @@ func (s trainingService) Get(info *TrainingIndex) (dto TrainingDTO, code string,
 }
 
 func (s trainingService) UpdateJobDetail(info *TrainingIndex, v *JobDetail) error {
-	return s.repo.UpdateJobDetail(info, v)
+	if err := s.repo.UpdateJobDetail(info, v); err != nil {
+		return err
+	}
+
+	if s.isJobDone(v.Status) {
+		s.notifyTrainingDone(info)
+	}
+
+	return nil
+}
+
+func (s trainingService) notifyTrainingDone(info *TrainingIndex) {
+	if s.sender == nil {
+		return
+	}
+
+	msg := new(message.MsgTraining)
+	msg.TrainingDone(*info)
+
+	if err := s.sender.NotifyTrainingDone(msg); err != nil {
+		s.log.Errorf("send message of training done failed, err:%s", err.Error())
+	}
 }
 
 func (s trainingService) Delete(info *TrainingIndex) error {
@@ -195,7 +373,18 @@ func (s trainingService) Delete(info *TrainingIndex) error {
 		}
 	}
 
-	return s.repo.Delete(info)
+	if err = s.repo.Delete(info); err != nil {
+		return err
+	}
+
+	if err := s.metric.Delete(info); err != nil {
+		s.log.Errorf("delete metrics of training(%s) failed, err:%s", info.TrainingId, err.Error())
+	}
+
+	// the training will never be done if it is deleted when running.
+	s.notifyTrainingDone(info)
+
+	return nil
 }
 
 func (s trainingService) Terminate(info *TrainingIndex) error {
@@ -252,7 +441,7 @@ func (s trainingService) CreateTrainingJob(
 	}
 
 	if lastChance {
-		s.repo.UpdateJobDetail(info, &JobDetail{
+		s.UpdateJobDetail(info, &JobDetail{
 			Status: trainingStatusScheduleFailed,
 			Error:  err.Error(),
 		})
@@ -277,7 +466,8 @@ func (s trainingService) createTrainingJob(info *TrainingIndex, endpoint string)
 		return
 	}
 
-	if data.Job.JobId != "" {
+	// the training may be canceled before it is scheduled.
+	if data.Job.JobId != "" || data.JobDetail.Status == trainingStatusCanceled {
 		return false, nil
 	}
 
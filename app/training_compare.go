@@ -0,0 +1,314 @@
+package app
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	minTrainingCompareNum = 2
+	maxTrainingCompareNum = 10
+
+	trainingConfigFieldCodeDir         = "code_dir"
+	trainingConfigFieldBootFile        = "boot_file"
+	trainingConfigFieldComputeType     = "compute_type"
+	trainingConfigFieldComputeFlavor   = "compute_flavor"
+	trainingConfigFieldComputeVersion  = "compute_version"
+	trainingConfigFieldHyperparameters = "hyperparameters"
+	trainingConfigFieldEnv             = "env"
+	trainingConfigFieldInputs          = "inputs"
+)
+
+type TrainingCompareService interface {
+	Compare(*TrainingCompareCmd) (TrainingCompareDTO, string, error)
+}
+
+func NewTrainingCompareService(
+	train training.Training,
+	repo repository.Training,
+	metric repository.TrainingMetric,
+) TrainingCompareService {
+	return trainingCompareService{
+		ts: trainingService{
+			train: train,
+			repo:  repo,
+		},
+		metric: metric,
+	}
+}
+
+type trainingCompareService struct {
+	ts     trainingService
+	metric repository.TrainingMetric
+}
+
+func (s trainingCompareService) Compare(cmd *TrainingCompareCmd) (
+	dto TrainingCompareDTO, code string, err error,
+) {
+	n := len(cmd.TrainingIds)
+	trainings := make([]domain.UserTraining, n)
+	indexes := make([]TrainingIndex, n)
+
+	for i, id := range cmd.TrainingIds {
+		indexes[i] = TrainingIndex{
+			Project:    cmd.Project,
+			TrainingId: id,
+		}
+
+		if trainings[i], err = s.ts.repo.Get(&indexes[i]); err != nil {
+			if repository.IsErrorResourceNotExists(err) {
+				code = ErrorTrainNotFound
+			}
+
+			return
+		}
+	}
+
+	dto.Trainings = make([]TrainingCompareItemDTO, len(trainings))
+	for i := range trainings {
+		err = s.toTrainingCompareItemDTO(&indexes[i], &trainings[i], &dto.Trainings[i])
+		if err != nil {
+			return
+		}
+	}
+
+	dto.Config = s.diff(trainings)
+
+	return
+}
+
+func (s trainingCompareService) toTrainingCompareItemDTO(
+	index *TrainingIndex, t *domain.UserTraining, dto *TrainingCompareItemDTO,
+) error {
+	detail := &t.JobDetail
+
+	status := detail.Status
+	if status == "" {
+		status = trainingStatusScheduling
+	}
+
+	*dto = TrainingCompareItemDTO{
+		Id:        t.Id,
+		Name:      t.Name.TrainingName(),
+		IsDone:    s.ts.isJobDone(detail.Status),
+		Error:     detail.Error,
+		Status:    status,
+		Duration:  detail.Duration,
+		CreatedAt: utils.ToDate(t.CreatedAt),
+	}
+
+	v, err := s.metric.FindLast(index)
+	if err != nil {
+		return err
+	}
+
+	dto.Metrics = make([]TrainingMetricDTO, len(v))
+	for i := range v {
+		dto.Metrics[i] = TrainingMetricDTO{
+			Name:  v[i].Name.CustomizedKey(),
+			Step:  v[i].Step,
+			Value: v[i].Value,
+		}
+	}
+
+	return nil
+}
+
+// diff returns the value of each field of config for every training
+// and the fields which are different come first.
+func (s trainingCompareService) diff(trainings []domain.UserTraining) []TrainingConfigDiffDTO {
+	r := []TrainingConfigDiffDTO{}
+
+	add := func(field, key string, value func(*domain.TrainingConfig) (string, bool)) {
+		item := TrainingConfigDiffDTO{
+			Field:  field,
+			Key:    key,
+			Values: make([]*string, len(trainings)),
+		}
+
+		for i := range trainings {
+			if v, ok := value(&trainings[i].TrainingConfig); ok {
+				item.Values[i] = &v
+			}
+		}
+
+		item.IsDifferent = s.isDifferent(item.Values)
+
+		r = append(r, item)
+	}
+
+	add(trainingConfigFieldCodeDir, "", func(c *domain.TrainingConfig) (string, bool) {
+		return c.CodeDir.Directory(), true
+	})
+	add(trainingConfigFieldBootFile, "", func(c *domain.TrainingConfig) (string, bool) {
+		return c.BootFile.FilePath(), true
+	})
+	add(trainingConfigFieldComputeType, "", func(c *domain.TrainingConfig) (string, bool) {
+		return c.Compute.Type.ComputeType(), true
+	})
+	add(trainingConfigFieldComputeFlavor, "", func(c *domain.TrainingConfig) (string, bool) {
+		return c.Compute.Flavor.ComputeFlavor(), true
+	})
+	add(trainingConfigFieldComputeVersion, "", func(c *domain.TrainingConfig) (string, bool) {
+		return c.Compute.Version.ComputeVersion(), true
+	})
+
+	kvs := func(field string, get func(*domain.TrainingConfig) []domain.KeyValue) {
+		keys := map[string]bool{}
+		for i := range trainings {
+			for _, kv := range get(&trainings[i].TrainingConfig) {
+				keys[kv.Key.CustomizedKey()] = true
+			}
+		}
+
+		for _, k := range s.sortedKeys(keys) {
+			key := k
+
+			add(field, key, func(c *domain.TrainingConfig) (string, bool) {
+				for _, kv := range get(c) {
+					if kv.Key.CustomizedKey() == key {
+						if kv.Value == nil {
+							return "", true
+						}
+
+						return kv.Value.CustomizedValue(), true
+					}
+				}
+
+				return "", false
+			})
+		}
+	}
+
+	kvs(trainingConfigFieldHyperparameters, func(c *domain.TrainingConfig) []domain.KeyValue {
+		return c.Hyperparameters
+	})
+	kvs(trainingConfigFieldEnv, func(c *domain.TrainingConfig) []domain.KeyValue {
+		return c.Env
+	})
+
+	keys := map[string]bool{}
+	for i := range trainings {
+		for _, v := range trainings[i].Inputs {
+			keys[v.Key.CustomizedKey()] = true
+		}
+	}
+
+	for _, k := range s.sortedKeys(keys) {
+		key := k
+
+		add(trainingConfigFieldInputs, key, func(c *domain.TrainingConfig) (string, bool) {
+			for i := range c.Inputs {
+				if v := &c.Inputs[i]; v.Key.CustomizedKey() == key {
+					return s.inputValue(&v.ResourceRef), true
+				}
+			}
+
+			return "", false
+		})
+	}
+
+	sort.SliceStable(r, func(i, j int) bool {
+		return r[i].IsDifferent && !r[j].IsDifferent
+	})
+
+	return r
+}
+
+func (s trainingCompareService) inputValue(ref *domain.ResourceRef) string {
+	v := ref.Type.ResourceType() + ":" + ref.User.Account() + "/" + ref.RepoId
+	if ref.File != "" {
+		v += "/" + ref.File
+	}
+
+	return v
+}
+
+func (s trainingCompareService) isDifferent(values []*string) bool {
+	for i := 1; i < len(values); i++ {
+		a, b := values[0], values[i]
+
+		if (a == nil) != (b == nil) {
+			return true
+		}
+
+		if a != nil && *a != *b {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s trainingCompareService) sortedKeys(keys map[string]bool) []string {
+	r := make([]string, 0, len(keys))
+	for k := range keys {
+		r = append(r, k)
+	}
+
+	sort.Strings(r)
+
+	return r
+}
+
+type TrainingCompareCmd struct {
+	Project     domain.ResourceIndex
+	TrainingIds []string
+}
+
+func (cmd *TrainingCompareCmd) Validate() error {
+	n := len(cmd.TrainingIds)
+	if n < minTrainingCompareNum || n > maxTrainingCompareNum {
+		return errors.New("invalid num of trainings to compare")
+	}
+
+	ids := map[string]bool{}
+	for _, id := range cmd.TrainingIds {
+		if id == "" || ids[id] {
+			return errors.New("empty or duplicate training id")
+		}
+
+		ids[id] = true
+	}
+
+	return nil
+}
+
+type TrainingCompareDTO struct {
+	Trainings []TrainingCompareItemDTO `json:"trainings"`
+	Config    []TrainingConfigDiffDTO  `json:"config"`
+}
+
+type TrainingCompareItemDTO struct {
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+	IsDone    bool   `json:"is_done"`
+	Error     string `json:"error"`
+	Status    string `json:"status"`
+	Duration  int    `json:"duration"`
+	CreatedAt string `json:"created_at"`
+
+	// Metrics is the last value of each metric.
+	Metrics []TrainingMetricDTO `json:"metrics"`
+}
+
+type TrainingMetricDTO struct {
+	Name  string  `json:"name"`
+	Step  int     `json:"step"`
+	Value float64 `json:"value"`
+}
+
+// TrainingConfigDiffDTO holds the value of a config field for each training
+// in the order of the compared trainings. The value is null if the training
+// has not the key.
+type TrainingConfigDiffDTO struct {
+	Field       string    `json:"field"`
+	Key         string    `json:"key,omitempty"`
+	Values      []*string `json:"values"`
+	IsDifferent bool      `json:"is_different"`
+}
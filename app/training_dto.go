@@ -76,6 +76,9 @@ type TrainingSummaryDTO struct {
 	CreatedAt string `json:"created_at"`
 	IsDone    bool   `json:"is_done"`
 	Duration  int    `json:"duration"`
+
+	// QueuePosition starts from 1 and it is 0 if the training is not queued.
+	QueuePosition int `json:"queue_position"`
 }
 
 func (s trainingService) toTrainingSummaryDTO(
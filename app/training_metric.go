@@ -0,0 +1,86 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+const defaultMaxTrainingMetricPointNum = 500
+
+type TrainingMetricService interface {
+	Add(*TrainingIndex, []domain.TrainingMetric) error
+	Get(*TrainingMetricGetCmd) ([]TrainingMetricSeriesDTO, error)
+}
+
+func NewTrainingMetricService(
+	repo repository.TrainingMetric, training repository.Training,
+) TrainingMetricService {
+	return trainingMetricService{repo, training}
+}
+
+type trainingMetricService struct {
+	repo     repository.TrainingMetric
+	training repository.Training
+}
+
+func (s trainingMetricService) Add(index *TrainingIndex, v []domain.TrainingMetric) error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	// the metrics of a deleted training will never be removed,
+	// so don't save them.
+	if _, err := s.training.GetJob(index); err != nil {
+		return err
+	}
+
+	return s.repo.Add(index, v)
+}
+
+func (s trainingMetricService) Get(cmd *TrainingMetricGetCmd) ([]TrainingMetricSeriesDTO, error) {
+	v, err := s.repo.Find(&cmd.Index, cmd.Names)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	max := cmd.MaxPoints
+	if max <= 0 {
+		max = defaultMaxTrainingMetricPointNum
+	}
+
+	r := make([]TrainingMetricSeriesDTO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		points := item.Downsample(max)
+
+		dto := &r[i]
+		dto.Name = item.Name.CustomizedKey()
+		dto.Points = make([]TrainingMetricPointDTO, len(points))
+
+		for j := range points {
+			dto.Points[j] = TrainingMetricPointDTO{
+				Step:  points[j].Step,
+				Value: points[j].Value,
+			}
+		}
+	}
+
+	return r, nil
+}
+
+type TrainingMetricGetCmd struct {
+	Index     TrainingIndex
+	Names     []string
+	MaxPoints int
+}
+
+type TrainingMetricSeriesDTO struct {
+	Name   string                   `json:"name"`
+	Points []TrainingMetricPointDTO `json:"points"`
+}
+
+type TrainingMetricPointDTO struct {
+	Step  int     `json:"step"`
+	Value float64 `json:"value"`
+}
@@ -0,0 +1,369 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type TrainingPublishCmd struct {
+	Index TrainingIndex
+
+	// User commits the output to the repo of model.
+	User platform.UserInfo
+
+	// the following fields are used to create the model if it does not exist.
+	Name     domain.ResourceName
+	Desc     domain.ResourceDesc
+	Title    domain.ResourceTitle
+	RepoType domain.RepoType
+	Protocol domain.ProtocolName
+}
+
+func (cmd *TrainingPublishCmd) Validate() error {
+	b := cmd.Index.Project.Owner != nil &&
+		cmd.Index.Project.Id != "" &&
+		cmd.Index.TrainingId != "" &&
+		cmd.Name != nil &&
+		cmd.RepoType != nil &&
+		cmd.Protocol != nil
+
+	if !b {
+		return errors.New("invalid cmd of publishing training")
+	}
+
+	return nil
+}
+
+type TrainingPublishDTO struct {
+	ModelId string `json:"model_id"`
+	Owner   string `json:"owner"`
+	Name    string `json:"name"`
+	Version int    `json:"version"`
+	File    string `json:"file"`
+}
+
+type TrainingPublishService interface {
+	// Publish commits the output of training to the model which will
+	// be created if it does not exist, and records the lineage of it.
+	// The caller must make sure that the user can write the models of
+	// the owner of project.
+	Publish(*TrainingPublishCmd, platform.Repository) (TrainingPublishDTO, string, error)
+}
+
+func NewTrainingPublishService(
+	log *logrus.Entry,
+	train training.Training,
+	repo repository.Training,
+	lineage repository.ModelLineage,
+	rf platform.RepoFile,
+	model ModelService,
+	modelRepo repository.Model,
+	project ProjectService,
+	projectRepo repository.Project,
+	dataset repository.Dataset,
+) TrainingPublishService {
+	return trainingPublishService{
+		log: log,
+		ts: trainingService{
+			log:   log,
+			train: train,
+			repo:  repo,
+		},
+		rf:          rf,
+		lineage:     lineage,
+		model:       model,
+		modelRepo:   modelRepo,
+		project:     project,
+		projectRepo: projectRepo,
+		dataset:     dataset,
+	}
+}
+
+type trainingPublishService struct {
+	log         *logrus.Entry
+	ts          trainingService
+	rf          platform.RepoFile
+	lineage     repository.ModelLineage
+	model       ModelService
+	modelRepo   repository.Model
+	project     ProjectService
+	projectRepo repository.Project
+	dataset     repository.Dataset
+}
+
+func (s trainingPublishService) Publish(cmd *TrainingPublishCmd, pr platform.Repository) (
+	dto TrainingPublishDTO, code string, err error,
+) {
+	index := &cmd.Index
+
+	t, err := s.ts.repo.Get(index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainNotFound
+		}
+
+		return
+	}
+
+	detail := &t.JobDetail
+
+	if !s.ts.isJobDone(detail.Status) {
+		code = ErrorTrainNotDone
+		err = errors.New("training is not done")
+
+		return
+	}
+
+	// only the output of a successful training can be published.
+	if detail.Status != trainingStatusCompleted {
+		code = ErrorTrainNotCompleted
+		err = errors.New("training is not completed successfully")
+
+		return
+	}
+
+	if t.Job.OutputDir == "" || detail.OutputPath == "" {
+		code = ErrorTrainNoOutput
+		err = errors.New("no output")
+
+		return
+	}
+
+	// download the output before creating the model,
+	// so that it will not be created if the output can't be published.
+	data, code, err := s.download(&t)
+	if err != nil {
+		return
+	}
+
+	model, created, err := s.getOrCreateModel(cmd, pr)
+	if created {
+		defer func() {
+			if err != nil {
+				s.deleteModel(&model, pr)
+			}
+		}()
+	}
+
+	if err != nil {
+		return
+	}
+
+	modelIndex := model.ResourceIndex()
+
+	lineages, version, err := s.lineage.List(&modelIndex)
+	if err != nil {
+		return
+	}
+
+	for i := range lineages {
+		item := &lineages[i]
+
+		if item.TrainingId == index.TrainingId && item.Project.Id == index.Project.Id {
+			code = ErrorTrainPublished
+			err = errors.New("the training has been published to the model")
+
+			return
+		}
+	}
+
+	lineage := domain.ModelLineage{
+		Version:    len(lineages) + 1,
+		Project:    index.Project,
+		TrainingId: index.TrainingId,
+		Datasets:   s.inputDatasets(&t),
+		CreatedAt:  utils.Now(),
+	}
+
+	if lineage.File, err = s.commit(cmd, &t, data, model.RepoId, lineage.Version); err != nil {
+		return
+	}
+
+	if err = s.lineage.Add(&modelIndex, &lineage, version); err != nil {
+		return
+	}
+
+	s.addRelations(&modelIndex, &lineage)
+
+	dto = TrainingPublishDTO{
+		ModelId: model.Id,
+		Owner:   model.Owner.Account(),
+		Name:    model.Name.ResourceName(),
+		Version: lineage.Version,
+		File:    lineage.File,
+	}
+
+	return
+}
+
+// getOrCreateModel returns true if the model is created.
+func (s trainingPublishService) getOrCreateModel(
+	cmd *TrainingPublishCmd, pr platform.Repository,
+) (domain.Model, bool, error) {
+	owner := cmd.Index.Project.Owner
+
+	m, err := s.modelRepo.GetByName(owner, cmd.Name)
+	if err == nil || !repository.IsErrorResourceNotExists(err) {
+		return m, false, err
+	}
+
+	v, err := s.model.Create(
+		&ModelCreateCmd{
+			Owner:    owner,
+			Name:     cmd.Name,
+			Desc:     cmd.Desc,
+			Title:    cmd.Title,
+			RepoType: cmd.RepoType,
+			Protocol: cmd.Protocol,
+		},
+		pr,
+	)
+	if err != nil {
+		return domain.Model{}, false, err
+	}
+
+	m, err = s.modelRepo.Get(owner, v.Id)
+	if err != nil {
+		// the model is created, so delete it by the repo id.
+		m = domain.Model{RepoId: v.RepoId}
+		m.Owner = owner
+		m.Id = v.Id
+		m.RepoType = cmd.RepoType
+	}
+
+	return m, true, err
+}
+
+func (s trainingPublishService) deleteModel(m *domain.Model, pr platform.Repository) {
+	if err := s.model.Delete(m, pr); err != nil {
+		s.log.Errorf(
+			"delete the model(%s) created for publishing failed, err:%s",
+			m.Id, err.Error(),
+		)
+	}
+}
+
+// download downloads the output of training. The output is uploaded in
+// one request, so it is limited by the size of file which can be committed
+// to the repo.
+func (s trainingPublishService) download(t *domain.UserTraining) ([]byte, string, error) {
+	data, err := s.ts.train.DownloadFile(
+		t.Job.Endpoint, t.JobDetail.OutputPath, platform.MaxRepoFileContentSize,
+	)
+	if err != nil && training.IsErrorFileTooBig(err) {
+		return nil, ErrorTrainOutputTooBig, errors.New("the output is too big to publish")
+	}
+
+	return data, "", err
+}
+
+// commit uploads the output of training as a file of the version directory.
+func (s trainingPublishService) commit(
+	cmd *TrainingPublishCmd, t *domain.UserTraining, data []byte, repoId string, version int,
+) (string, error) {
+	file, err := domain.NewFilePath(
+		fmt.Sprintf("v%d/%s", version, filepath.Base(t.JobDetail.OutputPath)),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	content := base64.StdEncoding.EncodeToString(data)
+
+	fc := platform.RepoFileContent{
+		Content:   &content,
+		IsEncoded: true,
+	}
+
+	err = s.rf.Create(
+		&cmd.User,
+		&platform.RepoFileInfo{
+			RepoId: repoId,
+			Path:   file,
+		},
+		&fc,
+	)
+
+	return file.FilePath(), err
+}
+
+// inputDatasets returns the datasets used by the training which still exist.
+func (s trainingPublishService) inputDatasets(t *domain.UserTraining) []domain.ResourceIndex {
+	r := []domain.ResourceIndex{}
+	m := map[string]bool{}
+
+	for i := range t.Inputs {
+		ref := &t.Inputs[i].ResourceRef
+
+		if ref.Type.ResourceType() != domain.ResourceTypeDataset.ResourceType() {
+			continue
+		}
+
+		v, err := s.dataset.GetSummaryByRepoId(ref.User, ref.RepoId)
+		if err != nil {
+			if !repository.IsErrorResourceNotExists(err) {
+				s.log.Errorf(
+					"get dataset by repo id(%s) failed, err:%s",
+					ref.RepoId, err.Error(),
+				)
+			}
+
+			continue
+		}
+
+		if k := v.Owner.Account() + "/" + v.Id; !m[k] {
+			m[k] = true
+
+			r = append(r, domain.ResourceIndex{
+				Owner: v.Owner,
+				Id:    v.Id,
+			})
+		}
+	}
+
+	return r
+}
+
+// addRelations relates the model to the input datasets and the source project.
+// It only logs the errors, because the lineage has been recorded.
+func (s trainingPublishService) addRelations(
+	model *domain.ResourceIndex, lineage *domain.ModelLineage,
+) {
+	for i := range lineage.Datasets {
+		// get the model every time, because its version is changed
+		// after adding the related dataset.
+		m, err := s.modelRepo.Get(model.Owner, model.Id)
+		if err == nil {
+			err = s.model.AddRelatedDataset(&m, &lineage.Datasets[i])
+		}
+
+		if err != nil {
+			s.log.Errorf(
+				"add related dataset(%s) to model(%s) failed, err:%s",
+				lineage.Datasets[i].Id, model.Id, err.Error(),
+			)
+		}
+	}
+
+	p, err := s.projectRepo.Get(lineage.Project.Owner, lineage.Project.Id)
+	if err == nil {
+		err = s.project.AddRelatedModel(&p, model)
+	}
+
+	if err != nil {
+		s.log.Errorf(
+			"add related model(%s) to project(%s) failed, err:%s",
+			model.Id, lineage.Project.Id, err.Error(),
+		)
+	}
+}
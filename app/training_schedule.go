@@ -0,0 +1,352 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/domain/training"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// the number of the following runs to check the interval of schedule.
+const trainingScheduleCheckRunNum = 10
+
+type TrainingScheduleCreateCmd struct {
+	Training TrainingIndex
+	Cron     domain.CronExpr
+	Policy   domain.TrainingSchedulePolicy
+}
+
+func (cmd *TrainingScheduleCreateCmd) Validate() error {
+	if cmd.Cron == nil || cmd.Policy == nil || cmd.Training.TrainingId == "" {
+		return errors.New("invalid cmd of creating training schedule")
+	}
+
+	return checkTrainingScheduleInterval(cmd.Cron)
+}
+
+type TrainingScheduleUpdateCmd struct {
+	Index domain.TrainingScheduleIndex
+
+	// the fields will not be changed if they are nil.
+	Cron    domain.CronExpr
+	Policy  domain.TrainingSchedulePolicy
+	Enabled *bool
+}
+
+func (cmd *TrainingScheduleUpdateCmd) Validate() error {
+	if cmd.Cron == nil && cmd.Policy == nil && cmd.Enabled == nil {
+		return errors.New("nothing to update")
+	}
+
+	if cmd.Cron != nil {
+		return checkTrainingScheduleInterval(cmd.Cron)
+	}
+
+	return nil
+}
+
+func checkTrainingScheduleInterval(cron domain.CronExpr) error {
+	min := int64(domain.DomainConfig.MinTrainingScheduleInterval)
+
+	t := cron.Next(utils.Now())
+	for i := 0; i < trainingScheduleCheckRunNum; i++ {
+		next := cron.Next(t)
+		if next == 0 {
+			break
+		}
+
+		if next-t < min {
+			return errors.New("the interval of schedule is too short")
+		}
+
+		t = next
+	}
+
+	return nil
+}
+
+type TrainingScheduleDTO struct {
+	Id         string `json:"id"`
+	TrainingId string `json:"training_id"`
+	Cron       string `json:"cron"`
+	Policy     string `json:"policy"`
+	Enabled    bool   `json:"enabled"`
+	NextRunAt  int64  `json:"next_run_at,omitempty"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type TrainingScheduleRunDTO struct {
+	TriggeredAt int64  `json:"triggered_at"`
+	TrainingId  string `json:"training_id,omitempty"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+type TrainingScheduleService interface {
+	Create(*TrainingScheduleCreateCmd) (string, string, error)
+	Update(*TrainingScheduleUpdateCmd) (string, error)
+	Delete(*domain.TrainingScheduleIndex) error
+	List(*domain.ResourceIndex) ([]TrainingScheduleDTO, error)
+	ListRuns(*domain.TrainingScheduleIndex) ([]TrainingScheduleRunDTO, string, error)
+
+	// Trigger runs the schedules which are due at t.
+	Trigger(t int64) error
+}
+
+func NewTrainingScheduleService(
+	log *logrus.Entry,
+	train training.Training,
+	trainingRepo repository.Training,
+	repo repository.TrainingSchedule,
+	sender message.Sender,
+	maxTrainingRecordNum int,
+) TrainingScheduleService {
+	return trainingScheduleService{
+		log:  log,
+		repo: repo,
+		ts: trainingService{
+			log:    log,
+			train:  train,
+			repo:   trainingRepo,
+			sender: sender,
+
+			maxTrainingRecordNum: maxTrainingRecordNum,
+		},
+	}
+}
+
+type trainingScheduleService struct {
+	log  *logrus.Entry
+	repo repository.TrainingSchedule
+	ts   trainingService
+}
+
+func (s trainingScheduleService) Create(cmd *TrainingScheduleCreateCmd) (
+	id string, code string, err error,
+) {
+	config, err := s.ts.repo.GetTrainingConfig(&cmd.Training)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainNotFound
+		}
+
+		return
+	}
+
+	v, version, err := s.repo.List(&cmd.Training.Project)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= domain.DomainConfig.MaxTrainingScheduleNum {
+		code = ErrorTrainingScheduleExccedMaxNum
+		err = errors.New("exceed max training schedule num")
+
+		return
+	}
+
+	now := utils.Now()
+
+	schedule := domain.TrainingSchedule{
+		Project:    cmd.Training.Project,
+		TrainingId: cmd.Training.TrainingId,
+		Config:     config,
+		Cron:       cmd.Cron,
+		Policy:     cmd.Policy,
+		Enabled:    true,
+		NextRunAt:  cmd.Cron.Next(now),
+		CreatedAt:  now,
+	}
+
+	id, err = s.repo.Save(&schedule, version)
+
+	return
+}
+
+func (s trainingScheduleService) Update(cmd *TrainingScheduleUpdateCmd) (code string, err error) {
+	v, err := s.repo.Get(&cmd.Index)
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainingScheduleNotFound
+		}
+
+		return
+	}
+
+	if cmd.Policy != nil {
+		v.Policy = cmd.Policy
+	}
+
+	if cmd.Enabled != nil {
+		v.Enabled = *cmd.Enabled
+	}
+
+	// it should be recalculated when it is enabled again,
+	// otherwise the missed runs will be triggered at once.
+	if cmd.Cron != nil || cmd.Enabled != nil {
+		if cmd.Cron != nil {
+			v.Cron = cmd.Cron
+		}
+
+		v.NextRunAt = v.Cron.Next(utils.Now())
+	}
+
+	err = s.repo.Update(&v)
+
+	return
+}
+
+func (s trainingScheduleService) Delete(index *domain.TrainingScheduleIndex) error {
+	return s.repo.Delete(index)
+}
+
+func (s trainingScheduleService) List(project *domain.ResourceIndex) ([]TrainingScheduleDTO, error) {
+	v, _, err := s.repo.List(project)
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]TrainingScheduleDTO, len(v))
+	for i := range v {
+		r[i] = s.toTrainingScheduleDTO(&v[i])
+	}
+
+	return r, nil
+}
+
+func (s trainingScheduleService) ListRuns(index *domain.TrainingScheduleIndex) (
+	r []TrainingScheduleRunDTO, code string, err error,
+) {
+	if _, err = s.repo.Get(index); err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			code = ErrorTrainingScheduleNotFound
+		}
+
+		return
+	}
+
+	v, err := s.repo.ListRuns(index)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	r = make([]TrainingScheduleRunDTO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		r[i] = TrainingScheduleRunDTO{
+			TriggeredAt: item.TriggeredAt,
+			TrainingId:  item.TrainingId,
+			Status:      item.Status,
+			Reason:      item.Reason,
+		}
+	}
+
+	return
+}
+
+func (s trainingScheduleService) Trigger(t int64) error {
+	v, err := s.repo.FindDue(t)
+	if err != nil {
+		return err
+	}
+
+	for i := range v {
+		s.trigger(&v[i], t)
+	}
+
+	return nil
+}
+
+func (s trainingScheduleService) trigger(schedule *domain.TrainingSchedule, t int64) {
+	// move to the next run first, so that the schedule will be
+	// triggered only once even if there are several instances.
+	schedule.NextRunAt = schedule.Cron.Next(t)
+	if schedule.NextRunAt == 0 {
+		schedule.Enabled = false
+	}
+
+	if err := s.repo.Update(schedule); err != nil {
+		if !repository.IsErrorConcurrentUpdating(err) {
+			s.log.Errorf(
+				"update next run of training schedule:%s failed, err:%s",
+				schedule.Id, err.Error(),
+			)
+		}
+
+		return
+	}
+
+	run := s.run(schedule)
+	run.TriggeredAt = t
+
+	index := schedule.Index()
+	if err := s.repo.AddRun(&index, &run); err != nil {
+		s.log.Errorf(
+			"add run of training schedule:%s failed, err:%s",
+			schedule.Id, err.Error(),
+		)
+	}
+}
+
+func (s trainingScheduleService) run(schedule *domain.TrainingSchedule) (
+	r domain.TrainingScheduleRun,
+) {
+	if schedule.Policy.IsSkip() {
+		v, _, err := s.ts.repo.List(schedule.Project.Owner, schedule.Project.Id)
+		if err != nil {
+			r.Status = domain.TrainingScheduleRunFailed
+			r.Reason = err.Error()
+
+			return
+		}
+
+		if s.ts.hasUndoneJob(v) {
+			r.Status = domain.TrainingScheduleRunSkipped
+			r.Reason = "another training is not done"
+
+			return
+		}
+	}
+
+	project := &schedule.Project
+
+	id, err := s.ts.recreate(project.Owner, project.Id, &schedule.Config)
+	if err != nil {
+		r.Status = domain.TrainingScheduleRunFailed
+		r.Reason = err.Error()
+
+		// the schedule will work again after the user deletes some trainings.
+		if _, ok := err.(ErrorExccedMaxTrainingRecord); ok {
+			r.Status = domain.TrainingScheduleRunSkipped
+			r.Reason = "the trainings reach the max num, please delete some of them"
+		}
+	} else {
+		r.Status = domain.TrainingScheduleRunTriggered
+		r.TrainingId = id
+	}
+
+	return
+}
+
+func (s trainingScheduleService) toTrainingScheduleDTO(v *domain.TrainingSchedule) TrainingScheduleDTO {
+	dto := TrainingScheduleDTO{
+		Id:         v.Id,
+		TrainingId: v.TrainingId,
+		Cron:       v.Cron.CronExpr(),
+		Policy:     v.Policy.TrainingSchedulePolicy(),
+		Enabled:    v.Enabled,
+		CreatedAt:  utils.ToDate(v.CreatedAt),
+	}
+
+	if v.Enabled && v.NextRunAt > 0 {
+		dto.NextRunAt = v.NextRunAt
+	}
+
+	return dto
+}
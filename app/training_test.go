@@ -0,0 +1,199 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type fakeTrainingRepo struct {
+	repository.Training
+
+	trainings []domain.TrainingSummary
+	version   int
+}
+
+func (r *fakeTrainingRepo) Save(t *domain.UserTraining, version int) (string, error) {
+	if version != r.version {
+		return "", repository.NewErrorConcurrentUpdating(nil)
+	}
+
+	id := t.Name.TrainingName()
+
+	r.trainings = append(r.trainings, domain.TrainingSummary{
+		Id:        id,
+		Name:      t.Name,
+		Status:    t.JobDetail.Status,
+		CreatedAt: t.CreatedAt,
+	})
+	r.version++
+
+	return id, nil
+}
+
+func (r *fakeTrainingRepo) List(domain.Account, string) ([]domain.TrainingSummary, int, error) {
+	v := make([]domain.TrainingSummary, len(r.trainings))
+	copy(v, r.trainings)
+
+	return v, r.version, nil
+}
+
+func (r *fakeTrainingRepo) UpdateJobDetailWithVersion(
+	info *domain.TrainingIndex, detail *domain.JobDetail, version int,
+) error {
+	if version != r.version {
+		return repository.NewErrorConcurrentUpdating(nil)
+	}
+
+	for i := range r.trainings {
+		if r.trainings[i].Id == info.TrainingId {
+			r.trainings[i].Status = detail.Status
+			r.version++
+
+			return nil
+		}
+	}
+
+	return repository.NewErrorResourceNotExists(nil)
+}
+
+type fakeSweepRepo struct {
+	repository.Sweep
+
+	sweeps []domain.Sweep
+}
+
+func (r *fakeSweepRepo) List(domain.Account, string) ([]domain.Sweep, int, error) {
+	v := make([]domain.Sweep, len(r.sweeps))
+	copy(v, r.sweeps)
+
+	return v, 0, nil
+}
+
+func (r *fakeSweepRepo) UpdateTrials(sweep *domain.Sweep) error {
+	for i := range r.sweeps {
+		if r.sweeps[i].Id == sweep.Id {
+			r.sweeps[i] = *sweep
+		}
+	}
+
+	return nil
+}
+
+type fakeSender struct {
+	message.Sender
+
+	created []message.MsgTraining
+	done    []message.MsgTraining
+}
+
+func (s *fakeSender) CreateTraining(msg *message.MsgTraining) error {
+	s.created = append(s.created, *msg)
+
+	return nil
+}
+
+func (s *fakeSender) NotifyTrainingDone(msg *message.MsgTraining) error {
+	s.done = append(s.done, *msg)
+
+	return nil
+}
+
+func TestCancelQueuedSweepTrial(t *testing.T) {
+	cfg := domain.Config{}
+	cfg.SetDefault()
+	domain.Init(&cfg)
+
+	owner, err := domain.NewAccount("alice")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := domain.NewTrainingName("sweep")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := domain.NewCustomizedKey("lr")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	value, err := domain.NewCustomizedValue("0.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trial := func(id string) domain.SweepTrial {
+		return domain.SweepTrial{
+			Hyperparameters: []domain.KeyValue{{Key: key, Value: value}},
+			TrainingId:      id,
+		}
+	}
+
+	trainingRepo := &fakeTrainingRepo{
+		trainings: []domain.TrainingSummary{
+			{Id: "sweep-1", Status: trainingStatusQueued},
+		},
+	}
+
+	sweepRepo := &fakeSweepRepo{
+		sweeps: []domain.Sweep{{
+			Id:             "1",
+			Owner:          owner,
+			ProjectId:      "p",
+			TrainingConfig: domain.TrainingConfig{Name: name},
+			Concurrency:    1,
+			Trials:         []domain.SweepTrial{trial("sweep-1"), trial("")},
+		}},
+	}
+
+	sender := new(fakeSender)
+	log := logrus.NewEntry(logrus.New())
+
+	ts := NewTrainingService(log, nil, trainingRepo, nil, sender, 10)
+
+	index := TrainingIndex{
+		Project: domain.ResourceIndex{
+			Owner: owner,
+			Id:    "p",
+		},
+		TrainingId: "sweep-1",
+	}
+
+	if _, err := ts.Cancel(&index); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(sender.done); n != 1 {
+		t.Fatalf("expect 1 message of training done, got %d", n)
+	}
+
+	msg := &sender.done[0]
+	if msg.Type != message.MsgTypeTraningDone || msg.Details["training_id"] != "sweep-1" {
+		t.Fatalf("unexpected message of training done: %v", *msg)
+	}
+
+	// the message server handles the message as below.
+	sweep := NewSweepMessageService(log, nil, trainingRepo, sweepRepo, sender)
+	if err := sweep.HandleTrainingDone(&index); err != nil {
+		t.Fatal(err)
+	}
+
+	trials := sweepRepo.sweeps[0].Trials
+	if !trials[0].Done {
+		t.Fatal("the canceled trial should be done")
+	}
+
+	if trials[1].TrainingId != "sweep-2" {
+		t.Fatalf("the next trial should be launched, got training id %q", trials[1].TrainingId)
+	}
+
+	if n := len(sender.created); n != 1 {
+		t.Fatalf("expect 1 message of creating training, got %d", n)
+	}
+}
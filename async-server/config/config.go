@@ -12,8 +12,17 @@ import (
 	"github.com/opensourceways/xihe-server/async-server/infrastructure/poolimpl"
 	"github.com/opensourceways/xihe-server/async-server/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
+	cloudrepoimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	cloudwatchimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/watchimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+	competitionwatchimpl "github.com/opensourceways/xihe-server/competition/infrastructure/watchimpl"
+	xiheconfig "github.com/opensourceways/xihe-server/config"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/messages"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingscheduleimpl"
 )
 
 var reIpPort = regexp.MustCompile(`^((25[0-5]|(2[0-4]|1\d|[1-9]|)\d)\.?\b){4}:[1-9][0-9]*$`)
@@ -47,11 +56,24 @@ type ConfigSetDefault interface {
 type Config struct {
 	MaxRetry int `json:"max_retry"`
 
-	BigModel   bigmodels.Config `json:"bigmodel"     required:"true"`
-	Postgresql PostgresqlConfig `json:"postgresql"   required:"true"`
-	MQ         MQ               `json:"mq"           required:"true"`
-	Pool       poolimpl.Config  `json:"pool"         required:"true"`
-	Watcher    watchimpl.Config `json:"watcher"      required:"true"`
+	// MaxTrainingRecordNum is the max num of trainings of a project,
+	// and it must be same as the one of api server.
+	MaxTrainingRecordNum int `json:"max_training_record_num" required:"true"`
+
+	BigModel   bigmodels.Config   `json:"bigmodel"     required:"true"`
+	Postgresql PostgresqlConfig   `json:"postgresql"   required:"true"`
+	Mongodb    xiheconfig.Mongodb `json:"mongodb"      required:"true"`
+	Domain     domain.Config      `json:"domain"       required:"true"`
+	MQ         MQ                 `json:"mq"           required:"true"`
+	Pool       poolimpl.Config    `json:"pool"         required:"true"`
+	Watcher    watchimpl.Config   `json:"watcher"      required:"true"`
+
+	Training         trainingimpl.Config         `json:"training"          required:"true"`
+	Competition      competitionimpl.Config      `json:"competition"       required:"true"`
+	Schedule         trainingscheduleimpl.Config `json:"training_schedule"`
+	CloudWatch       cloudwatchimpl.Config       `json:"cloud_watch"`
+	Workspace        workspaceimpl.Config        `json:"cloud_workspace"   required:"true"`
+	CompetitionWatch competitionwatchimpl.Config `json:"competition_watch"`
 }
 
 func (cfg *Config) GetMQConfig() mq.MQConfig {
@@ -65,8 +87,17 @@ func (cfg *Config) configItems() []interface{} {
 		&cfg.BigModel,
 		&cfg.Postgresql.DB,
 		&cfg.Postgresql.Config,
+		&cfg.Postgresql.Cloud,
+		&cfg.Mongodb,
+		&cfg.Domain,
 		&cfg.MQ,
 		&cfg.Pool,
+		&cfg.Training,
+		&cfg.Competition,
+		&cfg.Schedule,
+		&cfg.CloudWatch,
+		&cfg.Workspace,
+		&cfg.CompetitionWatch,
 	}
 }
 
@@ -104,6 +135,8 @@ type PostgresqlConfig struct {
 	DB pgsql.Config `json:"db" required:"true"`
 
 	repositoryimpl.Config
+
+	Cloud cloudrepoimpl.Config
 }
 
 type MQ struct {
@@ -8,6 +8,7 @@ import (
 	"github.com/opensourceways/server-common-lib/logrusutil"
 	"github.com/sirupsen/logrus"
 
+	xiheapp "github.com/opensourceways/xihe-server/app"
 	"github.com/opensourceways/xihe-server/async-server/app"
 	"github.com/opensourceways/xihe-server/async-server/config"
 	"github.com/opensourceways/xihe-server/async-server/infrastructure/bigmodelimpl"
@@ -16,8 +17,23 @@ import (
 	"github.com/opensourceways/xihe-server/async-server/infrastructure/watchimpl"
 	bigmodelapp "github.com/opensourceways/xihe-server/bigmodel/app"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
+	cloudapp "github.com/opensourceways/xihe-server/cloud/app"
+	cloudrepo "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	cloudwatchimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/watchimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+	"github.com/opensourceways/xihe-server/common/infrastructure/ticker"
+	competitionapp "github.com/opensourceways/xihe-server/competition/app"
+	competitionrepo "github.com/opensourceways/xihe-server/competition/infrastructure/repositoryimpl"
+	competitionscorer "github.com/opensourceways/xihe-server/competition/infrastructure/scorerimpl"
+	competitionwatchimpl "github.com/opensourceways/xihe-server/competition/infrastructure/watchimpl"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/messages"
+	"github.com/opensourceways/xihe-server/infrastructure/mongodb"
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingscheduleimpl"
 )
 
 type options struct {
@@ -92,6 +108,22 @@ func main() {
 		logrus.Fatalf("init pool, err:%s", err.Error())
 	}
 
+	// mongo
+	m := &cfg.Mongodb
+	if err := mongodb.Initialize(m.DBConn, m.DBName, m.DBCert); err != nil {
+		logrus.Fatalf("initialize mongodb failed, err:%s", err.Error())
+	}
+
+	defer mongodb.Close()
+
+	// competition
+	if err := competitionimpl.Init(&cfg.Competition); err != nil {
+		logrus.Fatalf("initialize competition failed, err:%s", err.Error())
+	}
+
+	// domain
+	domain.Init(&cfg.Domain)
+
 	// bigmodel & sender
 	bm := bigmodels.NewBigModelService()
 	sender := messages.NewMessageSender()
@@ -111,6 +143,14 @@ func main() {
 		asyncWuKongRepo,
 	)
 
+	// watchers of the other services, only one async server runs them
+	// so that the same work will not be done concurrently.
+	for _, t := range newTickers(cfg) {
+		t.Start()
+
+		defer t.Stop()
+	}
+
 	// watch
 	w := watchimpl.NewWather(
 		cfg.Watcher,
@@ -124,3 +164,51 @@ func main() {
 	w.Run()
 	defer w.Exit()
 }
+
+func newTickers(cfg *config.Config) []*ticker.Ticker {
+	collections := &cfg.Mongodb.Collections
+	sender := messages.NewMessageSender()
+
+	// training schedule
+	training := repositories.NewTrainingRepository(
+		mongodb.NewTrainingMapper(collections.Training),
+	)
+
+	schedule := trainingscheduleimpl.NewWatcher(
+		&cfg.Schedule,
+		xiheapp.NewTrainingScheduleService(
+			logrus.NewEntry(logrus.StandardLogger()),
+			trainingimpl.NewTraining(&cfg.Training),
+			training,
+			repositories.NewTrainingScheduleRepository(
+				mongodb.NewTrainingScheduleMapper(collections.TrainingSchedule),
+			),
+			sender, cfg.MaxTrainingRecordNum,
+		).Trigger,
+	)
+
+	// competition
+	competition := competitionwatchimpl.NewWatcher(
+		&cfg.CompetitionWatch,
+		competitionapp.NewCompetitionScoringService(
+			competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
+			competitionrepo.NewWorkRepo(mongodb.NewCollection(collections.CompetitionWork)),
+			competitionscorer.NewScorer(competitionimpl.NewCompetitionService()),
+		).Score,
+	)
+
+	// cloud
+	cloud := cloudwatchimpl.NewWatcher(
+		&cfg.CloudWatch,
+		cloudapp.NewCloudWatchService(
+			cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf)),
+			cloudrepo.NewPodRepo(&cfg.Postgresql.Cloud),
+			cloudrepo.NewCreditRepo(&cfg.Postgresql.Cloud),
+			cloudrepo.NewWaitlistRepo(&cfg.Postgresql.Cloud),
+			workspaceimpl.NewWorkspace(&cfg.Workspace),
+			sender,
+		).Watch,
+	)
+
+	return []*ticker.Ticker{schedule, competition, cloud}
+}
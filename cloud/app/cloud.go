@@ -3,10 +3,13 @@ package app
 import (
 	"errors"
 
+	"github.com/sirupsen/logrus"
+
 	"github.com/opensourceways/xihe-server/cloud/domain"
 	"github.com/opensourceways/xihe-server/cloud/domain/message"
 	"github.com/opensourceways/xihe-server/cloud/domain/repository"
 	"github.com/opensourceways/xihe-server/cloud/domain/service"
+	"github.com/opensourceways/xihe-server/cloud/domain/workspace"
 	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
 	types "github.com/opensourceways/xihe-server/domain"
 )
@@ -19,6 +22,12 @@ type CloudService interface {
 	// pod
 	Get(*PodInfoCmd) (PodInfoDTO, error)
 	ReleasePod(*RelasePodCmd) (code string, err error)
+	ExtendPod(*ExtendPodCmd) (code string, err error)
+
+	// waitlist
+	JoinWaitlist(*SubscribeCloudCmd) (WaitingDTO, string, error)
+	GetWaiting(*SubscribeCloudCmd) (WaitingDTO, string, error)
+	LeaveWaitlist(*SubscribeCloudCmd) error
 }
 
 var _ CloudService = (*cloudService)(nil)
@@ -26,19 +35,26 @@ var _ CloudService = (*cloudService)(nil)
 func NewCloudService(
 	cloudRepo repository.Cloud,
 	podRepo repository.Pod,
+	creditRepo repository.Credit,
+	waitlistRepo repository.Waitlist,
+	workspace workspace.Workspace,
 	producer message.CloudMessageProducer,
 ) *cloudService {
 	return &cloudService{
 		cloudRepo:    cloudRepo,
 		podRepo:      podRepo,
+		waitlistRepo: waitlistRepo,
+		workspace:    workspace,
 		producer:     producer,
-		cloudService: service.NewCloudService(podRepo, producer),
+		cloudService: service.NewCloudService(cloudRepo, podRepo, creditRepo, producer),
 	}
 }
 
 type cloudService struct {
 	cloudRepo    repository.Cloud
 	podRepo      repository.Pod
+	waitlistRepo repository.Waitlist
+	workspace    workspace.Workspace
 	producer     message.CloudMessageProducer
 	cloudService service.CloudService
 }
@@ -120,8 +136,78 @@ func (s *cloudService) SubscribeCloud(cmd *SubscribeCloudCmd) (code string, err
 		return
 	}
 
+	// the idle resource is reserved for the waiting users
+	n, err := s.waitlistRepo.Count(cmd.CloudId)
+	if err != nil {
+		return
+	}
+
+	if n >= c.Remain.CloudRemain() {
+		code = errorResourceBusy
+		err = errors.New("the idle resource is reserved for the waiting users")
+
+		return
+	}
+
+	// check credit
+	cost := c.CloudConf.PodCost(c.CloudConf.PodSurvivalTime())
+	if code, err = s.checkCredit(cmd.User, cost); err != nil {
+		return
+	}
+
+	// check workspace
+	if code, err = s.checkWorkspace(cmd.User); err != nil {
+		return
+	}
+
 	// subscribe
-	err = s.cloudService.SubscribeCloud(&c.CloudConf, cmd.User)
+	if err = s.cloudService.SubscribeCloud(&c.CloudConf, cmd.User, n); err != nil {
+		if repository.IsErrorCreditInsufficient(err) {
+			code = errorCreditInsufficient
+		}
+
+		if repository.IsErrorCloudBusy(err) {
+			code = errorResourceBusy
+		}
+
+		return
+	}
+
+	// the user may be waiting for the cloud
+	if err1 := s.waitlistRepo.Remove(cmd.CloudId, cmd.User); err1 != nil {
+		logrus.Errorf(
+			"remove user(%s) from waitlist of cloud(%s) failed, err:%s",
+			cmd.User.Account(), cmd.CloudId, err1.Error(),
+		)
+	}
+
+	return
+}
+
+func (s *cloudService) checkWorkspace(user types.Account) (code string, err error) {
+	b, err := s.workspace.IsExceedQuota(user)
+	if err != nil {
+		return
+	}
+
+	if b {
+		code = errorWorkspaceExceedQuota
+		err = errors.New("the workspace exceeds the quota")
+	}
+
+	return
+}
+
+func (s *cloudService) checkCredit(user types.Account, cost int64) (code string, err error) {
+	ok, err := s.cloudService.HasSufficientCredit(user, cost)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		code = errorCreditInsufficient
+		err = errors.New("insufficient credit")
+	}
 
 	return
 }
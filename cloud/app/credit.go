@@ -0,0 +1,73 @@
+package app
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type CreditService interface {
+	GetBalance(types.Account) (CreditBalanceDTO, error)
+	Grant(*CreditGrantCmd) (CreditBalanceDTO, error)
+	ListTransactions(*CreditTransactionListCmd) (CreditTransactionsDTO, error)
+}
+
+func NewCreditService(repo repository.Credit) CreditService {
+	return &creditService{
+		repo: repo,
+	}
+}
+
+type creditService struct {
+	repo repository.Credit
+}
+
+func (s *creditService) GetBalance(user types.Account) (dto CreditBalanceDTO, err error) {
+	b, err := s.repo.GetBalance(user)
+	if err != nil {
+		return
+	}
+
+	dto.toCreditBalanceDTO(&b)
+
+	return
+}
+
+func (s *creditService) Grant(cmd *CreditGrantCmd) (dto CreditBalanceDTO, err error) {
+	t, err := domain.NewCreditGrant(cmd.User, cmd.Amount, cmd.Remark)
+	if err != nil {
+		return
+	}
+
+	b, err := s.repo.AddTransaction(&t)
+	if err != nil {
+		return
+	}
+
+	dto.toCreditBalanceDTO(&b)
+
+	return
+}
+
+func (s *creditService) ListTransactions(cmd *CreditTransactionListCmd) (
+	dto CreditTransactionsDTO, err error,
+) {
+	v, err := s.repo.ListTransactions(
+		cmd.User,
+		&repository.CreditTransactionListOption{
+			PageNum:      cmd.PageNum,
+			CountPerPage: cmd.CountPerPage,
+		},
+	)
+	if err != nil {
+		return
+	}
+
+	dto.Total = v.Total
+	dto.Transactions = make([]CreditTransactionDTO, len(v.Transactions))
+	for i := range v.Transactions {
+		dto.Transactions[i].toCreditTransactionDTO(&v.Transactions[i])
+	}
+
+	return
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensourceways/xihe-server/cloud/domain"
 	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
 )
 
 type SubscribeCloudCmd struct {
@@ -24,6 +25,24 @@ type RelasePodCmd struct {
 	PodId string
 }
 
+type ExtendPodCmd struct {
+	User  types.Account
+	PodId string
+	Hours int
+}
+
+type CreditGrantCmd struct {
+	User   types.Account
+	Amount int64
+	Remark string
+}
+
+type CreditTransactionListCmd struct {
+	User         types.Account
+	PageNum      int
+	CountPerPage int
+}
+
 type UpdatePodInternalCmd struct {
 	PodId     string
 	PodError  domain.PodError
@@ -56,6 +75,36 @@ type PodInfoDTO struct {
 	Error     string `json:"error"`
 	AccessURL string `json:"access_url"`
 	CreatedAt int64  `json:"created_at"`
+
+	RemainingTime int64 `json:"remaining_time"`
+	ExpiryWarning bool  `json:"expiry_warning"`
+}
+
+type WaitingDTO struct {
+	CloudId  string `json:"cloud_id"`
+	Position int    `json:"position"`
+	Total    int    `json:"total"`
+}
+
+type CreditBalanceDTO struct {
+	Owner   string `json:"owner"`
+	Balance int64  `json:"balance"`
+}
+
+type CreditTransactionDTO struct {
+	Id        string `json:"id"`
+	Type      string `json:"type"`
+	Amount    int64  `json:"amount"`
+	Balance   int64  `json:"balance"`
+	PodId     string `json:"pod_id,omitempty"`
+	CloudId   string `json:"cloud_id,omitempty"`
+	Remark    string `json:"remark"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CreditTransactionsDTO struct {
+	Total        int                    `json:"total"`
+	Transactions []CreditTransactionDTO `json:"transactions"`
 }
 
 func (cmd *SubscribeCloudCmd) Validate() error {
@@ -80,6 +129,30 @@ func (cmd *PodInfoCmd) Validate() error {
 	return nil
 }
 
+func (cmd *ExtendPodCmd) Validate() error {
+	b := cmd.User != nil &&
+		cmd.PodId != "" &&
+		cmd.Hours > 0
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	return nil
+}
+
+func (cmd *ExtendPodCmd) duration() int64 {
+	return int64(cmd.Hours) * 3600
+}
+
+func (cmd *CreditGrantCmd) Validate() error {
+	if cmd.User == nil || cmd.Amount <= 0 {
+		return errors.New("invalid cmd")
+	}
+
+	return nil
+}
+
 func (cmd *GetCloudConfCmd) ToCmd(user types.Account, visitor bool) {
 	*cmd = GetCloudConfCmd{
 		IsVisitor: visitor,
@@ -141,4 +214,29 @@ func (r *PodInfoDTO) toPodInfoDTO(p *domain.PodInfo) {
 	if p.CreatedAt != nil {
 		r.CreatedAt = p.CreatedAt.Time()
 	}
+
+	if p.Status != nil {
+		r.RemainingTime = p.RemainingTime()
+		r.ExpiryWarning = p.IsAboutToExpire()
+	}
+}
+
+func (r *CreditBalanceDTO) toCreditBalanceDTO(b *domain.CreditBalance) {
+	*r = CreditBalanceDTO{
+		Owner:   b.Owner.Account(),
+		Balance: b.Balance,
+	}
+}
+
+func (r *CreditTransactionDTO) toCreditTransactionDTO(t *domain.CreditTransaction) {
+	*r = CreditTransactionDTO{
+		Id:        t.Id,
+		Type:      t.Type,
+		Amount:    t.Amount.Credit(),
+		Balance:   t.Balance,
+		PodId:     t.PodId,
+		CloudId:   t.CloudId,
+		Remark:    t.Remark,
+		CreatedAt: utils.ToDate(t.CreatedAt),
+	}
 }
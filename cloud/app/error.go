@@ -5,4 +5,10 @@ const (
 	errorNoAuthorized = "cloud_no_authorized"
 	errorNotAllowed   = "cloud_not_allowed"
 	errorNotRunning   = "cloud_not_running"
+
+	errorExceedMaxSurvivalTime = "cloud_exceed_max_survival_time"
+	errorCreditInsufficient    = "cloud_credit_insufficient"
+	errorNotWaiting            = "cloud_not_waiting"
+	errorWorkspaceExceedQuota  = "cloud_workspace_exceed_quota"
+	errorWorkspaceEmpty        = "cloud_workspace_empty"
 )
@@ -3,21 +3,32 @@ package app
 import (
 	"github.com/opensourceways/xihe-server/cloud/domain"
 	"github.com/opensourceways/xihe-server/cloud/domain/cloud"
+	"github.com/opensourceways/xihe-server/cloud/domain/message"
 	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	"github.com/opensourceways/xihe-server/cloud/domain/service"
+	"github.com/opensourceways/xihe-server/cloud/domain/workspace"
 )
 
 type CloudMessageService interface {
 	CreatePodInstance(*domain.PodInfo) error
+	ExtendPodInstance(*domain.PodInfo) error
+	ReleasePodInstance(*domain.PodInfo) error
 }
 
 func NewCloudMessageService(
+	cloudRepo repository.Cloud,
 	repo repository.Pod,
+	creditRepo repository.Credit,
 	manager cloud.CloudPod,
+	workspace workspace.Workspace,
+	producer message.CloudMessageProducer,
 	survivalTimeForPod int64,
 ) CloudMessageService {
 	return &cloudMessageService{
 		repo:               repo,
 		manager:            manager,
+		workspace:          workspace,
+		cloudService:       service.NewCloudService(cloudRepo, repo, creditRepo, producer),
 		survivalTimeForPod: survivalTimeForPod,
 	}
 }
@@ -25,15 +36,28 @@ func NewCloudMessageService(
 type cloudMessageService struct {
 	repo               repository.Pod
 	manager            cloud.CloudPod
+	workspace          workspace.Workspace
+	cloudService       service.CloudService
 	survivalTimeForPod int64
 }
 
 func (c *cloudMessageService) CreatePodInstance(p *domain.PodInfo) error {
+	// the survival time of cloud conf is preferred
+	survivalTime := p.RemainingTime()
+	if survivalTime <= 0 {
+		survivalTime = c.survivalTimeForPod
+	}
+
+	// prepare the workspace of user which is shared with the container manager
+	if _, err := c.workspace.Volume(p.Owner); err != nil {
+		return err
+	}
+
 	// create pod instance by SDK
 	err := c.manager.Create(
 		&cloud.CloudPodCreateInfo{
 			PodId:        p.Id,
-			SurvivalTime: c.survivalTimeForPod,
+			SurvivalTime: survivalTime,
 		},
 	)
 
@@ -46,3 +70,33 @@ func (c *cloudMessageService) CreatePodInstance(p *domain.PodInfo) error {
 
 	return c.repo.UpdatePod(p)
 }
+
+// ExtendPodInstance extends the pod instance to the expiry of p,
+// and then updates the pod.
+func (c *cloudMessageService) ExtendPodInstance(p *domain.PodInfo) error {
+	survivalTime := p.RemainingTime()
+	if survivalTime <= 0 {
+		return nil
+	}
+
+	err := c.manager.Extend(
+		&cloud.CloudPodExtendInfo{
+			PodId:        p.Id,
+			SurvivalTime: survivalTime,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.cloudService.PodExtended(p.Id, p.Expiry)
+}
+
+// ReleasePodInstance releases the pod instance, and then terminates the pod.
+func (c *cloudMessageService) ReleasePodInstance(p *domain.PodInfo) error {
+	if err := c.manager.Release(p.Id); err != nil {
+		return err
+	}
+
+	return c.cloudService.PodReleased(p.Id)
+}
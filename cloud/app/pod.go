@@ -27,8 +27,56 @@ func (s *cloudService) ReleasePod(cmd *RelasePodCmd) (code string, err error) {
 		return
 	}
 
-	// relase
-	err = s.cloudService.ReleasePod(&p.Pod)
+	// relase, and the pod is terminated after its instance is released.
+	err = s.cloudService.ReleasePod(&p)
+
+	return
+}
+
+// ExtendPod checks the extension and the credit, and the pod will be extended
+// and charged after the container manager extends the pod instance.
+func (s *cloudService) ExtendPod(cmd *ExtendPodCmd) (code string, err error) {
+	// get pod
+	p, err := s.podRepo.GetPodInfo(cmd.PodId)
+	if err != nil {
+		return
+	}
+
+	// is owner
+	if !p.Pod.IsOnwer(cmd.User) {
+		code = errorNoAuthorized
+		err = errors.New("no authorize")
+
+		return
+	}
+
+	// check status
+	if !p.CanExtend() {
+		code = errorNotRunning
+		err = errors.New("pod not running")
+
+		return
+	}
+
+	// get cloud conf
+	c, err := s.cloudRepo.GetCloudConf(p.CloudId)
+	if err != nil {
+		return
+	}
+
+	if err = p.Extend(&c, cmd.duration()); err != nil {
+		code = errorExceedMaxSurvivalTime
+
+		return
+	}
+
+	// check credit
+	if code, err = s.checkCredit(cmd.User, c.PodCost(cmd.duration())); err != nil {
+		return
+	}
+
+	// extend
+	err = s.cloudService.ExtendPod(&p)
 
 	return
 }
@@ -0,0 +1,171 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/message"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+)
+
+func (s *cloudService) JoinWaitlist(cmd *SubscribeCloudCmd) (
+	dto WaitingDTO, code string, err error,
+) {
+	// check
+	_, ok, err := s.cloudService.CheckUserCanSubsribe(cmd.User, cmd.CloudId)
+	if err != nil {
+		return
+	}
+
+	if !ok {
+		code = errorNotAllowed
+		err = errors.New("starting or running pod exist")
+
+		return
+	}
+
+	c, err := s.cloudRepo.GetCloudConf(cmd.CloudId)
+	if err != nil {
+		return
+	}
+
+	if code, err = s.checkCredit(cmd.User, c.PodCost(c.PodSurvivalTime())); err != nil {
+		return
+	}
+
+	if code, err = s.checkWorkspace(cmd.User); err != nil {
+		return
+	}
+
+	// join
+	w := domain.NewWaiting(cmd.CloudId, cmd.User)
+	if err = s.waitlistRepo.Add(&w); err != nil {
+		return
+	}
+
+	return s.GetWaiting(cmd)
+}
+
+func (s *cloudService) GetWaiting(cmd *SubscribeCloudCmd) (
+	dto WaitingDTO, code string, err error,
+) {
+	position, err := s.waitlistRepo.GetPosition(cmd.CloudId, cmd.User)
+	if err != nil {
+		if commonrepo.IsErrorResourceNotExists(err) {
+			code = errorNotWaiting
+		}
+
+		return
+	}
+
+	total, err := s.waitlistRepo.Count(cmd.CloudId)
+	if err != nil {
+		return
+	}
+
+	dto = WaitingDTO{
+		CloudId:  cmd.CloudId,
+		Position: position,
+		Total:    total,
+	}
+
+	return
+}
+
+func (s *cloudService) LeaveWaitlist(cmd *SubscribeCloudCmd) error {
+	return s.waitlistRepo.Remove(cmd.CloudId, cmd.User)
+}
+
+// subscribeWaitings subscribes the cloud for the waiting users in order
+// until there is no idle resource. The user leaves the waitlist only after
+// it is subscribed or it is notified of the reason why it can't be.
+// The watcher runs in one instance, and subscribing the cloud is atomic
+// among all the instances, so the resource will not be over subscribed.
+func (s *cloudWatchService) subscribeWaitings(conf *domain.CloudConf) error {
+	for {
+		w, err := s.waitlistRepo.GetHead(conf.Id)
+		if err != nil {
+			if commonrepo.IsErrorResourceNotExists(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		// the user keeps waiting if it failed to subscribe by accident.
+		msg, err := s.subscribe(conf, &w)
+		if err != nil {
+			if repository.IsErrorCloudBusy(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if err = s.waitlistRepo.Remove(w.CloudId, w.Owner); err != nil {
+			return err
+		}
+
+		if msg == nil {
+			continue
+		}
+
+		if err = s.producer.NotifyWaiting(msg); err != nil {
+			logrus.Errorf(
+				"notify the waiting user(%s) failed, err:%s", w.Owner.Account(), err.Error(),
+			)
+		}
+	}
+}
+
+// subscribe returns the notice to the user, which is nil if the user
+// has subscribed the cloud by itself.
+func (s *cloudWatchService) subscribe(c *domain.CloudConf, w *domain.Waiting) (
+	*message.MsgNotice, error,
+) {
+	_, ok, err := s.cloudService.CheckUserCanSubsribe(w.Owner, w.CloudId)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	msg := new(message.MsgNotice)
+
+	ok, err = s.cloudService.HasSufficientCredit(w.Owner, c.PodCost(c.PodSurvivalTime()))
+	if err != nil {
+		return nil, err
+	}
+
+	if !ok {
+		msg.ToMsgWaitingFailed(c, w.Owner, "insufficient credit")
+
+		return msg, nil
+	}
+
+	exceed, err := s.workspace.IsExceedQuota(w.Owner)
+	if err != nil {
+		return nil, err
+	}
+
+	if exceed {
+		msg.ToMsgWaitingFailed(c, w.Owner, "the workspace exceeds the quota")
+
+		return msg, nil
+	}
+
+	if err = s.cloudService.SubscribeCloud(c, w.Owner, 0); err != nil {
+		if !repository.IsErrorCreditInsufficient(err) {
+			return nil, err
+		}
+
+		msg.ToMsgWaitingFailed(c, w.Owner, "insufficient credit")
+
+		return msg, nil
+	}
+
+	msg.ToMsgWaitingSubscribed(c, w.Owner)
+
+	return msg, nil
+}
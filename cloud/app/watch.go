@@ -0,0 +1,121 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/cloud/domain/message"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	"github.com/opensourceways/xihe-server/cloud/domain/service"
+	"github.com/opensourceways/xihe-server/cloud/domain/workspace"
+)
+
+type CloudWatchService interface {
+	// Watch terminates the holding pods which expired before t, releases
+	// the ones whose workspace exceeds the quota, and then subscribes the cloud for the
+	// waiting users with the released resource.
+	Watch(t int64) error
+}
+
+func NewCloudWatchService(
+	cloudRepo repository.Cloud,
+	podRepo repository.Pod,
+	creditRepo repository.Credit,
+	waitlistRepo repository.Waitlist,
+	workspace workspace.Workspace,
+	producer message.CloudMessageProducer,
+) CloudWatchService {
+	return &cloudWatchService{
+		cloudRepo:    cloudRepo,
+		podRepo:      podRepo,
+		waitlistRepo: waitlistRepo,
+		workspace:    workspace,
+		producer:     producer,
+		cloudService: service.NewCloudService(cloudRepo, podRepo, creditRepo, producer),
+	}
+}
+
+type cloudWatchService struct {
+	cloudRepo    repository.Cloud
+	podRepo      repository.Pod
+	waitlistRepo repository.Waitlist
+	workspace    workspace.Workspace
+	producer     message.CloudMessageProducer
+	cloudService service.CloudService
+}
+
+func (s *cloudWatchService) Watch(t int64) error {
+	if err := s.releaseExpiredPods(t); err != nil {
+		return err
+	}
+
+	confs, err := s.cloudRepo.ListCloudConf()
+	if err != nil {
+		return err
+	}
+
+	for i := range confs {
+		if err := s.releaseOverQuotaPods(confs[i].Id); err != nil {
+			logrus.Errorf(
+				"release the pods exceeding the workspace quota of cloud(%s) failed, err:%s",
+				confs[i].Id, err.Error(),
+			)
+		}
+
+		if err := s.subscribeWaitings(&confs[i]); err != nil {
+			logrus.Errorf(
+				"subscribe cloud(%s) for waiting users failed, err:%s",
+				confs[i].Id, err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+func (s *cloudWatchService) releaseExpiredPods(t int64) error {
+	v, err := s.podRepo.GetExpiredPods(t)
+	if err != nil {
+		return err
+	}
+
+	for i := range v.PodInfos {
+		p := &v.PodInfos[i]
+
+		// the instance is released by the container manager when it expires.
+		if err := s.cloudService.TerminatePod(p); err != nil {
+			logrus.Errorf("terminate expired pod(%s) failed, err:%s", p.Id, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// releaseOverQuotaPods releases the holding pods whose workspace exceeds the quota,
+// because the container manager can't limit the size of workspace.
+func (s *cloudWatchService) releaseOverQuotaPods(cid string) error {
+	v, err := s.podRepo.GetHoldingPods(cid)
+	if err != nil {
+		return err
+	}
+
+	for i := range v.PodInfos {
+		p := &v.PodInfos[i]
+
+		b, err := s.workspace.IsExceedQuota(p.Owner)
+		if err != nil {
+			logrus.Errorf("check the workspace of user(%s) failed, err:%s", p.Owner.Account(), err.Error())
+
+			continue
+		}
+
+		if !b {
+			continue
+		}
+
+		if err := s.cloudService.ReleasePod(p); err != nil {
+			logrus.Errorf("release pod(%s) exceeding the quota failed, err:%s", p.Id, err.Error())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package app
+
+import (
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+
+	"github.com/opensourceways/xihe-server/cloud/domain/workspace"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+)
+
+type WorkspaceSnapshotCmd struct {
+	// User commits the files of workspace to the repo of project.
+	User     platform.UserInfo
+	RepoId   string
+	RepoName types.ResourceName
+	Dir      types.Directory
+}
+
+func (cmd *WorkspaceSnapshotCmd) Validate() error {
+	b := cmd.User.User != nil &&
+		cmd.RepoId != "" &&
+		cmd.RepoName != nil &&
+		cmd.Dir != nil
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	return nil
+}
+
+type WorkspaceFileDTO struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+type WorkspaceDTO struct {
+	Usage int64              `json:"usage"`
+	Quota int64              `json:"quota"`
+	Files []WorkspaceFileDTO `json:"files"`
+}
+
+type WorkspaceSnapshotDTO struct {
+	Saved   []string `json:"saved"`
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+type WorkspaceService interface {
+	Get(types.Account) (WorkspaceDTO, error)
+
+	// Snapshot commits the files of workspace to the repo of project by one commit,
+	// and the files which are too large or not allowed to save to repo will be skipped.
+	Snapshot(*WorkspaceSnapshotCmd) (WorkspaceSnapshotDTO, string, error)
+}
+
+func NewWorkspaceService(ws workspace.Workspace, rf platform.RepoFile) WorkspaceService {
+	return &workspaceService{
+		ws: ws,
+		rf: rf,
+	}
+}
+
+type workspaceService struct {
+	ws workspace.Workspace
+	rf platform.RepoFile
+}
+
+func (s *workspaceService) Get(user types.Account) (dto WorkspaceDTO, err error) {
+	v, err := s.ws.Volume(user)
+	if err != nil {
+		return
+	}
+
+	if dto.Usage, err = s.ws.Usage(user); err != nil {
+		return
+	}
+
+	files, err := s.ws.ListFiles(user)
+	if err != nil {
+		return
+	}
+
+	dto.Quota = v.Quota
+	dto.Files = make([]WorkspaceFileDTO, len(files))
+	for i := range files {
+		dto.Files[i] = WorkspaceFileDTO{
+			Path: files[i].Path,
+			Size: files[i].Size,
+		}
+	}
+
+	return
+}
+
+func (s *workspaceService) Snapshot(cmd *WorkspaceSnapshotCmd) (
+	dto WorkspaceSnapshotDTO, code string, err error,
+) {
+	files, err := s.ws.ListFiles(cmd.User.User)
+	if err != nil {
+		return
+	}
+
+	if len(files) == 0 {
+		code = errorWorkspaceEmpty
+		err = errors.New("no file in workspace")
+
+		return
+	}
+
+	commits := make([]platform.RepoCommitFile, 0, len(files))
+
+	for i := range files {
+		f, ok, err1 := s.toCommitFile(cmd, files[i].Path)
+		if err1 != nil {
+			err = err1
+
+			return
+		}
+
+		if ok {
+			commits = append(commits, f)
+			dto.Saved = append(dto.Saved, files[i].Path)
+		} else {
+			dto.Skipped = append(dto.Skipped, files[i].Path)
+		}
+	}
+
+	if len(commits) == 0 {
+		return
+	}
+
+	err = s.rf.CommitFiles(
+		&cmd.User,
+		&platform.RepoDirInfo{
+			RepoDir: platform.RepoDir{
+				RepoName: cmd.RepoName,
+				Path:     cmd.Dir,
+			},
+			RepoId: cmd.RepoId,
+		},
+		commits,
+	)
+	if err != nil {
+		dto = WorkspaceSnapshotDTO{}
+	}
+
+	return
+}
+
+// toCommitFile returns false if the file can't be saved to the repo.
+func (s *workspaceService) toCommitFile(cmd *WorkspaceSnapshotCmd, path string) (
+	f platform.RepoCommitFile, ok bool, err error,
+) {
+	p, err1 := types.NewFilePath(filepath.Join(cmd.Dir.Directory(), path))
+	if err1 != nil {
+		return
+	}
+
+	info := platform.RepoFileInfo{
+		RepoId: cmd.RepoId,
+		Path:   p,
+	}
+
+	if info.BlacklistFilter() {
+		return
+	}
+
+	data, err := s.ws.ReadFile(cmd.User.User, path)
+	if err != nil {
+		return
+	}
+
+	v := base64.StdEncoding.EncodeToString(data)
+	f = platform.RepoCommitFile{
+		Path: p,
+		Content: platform.RepoFileContent{
+			Content:   &v,
+			IsEncoded: true,
+		},
+	}
+
+	ok = !f.Content.IsOverSize()
+
+	return
+}
@@ -1,5 +1,7 @@
 package domain
 
+const defaultPodSurvivalTime = 2 * 60 * 60
+
 type CloudConf struct {
 	Id        string
 	Name      CloudName
@@ -9,6 +11,53 @@ type CloudConf struct {
 	Processor CloudProcessor
 	Limited   CloudLimited
 	Credit    Credit
+
+	// SurvivalTime is the initial survival time of pod.
+	SurvivalTime SurvivalTime
+	// MaxSurvivalTime is the max survival time of pod including the extension.
+	MaxSurvivalTime SurvivalTime
+}
+
+func (c *CloudConf) PodSurvivalTime() int64 {
+	if c.SurvivalTime == nil || c.SurvivalTime.SurvivalTime() == 0 {
+		return defaultPodSurvivalTime
+	}
+
+	return c.SurvivalTime.SurvivalTime()
+}
+
+// PodCost returns the credit to hold the pod for the survival time in seconds,
+// and any part of an hour is charged as a whole one.
+func (c *CloudConf) PodCost(survivalTime int64) int64 {
+	if survivalTime <= 0 || c.Credit == nil {
+		return 0
+	}
+
+	hours := (survivalTime + 3599) / 3600
+
+	return hours * c.Credit.Credit()
+}
+
+// PodRefund returns the credit of the whole hours in the unused time in seconds,
+// which is the opposite of PodCost.
+func (c *CloudConf) PodRefund(unusedTime int64) int64 {
+	if unusedTime <= 0 || c.Credit == nil {
+		return 0
+	}
+
+	return unusedTime / 3600 * c.Credit.Credit()
+}
+
+// PodMaxSurvivalTime returns the survival time of pod if the max one is not set,
+// which means the pod can't be extended.
+func (c *CloudConf) PodMaxSurvivalTime() int64 {
+	v := c.PodSurvivalTime()
+
+	if c.MaxSurvivalTime != nil && c.MaxSurvivalTime.SurvivalTime() > v {
+		return c.MaxSurvivalTime.SurvivalTime()
+	}
+
+	return v
 }
 
 type Cloud struct {
@@ -1,10 +1,35 @@
 package cloud
 
+// ErrorUnsupported means the container manager can't do the operation.
+type ErrorUnsupported struct {
+	error
+}
+
+func NewErrorUnsupported(err error) ErrorUnsupported {
+	return ErrorUnsupported{err}
+}
+
+func IsErrorUnsupported(err error) bool {
+	_, ok := err.(ErrorUnsupported)
+
+	return ok
+}
+
 type CloudPodCreateInfo struct {
 	PodId        string
 	SurvivalTime int64
 }
 
+// CloudPodExtendInfo resets the survival time of pod from now on.
+type CloudPodExtendInfo struct {
+	PodId        string
+	SurvivalTime int64
+}
+
 type CloudPod interface {
 	Create(*CloudPodCreateInfo) error
-}
\ No newline at end of file
+
+	// Extend and Release return ErrorUnsupported if the manager can't do it.
+	Extend(*CloudPodExtendInfo) error
+	Release(podId string) error
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	creditTransactionTypeGrant  = "grant"
+	creditTransactionTypeDebit  = "debit"
+	creditTransactionTypeRefund = "refund"
+)
+
+type CreditBalance struct {
+	Owner   types.Account
+	Balance int64
+}
+
+func (b *CreditBalance) IsSufficient(amount int64) bool {
+	return b.Balance >= amount
+}
+
+type CreditTransaction struct {
+	Id      string
+	Owner   types.Account
+	Type    string
+	Amount  Credit
+	PodId   string
+	CloudId string
+	Remark  string
+
+	// Balance is the balance after the transaction.
+	Balance   int64
+	CreatedAt int64
+}
+
+func (t *CreditTransaction) IsGrant() bool {
+	return t.Type == creditTransactionTypeGrant
+}
+
+func (t *CreditTransaction) IsRefund() bool {
+	return t.Type == creditTransactionTypeRefund
+}
+
+// Change returns the change of balance caused by the transaction.
+func (t *CreditTransaction) Change() int64 {
+	if t.IsGrant() || t.IsRefund() {
+		return t.Amount.Credit()
+	}
+
+	return -t.Amount.Credit()
+}
+
+func NewCreditGrant(owner types.Account, amount int64, remark string) (
+	t CreditTransaction, err error,
+) {
+	if amount <= 0 {
+		err = errors.New("invalid amount")
+
+		return
+	}
+
+	t = CreditTransaction{
+		Owner:     owner,
+		Type:      creditTransactionTypeGrant,
+		Remark:    remark,
+		CreatedAt: utils.Now(),
+	}
+
+	t.Amount, err = NewCredit(amount)
+
+	return
+}
+
+// NewCreditDebit returns the transaction to pay for the pod.
+func NewCreditDebit(p *Pod, amount int64, remark string) (t CreditTransaction, err error) {
+	t = CreditTransaction{
+		Owner:     p.Owner,
+		Type:      creditTransactionTypeDebit,
+		PodId:     p.Id,
+		CloudId:   p.CloudId,
+		Remark:    remark,
+		CreatedAt: utils.Now(),
+	}
+
+	t.Amount, err = NewCredit(amount)
+
+	return
+}
+
+// NewCreditRefund returns the transaction to give back the credit which the pod didn't use.
+func NewCreditRefund(p *Pod, amount int64, remark string) (t CreditTransaction, err error) {
+	t, err = NewCreditDebit(p, amount, remark)
+	t.Type = creditTransactionTypeRefund
+
+	return
+}
@@ -148,6 +148,25 @@ func (r cloudLimited) CloudLimited() int {
 	return int(r)
 }
 
+// SurvivalTime
+type SurvivalTime interface {
+	SurvivalTime() int64
+}
+
+func NewSurvivalTime(v int64) (SurvivalTime, error) {
+	if v < 0 {
+		return nil, errors.New("invalid value")
+	}
+
+	return survivalTime(v), nil
+}
+
+type survivalTime int64
+
+func (r survivalTime) SurvivalTime() int64 {
+	return int64(r)
+}
+
 // CloudRemain
 type CloudRemain interface {
 	CloudRemain() int
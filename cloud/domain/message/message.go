@@ -2,46 +2,100 @@ package message
 
 import (
 	"github.com/opensourceways/xihe-server/cloud/domain"
+	comsg "github.com/opensourceways/xihe-server/common/domain/message"
 	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	MsgTypePodRelease = "msg_type_pod_release"
+	MsgTypePodExtend  = "msg_type_pod_extend"
+
+	MsgTypeWaitingSubscribed = "msg_type_cloud_waiting_subscribed"
+	MsgTypeWaitingFailed     = "msg_type_cloud_waiting_failed"
 )
 
 type MsgCloudConf struct {
-	User      string `json:"user"`
-	PodId     string `json:"pod_id"`
-	CloudId   string `json:"cloud_id"`
-	CloudName string `json:"cloud_name"`
+	User         string `json:"user"`
+	PodId        string `json:"pod_id"`
+	CloudId      string `json:"cloud_id"`
+	CloudName    string `json:"cloud_name"`
+	SurvivalTime int64  `json:"survival_time"`
 }
 
 type MsgPod struct {
+	Type    string `json:"type"`
 	PodId   string `json:"pod_id"`
 	CloudId string `json:"cloud_id"`
 	Owner   string `json:"owner"`
+	Expiry  int64  `json:"expiry,omitempty"`
 }
 
 type CloudMessageProducer interface {
 	SubscribeCloud(*MsgCloudConf) error
 	ReleasePod(*MsgPod) error
+	ExtendPod(*MsgPod) error
+	NotifyWaiting(*MsgNotice) error
 
 	AddOperateLogForCloudSubscribe(u types.Account, cloudId string) error
 }
 
 func (r *MsgCloudConf) ToMsgCloudConf(c *domain.CloudConf, u types.Account, pid string) {
 	*r = MsgCloudConf{
-		User:      u.Account(),
-		PodId:     pid,
-		CloudId:   c.Id,
-		CloudName: c.Name.CloudName(),
+		User:         u.Account(),
+		PodId:        pid,
+		CloudId:      c.Id,
+		CloudName:    c.Name.CloudName(),
+		SurvivalTime: c.PodSurvivalTime(),
 	}
 }
 
-func (r *MsgPod) ToMsgPod(p *domain.Pod) {
+func (r *MsgPod) ToMsgPodRelease(p *domain.Pod) {
 	*r = MsgPod{
+		Type:    MsgTypePodRelease,
 		PodId:   p.Id,
 		CloudId: p.CloudId,
 		Owner:   p.Owner.Account(),
 	}
 }
 
+// ToMsgPodExtend sends the expiry which the pod is extended to.
+func (r *MsgPod) ToMsgPodExtend(p *domain.PodInfo) {
+	*r = MsgPod{
+		Type:    MsgTypePodExtend,
+		PodId:   p.Id,
+		CloudId: p.CloudId,
+		Owner:   p.Owner.Account(),
+		Expiry:  p.Expiry.PodExpiry(),
+	}
+}
+
+// MsgNotice is the notice to user. It is consumed by the message center
+// outside of this server which notifies the user.
+type MsgNotice comsg.MsgNormal
+
+func (r *MsgNotice) ToMsgWaitingSubscribed(c *domain.CloudConf, u types.Account) {
+	*r = MsgNotice{
+		Type: MsgTypeWaitingSubscribed,
+		User: u.Account(),
+		Details: map[string]string{
+			"cloud_id":   c.Id,
+			"cloud_name": c.Name.CloudName(),
+		},
+		CreatedAt: utils.Now(),
+	}
+}
+
+// ToMsgWaitingFailed tells the user why it left the waitlist without subscribing the cloud.
+func (r *MsgNotice) ToMsgWaitingFailed(c *domain.CloudConf, u types.Account, reason string) {
+	r.ToMsgWaitingSubscribed(c, u)
+
+	r.Type = MsgTypeWaitingFailed
+	r.Details["reason"] = reason
+}
+
 type CloudMessageHandler interface {
 	HandleEventPodSubscribe(info *domain.PodInfo) error
+	HandleEventPodRelease(info *domain.PodInfo) error
+	HandleEventPodExtend(info *domain.PodInfo) error
 }
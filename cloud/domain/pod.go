@@ -1,11 +1,16 @@
 package domain
 
 import (
+	"errors"
+
 	types "github.com/opensourceways/xihe-server/common/domain"
 	otypes "github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
+// podExpiryWarningTime is the time before the expiry when the user will be warned.
+const podExpiryWarningTime = 10 * 60
+
 type Pod struct {
 	Id      string
 	CloudId string
@@ -38,12 +43,80 @@ func (p *PodInfo) IsFailedOrTerminated() bool {
 	return p.Status.IsFailed() || p.Status.IsTerminated()
 }
 
+func (p *PodInfo) IsHolding() bool {
+	return p.Status.IsCreating() || p.Status.IsStarting() || p.Status.IsRunning()
+}
+
+// RemainingTime returns the seconds before the expiry.
+func (p *PodInfo) RemainingTime() int64 {
+	if p.Expiry == nil || p.Expiry.PodExpiry() <= 0 {
+		return 0
+	}
+
+	if v := p.Expiry.PodExpiry() - utils.Now(); v > 0 {
+		return v
+	}
+
+	return 0
+}
+
+// UnusedTime returns the seconds of the survival time which the pod has not used.
+// The expiry is not set until the pod instance is created, so the whole survival
+// time is unused before it.
+func (p *PodInfo) UnusedTime(survivalTime int64) int64 {
+	if p.Expiry == nil || p.Expiry.PodExpiry() <= 0 {
+		return survivalTime
+	}
+
+	if v := p.RemainingTime(); v < survivalTime {
+		return v
+	}
+
+	return survivalTime
+}
+
+func (p *PodInfo) IsAboutToExpire() bool {
+	return p.Status.IsRunning() && p.RemainingTime() <= podExpiryWarningTime
+}
+
+func (p *PodInfo) CanExtend() bool {
+	return p.Status.IsRunning() && !p.IsExpiried()
+}
+
+// Extend extends the expiry by the duration in seconds, and the
+// survival time of pod can't exceed the max one of cloud conf.
+func (p *PodInfo) Extend(c *CloudConf, duration int64) (err error) {
+	if duration <= 0 {
+		return errors.New("invalid duration")
+	}
+
+	expiry := p.Expiry.PodExpiry() + duration
+
+	if expiry-p.CreatedAt.Time() > c.PodMaxSurvivalTime() {
+		return errors.New("exceed the max survival time")
+	}
+
+	p.Expiry, err = NewPodExpiry(expiry)
+
+	return
+}
+
 func (p *PodInfo) IsHoldingAndNotExpiried() bool {
 	if p.IsExpiried() {
 		return false
 	}
 
-	return p.Status.IsCreating() || p.Status.IsStarting() || p.Status.IsRunning()
+	return p.IsHolding()
+}
+
+// IsOccupying checks whether the pod occupies the resource of cloud.
+// The expiry of a starting pod is not set until the pod instance is created.
+func (p *PodInfo) IsOccupying() bool {
+	if p.Status.IsStarting() {
+		return true
+	}
+
+	return p.IsHoldingAndNotExpiried()
 }
 
 func (p *PodInfo) CheckGoodAndSet() bool {
@@ -67,6 +140,15 @@ func (p *PodInfo) StatusSetFailed() {
 	p.Status, _ = NewPodStatus(cloudPodStatusFailed)
 }
 
+// StatusSetTerminated terminates the pod and releases the resource at once.
+func (p *PodInfo) StatusSetTerminated() {
+	p.Status, _ = NewPodStatus(cloudPodStatusTerminated)
+
+	if now := utils.Now(); p.Expiry == nil || p.Expiry.PodExpiry() > now {
+		p.Expiry, _ = NewPodExpiry(now)
+	}
+}
+
 func (p *PodInfo) SetStatus() {
 	if p.AccessURL.AccessURL() != "" {
 		p.StatusSetRunning()
@@ -75,11 +157,15 @@ func (p *PodInfo) SetStatus() {
 	}
 }
 
-func (p *PodInfo) SetDefaultExpiry() (err error) {
-	if p.Expiry, err = NewPodExpiry(utils.Now() + 2*60*60); err != nil { // TODO conifg
-		return
+// SetExpiry sets the expiry by the survival time in seconds,
+// and the default survival time will be used if it is not set.
+func (p *PodInfo) SetExpiry(survivalTime int64) (err error) {
+	if survivalTime <= 0 {
+		survivalTime = defaultPodSurvivalTime
 	}
 
+	p.Expiry, err = NewPodExpiry(utils.Now() + survivalTime)
+
 	return
 }
 
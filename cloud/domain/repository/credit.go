@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// ErrorCreditInsufficient
+type ErrorCreditInsufficient struct {
+	error
+}
+
+func NewErrorCreditInsufficient(err error) ErrorCreditInsufficient {
+	return ErrorCreditInsufficient{err}
+}
+
+func IsErrorCreditInsufficient(err error) bool {
+	_, ok := err.(ErrorCreditInsufficient)
+
+	return ok
+}
+
+type CreditTransactionListOption struct {
+	PageNum      int
+	CountPerPage int
+}
+
+type CreditTransactionList struct {
+	Total        int
+	Transactions []domain.CreditTransaction
+}
+
+type Credit interface {
+	// GetBalance returns zero balance if the user has no balance.
+	GetBalance(types.Account) (domain.CreditBalance, error)
+
+	// AddTransaction changes the balance and saves the transaction at once.
+	// It returns ErrorCreditInsufficient if the balance is not enough to debit.
+	AddTransaction(*domain.CreditTransaction) (domain.CreditBalance, error)
+
+	ListTransactions(types.Account, *CreditTransactionListOption) (CreditTransactionList, error)
+}
@@ -5,14 +5,34 @@ import (
 	types "github.com/opensourceways/xihe-server/domain"
 )
 
+// ErrorCloudBusy
+type ErrorCloudBusy struct {
+	error
+}
+
+func NewErrorCloudBusy(err error) ErrorCloudBusy {
+	return ErrorCloudBusy{err}
+}
+
+func IsErrorCloudBusy(err error) bool {
+	_, ok := err.(ErrorCloudBusy)
+
+	return ok
+}
+
 type PodInfoList struct {
 	PodInfos []domain.PodInfo
 }
 
 type Pod interface {
-	GetRunningPod(cid string) (PodInfoList, error)
+	GetHoldingPods(cid string) (PodInfoList, error)
+	// GetExpiredPods returns the holding pods which expired before t.
+	GetExpiredPods(t int64) (PodInfoList, error)
 	GetPodInfo(pid string) (domain.PodInfo, error)
 	GetUserCloudIdLastPod(user types.Account, cloudId string) (domain.PodInfo, error)
-	AddStartingPod(*domain.PodInfo) (pid string, err error)
+	// AddStartingPodIfIdle adds the starting pod only if the pods occupying the cloud
+	// are less than the limit, and it is atomic among all the instances of server.
+	// It returns ErrorCloudBusy if there is no idle resource.
+	AddStartingPodIfIdle(p *domain.PodInfo, limit int) (pid string, err error)
 	UpdatePod(*domain.PodInfo) error
 }
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// Waitlist is the FIFO queue of users for each cloud.
+type Waitlist interface {
+	// Add does nothing if the user is waiting for the cloud already.
+	Add(*domain.Waiting) error
+	Remove(cid string, user types.Account) error
+	Count(cid string) (int, error)
+	GetHead(cid string) (domain.Waiting, error)
+
+	// GetPosition returns the position of user in the queue which starts from 1.
+	GetPosition(cid string, user types.Account) (int, error)
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"github.com/sirupsen/logrus"
+
 	"github.com/opensourceways/xihe-server/cloud/domain"
 	"github.com/opensourceways/xihe-server/cloud/domain/message"
 	"github.com/opensourceways/xihe-server/cloud/domain/repository"
@@ -9,16 +11,22 @@ import (
 )
 
 type CloudService struct {
-	podRepo repository.Pod
-	sender  message.CloudMessageProducer
+	cloudRepo  repository.Cloud
+	podRepo    repository.Pod
+	creditRepo repository.Credit
+	sender     message.CloudMessageProducer
 }
 
 func NewCloudService(
+	cloud repository.Cloud,
 	pod repository.Pod,
+	credit repository.Credit,
 	sender message.CloudMessageProducer,
 ) CloudService {
 	return CloudService{
+		cloud,
 		pod,
+		credit,
 		sender,
 	}
 }
@@ -26,10 +34,10 @@ func NewCloudService(
 func (r *CloudService) caculateRemain(
 	c *domain.Cloud, p *repository.PodInfoList,
 ) (err error) {
-	// caculate running and not expiry pod
+	// caculate holding and not expiry pod
 	var count int
 	for i := range p.PodInfos {
-		if !p.PodInfos[i].IsExpiried() {
+		if p.PodInfos[i].IsOccupying() {
 			count++
 		}
 	}
@@ -46,7 +54,7 @@ func (r *CloudService) caculateRemain(
 }
 
 func (r *CloudService) ToCloud(c *domain.Cloud) (err error) {
-	plist, err := r.podRepo.GetRunningPod(c.CloudConf.Id)
+	plist, err := r.podRepo.GetHoldingPods(c.CloudConf.Id)
 	if err != nil {
 		return
 	}
@@ -54,8 +62,10 @@ func (r *CloudService) ToCloud(c *domain.Cloud) (err error) {
 	return r.caculateRemain(c, &plist)
 }
 
+// SubscribeCloud subscribes the cloud if there is idle resource except the reserved,
+// otherwise it returns repository.ErrorCloudBusy.
 func (r *CloudService) SubscribeCloud(
-	c *domain.CloudConf, u types.Account,
+	c *domain.CloudConf, u types.Account, reserved int,
 ) (err error) {
 	// save into repo
 	p := new(domain.PodInfo)
@@ -64,7 +74,20 @@ func (r *CloudService) SubscribeCloud(
 	}
 
 	var pid string
-	if pid, err = r.podRepo.AddStartingPod(p); err != nil {
+	limit := c.Limited.CloudLimited() - reserved
+	if pid, err = r.podRepo.AddStartingPodIfIdle(p, limit); err != nil {
+		return
+	}
+
+	// pay for the pod
+	p.Id = pid
+	if err = r.debit(&p.Pod, c.PodCost(c.PodSurvivalTime()), "subscribe cloud"); err != nil {
+		p.StatusSetFailed()
+
+		if err1 := r.podRepo.UpdatePod(p); err1 != nil {
+			logrus.Errorf("set pod(%s) failed, err:%s", pid, err1.Error())
+		}
+
 		return
 	}
 
@@ -114,9 +137,130 @@ func (r *CloudService) HasHolding(user types.Account, c *domain.CloudConf) (bool
 	return false, nil
 }
 
-func (r *CloudService) ReleasePod(p *domain.Pod) error {
+// ReleasePod asks the container manager to release the pod instance asynchronously.
+// The pod keeps occupying the resource until the instance is released, see PodReleased.
+func (r *CloudService) ReleasePod(p *domain.PodInfo) error {
 	msg := new(message.MsgPod)
-	msg.ToMsgPod(p)
+	msg.ToMsgPodRelease(&p.Pod)
 
 	return r.sender.ReleasePod(msg)
 }
+
+// PodReleased terminates the pod whose instance has been released, and refunds
+// the whole hours which the pod has not used to the owner.
+func (r *CloudService) PodReleased(pid string) error {
+	p, err := r.podRepo.GetPodInfo(pid)
+	if err != nil {
+		return err
+	}
+
+	if !p.IsHolding() {
+		return nil
+	}
+
+	c, err := r.cloudRepo.GetCloudConf(p.CloudId)
+	if err != nil {
+		return err
+	}
+
+	unused := p.UnusedTime(c.PodSurvivalTime())
+
+	if err := r.TerminatePod(&p); err != nil {
+		return err
+	}
+
+	if err := r.refund(&p.Pod, c.PodRefund(unused), "release pod"); err != nil {
+		logrus.Errorf("refund pod(%s) failed, err:%s", p.Id, err.Error())
+	}
+
+	return nil
+}
+
+// TerminatePod terminates the pod in the DB, and it should only be called when
+// the pod instance is not running any more, such as the pod has expired.
+func (r *CloudService) TerminatePod(p *domain.PodInfo) error {
+	p.StatusSetTerminated()
+
+	return r.podRepo.UpdatePod(p)
+}
+
+// ExtendPod asks the container manager to extend the pod instance to the expiry
+// of p asynchronously, see PodExtended.
+func (r *CloudService) ExtendPod(p *domain.PodInfo) error {
+	msg := new(message.MsgPod)
+	msg.ToMsgPodExtend(p)
+
+	return r.sender.ExtendPod(msg)
+}
+
+// PodExtended updates the expiry of pod whose instance has been extended to
+// the expiry, and the extended hours cost the credit.
+func (r *CloudService) PodExtended(pid string, expiry domain.PodExpiry) error {
+	p, err := r.podRepo.GetPodInfo(pid)
+	if err != nil {
+		return err
+	}
+
+	duration := expiry.PodExpiry() - p.Expiry.PodExpiry()
+	if duration <= 0 {
+		return nil
+	}
+
+	c, err := r.cloudRepo.GetCloudConf(p.CloudId)
+	if err != nil {
+		return err
+	}
+
+	// the instance has been extended, so the pod should be updated
+	// even if the credit is insufficient now.
+	if err := r.debit(&p.Pod, c.PodCost(duration), "extend pod"); err != nil {
+		logrus.Errorf("debit extended pod(%s) failed, err:%s", p.Id, err.Error())
+	}
+
+	p.Expiry = expiry
+
+	return r.podRepo.UpdatePod(&p)
+}
+
+func (r *CloudService) HasSufficientCredit(user types.Account, cost int64) (bool, error) {
+	if cost <= 0 {
+		return true, nil
+	}
+
+	b, err := r.creditRepo.GetBalance(user)
+	if err != nil {
+		return false, err
+	}
+
+	return b.IsSufficient(cost), nil
+}
+
+func (r *CloudService) debit(p *domain.Pod, cost int64, remark string) error {
+	if cost <= 0 {
+		return nil
+	}
+
+	t, err := domain.NewCreditDebit(p, cost, remark)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.creditRepo.AddTransaction(&t)
+
+	return err
+}
+
+func (r *CloudService) refund(p *domain.Pod, amount int64, remark string) error {
+	if amount <= 0 {
+		return nil
+	}
+
+	t, err := domain.NewCreditRefund(p, amount, remark)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.creditRepo.AddTransaction(&t)
+
+	return err
+}
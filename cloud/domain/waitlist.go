@@ -0,0 +1,21 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// Waiting is the user waiting for the idle resource of cloud.
+type Waiting struct {
+	CloudId   string
+	Owner     types.Account
+	CreatedAt int64
+}
+
+func NewWaiting(cid string, owner types.Account) Waiting {
+	return Waiting{
+		CloudId:   cid,
+		Owner:     owner,
+		CreatedAt: utils.Now(),
+	}
+}
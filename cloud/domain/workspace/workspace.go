@@ -0,0 +1,31 @@
+package workspace
+
+import types "github.com/opensourceways/xihe-server/domain"
+
+// Volume is the persistent workspace of user which will be mounted to the pod.
+type Volume struct {
+	Path  string
+	Quota int64
+}
+
+type File struct {
+	// Path is the relative path in the workspace.
+	Path string
+	Size int64
+}
+
+type Workspace interface {
+	// Volume returns the workspace of user and creates it if it does not exist.
+	Volume(types.Account) (Volume, error)
+
+	// Usage returns the bytes used by the workspace of user.
+	Usage(types.Account) (int64, error)
+
+	IsExceedQuota(types.Account) (bool, error)
+
+	// ListFiles returns the files which can be saved to the repo,
+	// and the hidden files and directories are excluded.
+	ListFiles(types.Account) ([]File, error)
+
+	ReadFile(user types.Account, path string) ([]byte, error)
+}
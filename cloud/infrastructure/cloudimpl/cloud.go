@@ -1,6 +1,8 @@
 package cloudimpl
 
 import (
+	"errors"
+
 	"github.com/opensourceways/xihe-inference-evaluate/sdk"
 	"github.com/opensourceways/xihe-server/cloud/domain/cloud"
 )
@@ -25,3 +27,15 @@ func (impl *cloudpodImpl) Create(info *cloud.CloudPodCreateInfo) error {
 
 	return impl.cli.CreateCloudPod(opt)
 }
+
+func (impl *cloudpodImpl) Extend(info *cloud.CloudPodExtendInfo) error {
+	return cloud.NewErrorUnsupported(
+		errors.New("the container manager can't extend the cloud pod"),
+	)
+}
+
+func (impl *cloudpodImpl) Release(podId string) error {
+	return cloud.NewErrorUnsupported(
+		errors.New("the container manager can't release the cloud pod"),
+	)
+}
@@ -5,5 +5,8 @@ type Config struct {
 }
 
 type Table struct {
-	Pod string `json:"pod" reuired:"true"`
+	Pod               string `json:"pod"                reuired:"true"`
+	CreditBalance     string `json:"credit_balance"     required:"true"`
+	CreditTransaction string `json:"credit_transaction" required:"true"`
+	Waitlist          string `json:"waitlist"           required:"true"`
 }
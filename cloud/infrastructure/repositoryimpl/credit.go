@@ -0,0 +1,147 @@
+package repositoryimpl
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func NewCreditRepo(cfg *Config) repository.Credit {
+	return &creditRepoImpl{
+		balanceTable:     cfg.Table.CreditBalance,
+		transactionTable: cfg.Table.CreditTransaction,
+		balance:          pgsql.NewDBTable(cfg.Table.CreditBalance),
+		transaction:      pgsql.NewDBTable(cfg.Table.CreditTransaction),
+	}
+}
+
+type creditRepoImpl struct {
+	balanceTable     string
+	transactionTable string
+
+	balance     pgsqlClient
+	transaction pgsqlClient
+}
+
+func (impl *creditRepoImpl) GetBalance(user types.Account) (
+	b domain.CreditBalance, err error,
+) {
+	filter := map[string]interface{}{
+		fieldOwner: user.Account(),
+	}
+
+	var v TCreditBalance
+	err = impl.balance.GetRecord(filter, &v)
+	if err != nil && !impl.balance.IsRowNotFound(err) {
+		return
+	}
+
+	err = nil
+	b.Owner = user
+	b.Balance = v.Balance
+
+	return
+}
+
+func (impl *creditRepoImpl) AddTransaction(t *domain.CreditTransaction) (
+	b domain.CreditBalance, err error,
+) {
+	owner := t.Owner.Account()
+
+	err = impl.balance.DB().Transaction(func(tx *gorm.DB) error {
+		if err := impl.changeBalance(tx, owner, t.Change()); err != nil {
+			return err
+		}
+
+		var v TCreditBalance
+		err := tx.Table(impl.balanceTable).Where(fieldOwner+" = ?", owner).First(&v).Error
+		if err != nil {
+			return err
+		}
+
+		t.Balance = v.Balance
+
+		do := new(TCreditTransaction)
+		do.toTCreditTransaction(t)
+
+		if err = tx.Table(impl.transactionTable).Create(do).Error; err != nil {
+			return err
+		}
+
+		t.Id = do.Id
+
+		return nil
+	})
+
+	if err == nil {
+		b.Owner = t.Owner
+		b.Balance = t.Balance
+	}
+
+	return
+}
+
+func (impl *creditRepoImpl) changeBalance(tx *gorm.DB, owner string, change int64) error {
+	if change >= 0 {
+		return tx.Table(impl.balanceTable).Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: fieldOwner}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				fieldBalance: gorm.Expr(impl.balanceTable+"."+fieldBalance+" + ?", change),
+			}),
+		}).Create(&TCreditBalance{Owner: owner, Balance: change}).Error
+	}
+
+	r := tx.Table(impl.balanceTable).
+		Where(fieldOwner+" = ? AND "+fieldBalance+" >= ?", owner, -change).
+		Update(fieldBalance, gorm.Expr(fieldBalance+" + ?", change))
+	if r.Error != nil {
+		return r.Error
+	}
+
+	if r.RowsAffected == 0 {
+		return repository.NewErrorCreditInsufficient(errors.New("insufficient credit"))
+	}
+
+	return nil
+}
+
+func (impl *creditRepoImpl) ListTransactions(
+	user types.Account, opt *repository.CreditTransactionListOption,
+) (r repository.CreditTransactionList, err error) {
+	filter := map[string]interface{}{
+		fieldOwner: user.Account(),
+	}
+
+	if r.Total, err = impl.transaction.Count(filter); err != nil || r.Total == 0 {
+		return
+	}
+
+	var v []TCreditTransaction
+
+	err = impl.transaction.GetRecords(
+		filter, &v,
+		pgsql.Pagination{
+			PageNum:      opt.PageNum,
+			CountPerPage: opt.CountPerPage,
+		},
+		[]pgsql.SortByColumn{{Column: fieldCreatedAt}},
+	)
+	if err != nil {
+		return
+	}
+
+	r.Transactions = make([]domain.CreditTransaction, len(v))
+	for i := range v {
+		if err = v[i].toCreditTransaction(&r.Transactions[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
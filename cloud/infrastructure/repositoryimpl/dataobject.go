@@ -7,10 +7,13 @@ import (
 )
 
 const (
-	fieldId      = "id"
-	fieldCloudId = "cloud_id"
-	fieldStatus  = "status"
-	fieldOwner   = "owner"
+	fieldId        = "id"
+	fieldCloudId   = "cloud_id"
+	fieldStatus    = "status"
+	fieldExpiry    = "expiry"
+	fieldOwner     = "owner"
+	fieldBalance   = "balance"
+	fieldCreatedAt = "created_at"
 )
 
 func (doc *DCloudConf) toCloudConf(c *domain.CloudConf) (err error) {
@@ -44,6 +47,14 @@ func (doc *DCloudConf) toCloudConf(c *domain.CloudConf) (err error) {
 		return
 	}
 
+	if c.SurvivalTime, err = domain.NewSurvivalTime(doc.SurvivalTime); err != nil {
+		return
+	}
+
+	if c.MaxSurvivalTime, err = domain.NewSurvivalTime(doc.MaxSurvivalTime); err != nil {
+		return
+	}
+
 	return
 }
 
@@ -108,3 +119,52 @@ func (table *TPod) toTPod(p *domain.PodInfo) {
 		table.CreatedAt = p.CreatedAt.Time()
 	}
 }
+
+func (table *TCreditTransaction) toTCreditTransaction(t *domain.CreditTransaction) {
+	*table = TCreditTransaction{
+		Owner:     t.Owner.Account(),
+		Type:      t.Type,
+		Amount:    t.Amount.Credit(),
+		Balance:   t.Balance,
+		PodId:     t.PodId,
+		CloudId:   t.CloudId,
+		Remark:    t.Remark,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func (table *TCreditTransaction) toCreditTransaction(t *domain.CreditTransaction) (err error) {
+	*t = domain.CreditTransaction{
+		Id:        table.Id,
+		Type:      table.Type,
+		PodId:     table.PodId,
+		CloudId:   table.CloudId,
+		Remark:    table.Remark,
+		Balance:   table.Balance,
+		CreatedAt: table.CreatedAt,
+	}
+
+	if t.Owner, err = otypes.NewAccount(table.Owner); err != nil {
+		return
+	}
+
+	t.Amount, err = domain.NewCredit(table.Amount)
+
+	return
+}
+
+func (table *TWaiting) toTWaiting(w *domain.Waiting) {
+	*table = TWaiting{
+		CloudId:   w.CloudId,
+		Owner:     w.Owner.Account(),
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func (table *TWaiting) toWaiting(w *domain.Waiting) (err error) {
+	w.CloudId = table.CloudId
+	w.CreatedAt = table.CreatedAt
+	w.Owner, err = otypes.NewAccount(table.Owner)
+
+	return
+}
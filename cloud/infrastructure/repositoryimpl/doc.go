@@ -9,4 +9,7 @@ type DCloudConf struct {
 	Processor string `bson:"processor" json:"processor"`
 	Limited   int    `bson:"limited"   json:"limited"`
 	Credit    int64  `bson:"credit"    json:"credit"`
+
+	SurvivalTime    int64 `bson:"survival_time"     json:"survival_time"`
+	MaxSurvivalTime int64 `bson:"max_survival_time" json:"max_survival_time"`
 }
@@ -1,8 +1,13 @@
 package repositoryimpl
 
-import "github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+import (
+	"gorm.io/gorm"
+
+	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+)
 
 type pgsqlClient interface {
+	DB() *gorm.DB
 	Create(result interface{}) error
 	Updates(filter, result interface{}) error
 	Count(filter interface{}) (int, error)
@@ -1,35 +1,62 @@
 package repositoryimpl
 
 import (
+	"errors"
+
+	"gorm.io/gorm"
+
 	"github.com/opensourceways/xihe-server/cloud/domain"
 	"github.com/opensourceways/xihe-server/cloud/domain/repository"
 	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
 	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
 	"github.com/sirupsen/logrus"
 )
 
 func NewPodRepo(cfg *Config) repository.Pod {
 	return &podRepoImpl{
-		cli: pgsql.NewDBTable(cfg.Table.Pod),
+		table: cfg.Table.Pod,
+		cli:   pgsql.NewDBTable(cfg.Table.Pod),
 	}
 }
 
 type podRepoImpl struct {
-	cli pgsqlClient
+	table string
+	cli   pgsqlClient
 }
 
-func (impl *podRepoImpl) GetRunningPod(cid string) (
+const podStatusStarting = "starting"
+
+// holdingStatus is the status of pods which hold the resource of cloud.
+var holdingStatus = []string{podStatusStarting, "creating", "running"}
+
+func (impl *podRepoImpl) GetHoldingPods(cid string) (
 	pods repository.PodInfoList, err error,
 ) {
 	filter := map[string]interface{}{
 		fieldCloudId: cid,
-		fieldStatus:  "running",
+		fieldStatus:  holdingStatus,
 	}
 
 	return impl.getFilterPods(filter)
 }
 
+func (impl *podRepoImpl) GetExpiredPods(t int64) (
+	pods repository.PodInfoList, err error,
+) {
+	var tpods []TPod
+
+	err = impl.cli.DB().
+		Where("status IN ? AND expiry > ? AND expiry <= ?", holdingStatus, 0, t).
+		Find(&tpods).Error
+	if err != nil {
+		return
+	}
+
+	return toPodInfoList(tpods)
+}
+
 func (impl *podRepoImpl) getFilterPods(filter interface{}) (
 	pods repository.PodInfoList, err error,
 ) {
@@ -39,6 +66,10 @@ func (impl *podRepoImpl) getFilterPods(filter interface{}) (
 		return
 	}
 
+	return toPodInfoList(tpods)
+}
+
+func toPodInfoList(tpods []TPod) (pods repository.PodInfoList, err error) {
 	podinfos := make([]domain.PodInfo, len(tpods))
 	for i := range tpods {
 		if err = tpods[i].toPodInfo(&podinfos[i]); err != nil {
@@ -110,11 +141,38 @@ func (impl *podRepoImpl) GetUserCloudIdLastPod(
 	return impl.getOrderOnePod(filter, order)
 }
 
-func (impl *podRepoImpl) AddStartingPod(p *domain.PodInfo) (pid string, err error) {
+func (impl *podRepoImpl) AddStartingPodIfIdle(p *domain.PodInfo, limit int) (
+	pid string, err error,
+) {
 	pod := new(TPod)
 	pod.toTPod(p)
 
-	err = impl.cli.Create(pod)
+	err = impl.cli.DB().Transaction(func(tx *gorm.DB) error {
+		// the lock is released when the transaction ends.
+		err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", impl.table+p.CloudId).Error
+		if err != nil {
+			return err
+		}
+
+		// the same as domain.PodInfo.IsOccupying
+		var n int64
+		err = tx.Table(impl.table).
+			Where(
+				fieldCloudId+" = ? AND ("+fieldStatus+" = ? OR ("+fieldStatus+" IN ? AND "+fieldExpiry+" >= ?))",
+				p.CloudId, podStatusStarting, holdingStatus, utils.Now(),
+			).
+			Count(&n).Error
+		if err != nil {
+			return err
+		}
+
+		if int(n) >= limit {
+			return repository.NewErrorCloudBusy(errors.New("no idle resource"))
+		}
+
+		return tx.Table(impl.table).Create(pod).Error
+	})
+
 	pid = pod.Id
 
 	return
@@ -14,3 +14,39 @@ type TPod struct {
 func (TPod) TableName() string {
 	return "pod"
 }
+
+type TCreditBalance struct {
+	Owner   string `gorm:"column:owner;primaryKey"`
+	Balance int64  `gorm:"column:balance;not null"`
+}
+
+func (TCreditBalance) TableName() string {
+	return "credit_balance"
+}
+
+type TCreditTransaction struct {
+	Id        string `gorm:"type:uuid;default:gen_random_uuid();primaryKey"`
+	Owner     string `gorm:"column:owner;not null"`
+	Type      string `gorm:"column:type;not null"`
+	Amount    int64  `gorm:"column:amount;not null"`
+	Balance   int64  `gorm:"column:balance;not null"`
+	PodId     string `gorm:"column:pod_id"`
+	CloudId   string `gorm:"column:cloud_id"`
+	Remark    string `gorm:"column:remark"`
+	CreatedAt int64  `gorm:"column:created_at;not null"`
+}
+
+func (TCreditTransaction) TableName() string {
+	return "credit_transaction"
+}
+
+type TWaiting struct {
+	Id        int64  `gorm:"primaryKey;autoIncrement"`
+	CloudId   string `gorm:"column:cloud_id;not null;uniqueIndex:idx_waitlist_cloud_owner"`
+	Owner     string `gorm:"column:owner;not null;uniqueIndex:idx_waitlist_cloud_owner"`
+	CreatedAt int64  `gorm:"column:created_at;not null"`
+}
+
+func (TWaiting) TableName() string {
+	return "cloud_waitlist"
+}
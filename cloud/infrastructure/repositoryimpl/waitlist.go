@@ -0,0 +1,90 @@
+package repositoryimpl
+
+import (
+	"gorm.io/gorm/clause"
+
+	"github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/repository"
+	commonrepo "github.com/opensourceways/xihe-server/common/domain/repository"
+	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func NewWaitlistRepo(cfg *Config) repository.Waitlist {
+	return &waitlistRepoImpl{
+		table: cfg.Table.Waitlist,
+		cli:   pgsql.NewDBTable(cfg.Table.Waitlist),
+	}
+}
+
+type waitlistRepoImpl struct {
+	table string
+	cli   pgsqlClient
+}
+
+func (impl *waitlistRepoImpl) Add(w *domain.Waiting) error {
+	do := new(TWaiting)
+	do.toTWaiting(w)
+
+	return impl.cli.DB().Table(impl.table).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(do).Error
+}
+
+func (impl *waitlistRepoImpl) Remove(cid string, user types.Account) error {
+	return impl.cli.DB().Table(impl.table).
+		Where(impl.filter(cid, user)).
+		Delete(&TWaiting{}).Error
+}
+
+func (impl *waitlistRepoImpl) Count(cid string) (int, error) {
+	filter := map[string]interface{}{
+		fieldCloudId: cid,
+	}
+
+	return impl.cli.Count(filter)
+}
+
+func (impl *waitlistRepoImpl) GetHead(cid string) (w domain.Waiting, err error) {
+	filter := map[string]interface{}{
+		fieldCloudId: cid,
+	}
+
+	var v TWaiting
+	if err = impl.cli.GetOrderOneRecord(filter, "id ASC", &v); err != nil {
+		if impl.cli.IsRowNotFound(err) {
+			err = commonrepo.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toWaiting(&w)
+
+	return
+}
+
+func (impl *waitlistRepoImpl) GetPosition(cid string, user types.Account) (int, error) {
+	var v TWaiting
+	if err := impl.cli.GetRecord(impl.filter(cid, user), &v); err != nil {
+		if impl.cli.IsRowNotFound(err) {
+			err = commonrepo.NewErrorResourceNotExists(err)
+		}
+
+		return 0, err
+	}
+
+	var n int64
+	err := impl.cli.DB().Table(impl.table).
+		Where(fieldCloudId+" = ? AND id <= ?", cid, v.Id).
+		Count(&n).Error
+
+	return int(n), err
+}
+
+func (impl *waitlistRepoImpl) filter(cid string, user types.Account) map[string]interface{} {
+	return map[string]interface{}{
+		fieldCloudId: cid,
+		fieldOwner:   user.Account(),
+	}
+}
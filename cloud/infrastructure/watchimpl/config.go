@@ -0,0 +1,12 @@
+package watchimpl
+
+type Config struct {
+	// Interval is the seconds between two scans of the expired pods and the waitlist.
+	Interval int64 `json:"interval"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 60
+	}
+}
@@ -0,0 +1,9 @@
+package watchimpl
+
+import (
+	"github.com/opensourceways/xihe-server/common/infrastructure/ticker"
+)
+
+func NewWatcher(cfg *Config, handle func(int64) error) *ticker.Ticker {
+	return ticker.NewTicker("watch cloud pods", cfg.Interval, handle)
+}
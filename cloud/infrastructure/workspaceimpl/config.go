@@ -0,0 +1,15 @@
+package workspaceimpl
+
+type Config struct {
+	// RootDir is the directory in which the workspace of each user is created.
+	RootDir string `json:"root_dir" required:"true"`
+
+	// Quota is the max bytes of workspace for each user.
+	Quota int64 `json:"quota"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.Quota <= 0 {
+		cfg.Quota = 1 << 30
+	}
+}
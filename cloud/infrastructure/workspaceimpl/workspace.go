@@ -0,0 +1,126 @@
+package workspaceimpl
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opensourceways/xihe-server/cloud/domain/workspace"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// NewWorkspace returns the workspace on the local file system which
+// should be shared with the container manager.
+func NewWorkspace(cfg *Config) workspace.Workspace {
+	return &workspaceImpl{
+		root:  cfg.RootDir,
+		quota: cfg.Quota,
+	}
+}
+
+type workspaceImpl struct {
+	root  string
+	quota int64
+}
+
+func (impl *workspaceImpl) dir(user types.Account) string {
+	return filepath.Join(impl.root, user.Account())
+}
+
+func (impl *workspaceImpl) Volume(user types.Account) (v workspace.Volume, err error) {
+	dir := impl.dir(user)
+
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	v.Path = dir
+	v.Quota = impl.quota
+
+	return
+}
+
+func (impl *workspaceImpl) Usage(user types.Account) (int64, error) {
+	var n int64
+
+	err := impl.walk(user, false, func(path string, info fs.FileInfo) {
+		n += info.Size()
+	})
+
+	return n, err
+}
+
+func (impl *workspaceImpl) IsExceedQuota(user types.Account) (bool, error) {
+	n, err := impl.Usage(user)
+
+	return n > impl.quota, err
+}
+
+func (impl *workspaceImpl) ListFiles(user types.Account) ([]workspace.File, error) {
+	var r []workspace.File
+
+	err := impl.walk(user, true, func(path string, info fs.FileInfo) {
+		r = append(r, workspace.File{
+			Path: path,
+			Size: info.Size(),
+		})
+	})
+
+	return r, err
+}
+
+func (impl *workspaceImpl) ReadFile(user types.Account, path string) ([]byte, error) {
+	p := filepath.Join(impl.dir(user), path)
+
+	if rel, err := filepath.Rel(impl.dir(user), p); err != nil || strings.HasPrefix(rel, "..") {
+		return nil, errors.New("invalid path")
+	}
+
+	return os.ReadFile(p)
+}
+
+// walk calls the handle with the relative path of each regular file in the workspace.
+func (impl *workspaceImpl) walk(
+	user types.Account, skipHidden bool, handle func(string, fs.FileInfo),
+) error {
+	dir := impl.dir(user)
+
+	err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == dir {
+			return nil
+		}
+
+		if skipHidden && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		handle(rel, info)
+
+		return nil
+	})
+
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,218 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/collaborator/domain"
+	"github.com/opensourceways/xihe-server/collaborator/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	typerepo "github.com/opensourceways/xihe-server/domain/repository"
+	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type CollaboratorService interface {
+	Add(*CollaboratorCmd) (string, error)
+	Update(*CollaboratorCmd) (string, error)
+	Remove(*CollaboratorRemoveCmd) (string, error)
+	List(*types.ResourceObject) ([]CollaboratorDTO, error)
+
+	CanRead(obj *types.ResourceObject, user types.Account) (bool, error)
+	CanWrite(obj *types.ResourceObject, user types.Account) (bool, error)
+}
+
+var _ CollaboratorService = (*collaboratorService)(nil)
+
+func NewCollaboratorService(
+	repo repository.ResourceCollaborators,
+	user userrepo.User,
+	member platform.RepoMember,
+) *collaboratorService {
+	return &collaboratorService{
+		repo:   repo,
+		user:   user,
+		member: member,
+	}
+}
+
+type collaboratorService struct {
+	repo   repository.ResourceCollaborators
+	user   userrepo.User
+	member platform.RepoMember
+}
+
+func (s *collaboratorService) Add(cmd *CollaboratorCmd) (code string, err error) {
+	if cmd.Collaborator.Account() == cmd.Resource.Owner.Account() {
+		code = errorCollaboratorIsOwner
+		err = errors.New("the owner can't be a collaborator")
+
+		return
+	}
+
+	uid, code, err := s.platformUserId(cmd.Collaborator)
+	if err != nil {
+		return
+	}
+
+	r, err := s.get(&cmd.Resource)
+	if err != nil {
+		return
+	}
+
+	c := domain.Collaborator{
+		Account:    cmd.Collaborator,
+		Permission: cmd.Permission,
+		AddedAt:    utils.Now(),
+	}
+
+	if err = r.Add(&c); err != nil {
+		if r.IsCollaborator(cmd.Collaborator) {
+			code = errorCollaboratorExists
+		} else {
+			code = errorExceedMaxCollaborator
+		}
+
+		return
+	}
+
+	err = s.member.AddMember(&platform.RepoMemberOption{
+		RepoId:     cmd.RepoId,
+		UserId:     uid,
+		Permission: toRepoPermission(cmd.Permission),
+	})
+	if err != nil {
+		return
+	}
+
+	err = s.repo.Save(&r)
+
+	return
+}
+
+func (s *collaboratorService) Update(cmd *CollaboratorCmd) (code string, err error) {
+	r, err := s.get(&cmd.Resource)
+	if err != nil {
+		return
+	}
+
+	if err = r.Update(cmd.Collaborator, cmd.Permission); err != nil {
+		code = errorCollaboratorNotExists
+
+		return
+	}
+
+	uid, code, err := s.platformUserId(cmd.Collaborator)
+	if err != nil {
+		return
+	}
+
+	err = s.member.UpdateMember(&platform.RepoMemberOption{
+		RepoId:     cmd.RepoId,
+		UserId:     uid,
+		Permission: toRepoPermission(cmd.Permission),
+	})
+	if err != nil {
+		return
+	}
+
+	err = s.repo.Save(&r)
+
+	return
+}
+
+func (s *collaboratorService) Remove(cmd *CollaboratorRemoveCmd) (code string, err error) {
+	r, err := s.get(&cmd.Resource)
+	if err != nil {
+		return
+	}
+
+	if err = r.Remove(cmd.Collaborator); err != nil {
+		code = errorCollaboratorNotExists
+
+		return
+	}
+
+	uid, code, err := s.platformUserId(cmd.Collaborator)
+	if err != nil {
+		return
+	}
+
+	if err = s.member.RemoveMember(cmd.RepoId, uid); err != nil {
+		return
+	}
+
+	err = s.repo.Save(&r)
+
+	return
+}
+
+func (s *collaboratorService) List(obj *types.ResourceObject) (dtos []CollaboratorDTO, err error) {
+	r, err := s.get(obj)
+	if err != nil || len(r.Collaborators) == 0 {
+		return
+	}
+
+	dtos = make([]CollaboratorDTO, len(r.Collaborators))
+	for i := range r.Collaborators {
+		dtos[i] = toCollaboratorDTO(&r.Collaborators[i])
+	}
+
+	return
+}
+
+func (s *collaboratorService) CanRead(obj *types.ResourceObject, user types.Account) (bool, error) {
+	r, err := s.get(obj)
+	if err != nil {
+		return false, err
+	}
+
+	return r.IsCollaborator(user), nil
+}
+
+func (s *collaboratorService) CanWrite(obj *types.ResourceObject, user types.Account) (bool, error) {
+	r, err := s.get(obj)
+	if err != nil {
+		return false, err
+	}
+
+	p, ok := r.PermissionOf(user)
+
+	return ok && p.CanWrite(), nil
+}
+
+// get returns an empty one if there is no collaborator of the resource.
+func (s *collaboratorService) get(obj *types.ResourceObject) (
+	r domain.ResourceCollaborators, err error,
+) {
+	if r, err = s.repo.Get(obj); err != nil && typerepo.IsErrorResourceNotExists(err) {
+		r = domain.ResourceCollaborators{Resource: *obj}
+		err = nil
+	}
+
+	return
+}
+
+func (s *collaboratorService) platformUserId(u types.Account) (
+	id string, code string, err error,
+) {
+	v, err := s.user.GetByAccount(u)
+	if err != nil {
+		return
+	}
+
+	if id = v.PlatformUser.Id; id == "" {
+		code = errorNoPlatformUser
+		err = errors.New("collaborator has no platform account")
+	}
+
+	return
+}
+
+func toRepoPermission(p domain.Permission) string {
+	if p.CanWrite() {
+		return platform.RepoPermissionWrite
+	}
+
+	return platform.RepoPermissionRead
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/collaborator/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type CollaboratorRemoveCmd struct {
+	Resource     types.ResourceObject
+	RepoId       string
+	Collaborator types.Account
+}
+
+type CollaboratorCmd struct {
+	CollaboratorRemoveCmd
+
+	Permission domain.Permission
+}
+
+func (cmd *CollaboratorCmd) Validate() error {
+	b := cmd.Resource.Owner != nil &&
+		cmd.Resource.Type != nil &&
+		cmd.Resource.Id != "" &&
+		cmd.RepoId != "" &&
+		cmd.Collaborator != nil &&
+		cmd.Permission != nil
+
+	if !b {
+		return errors.New("invalid cmd of collaborator")
+	}
+
+	return nil
+}
+
+type CollaboratorDTO struct {
+	Account    string `json:"account"`
+	Permission string `json:"permission"`
+	AddedAt    string `json:"added_at"`
+}
+
+func toCollaboratorDTO(c *domain.Collaborator) CollaboratorDTO {
+	return CollaboratorDTO{
+		Account:    c.Account.Account(),
+		Permission: c.Permission.Permission(),
+		AddedAt:    utils.ToDate(c.AddedAt),
+	}
+}
@@ -0,0 +1,9 @@
+package app
+
+const (
+	errorCollaboratorExists    = "collaborator_exists"
+	errorCollaboratorNotExists = "collaborator_not_exists"
+	errorExceedMaxCollaborator = "collaborator_exceed_max_num"
+	errorNoPlatformUser        = "collaborator_no_platform_user"
+	errorCollaboratorIsOwner   = "collaborator_is_owner"
+)
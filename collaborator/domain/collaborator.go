@@ -0,0 +1,82 @@
+package domain
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// ResourceCollaborators is the collaborators of a private project,
+// model or dataset who are not the owner of it.
+type ResourceCollaborators struct {
+	Id string
+
+	Resource      types.ResourceObject
+	Collaborators []Collaborator
+
+	Version int
+}
+
+type Collaborator struct {
+	Account    types.Account
+	Permission Permission
+	AddedAt    int64
+}
+
+func (r *ResourceCollaborators) index(u types.Account) int {
+	for i := range r.Collaborators {
+		if r.Collaborators[i].Account.Account() == u.Account() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (r *ResourceCollaborators) IsCollaborator(u types.Account) bool {
+	return r.index(u) >= 0
+}
+
+func (r *ResourceCollaborators) PermissionOf(u types.Account) (Permission, bool) {
+	if i := r.index(u); i >= 0 {
+		return r.Collaborators[i].Permission, true
+	}
+
+	return nil, false
+}
+
+func (r *ResourceCollaborators) Add(c *Collaborator) error {
+	if r.IsCollaborator(c.Account) {
+		return errors.New("already a collaborator")
+	}
+
+	if len(r.Collaborators) >= types.DomainConfig.MaxCollaboratorNum {
+		return errors.New("exceed max collaborator num")
+	}
+
+	r.Collaborators = append(r.Collaborators, *c)
+
+	return nil
+}
+
+func (r *ResourceCollaborators) Update(u types.Account, p Permission) error {
+	i := r.index(u)
+	if i < 0 {
+		return errors.New("not a collaborator")
+	}
+
+	r.Collaborators[i].Permission = p
+
+	return nil
+}
+
+func (r *ResourceCollaborators) Remove(u types.Account) error {
+	i := r.index(u)
+	if i < 0 {
+		return errors.New("not a collaborator")
+	}
+
+	r.Collaborators = append(r.Collaborators[:i], r.Collaborators[i+1:]...)
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "errors"
+
+const (
+	permissionRead  = "read"
+	permissionWrite = "write"
+)
+
+// Permission
+type Permission interface {
+	Permission() string
+	CanWrite() bool
+}
+
+func NewPermission(v string) (Permission, error) {
+	if v != permissionRead && v != permissionWrite {
+		return nil, errors.New("invalid permission")
+	}
+
+	return permission(v), nil
+}
+
+type permission string
+
+func (r permission) Permission() string {
+	return string(r)
+}
+
+func (r permission) CanWrite() bool {
+	return string(r) == permissionWrite
+}
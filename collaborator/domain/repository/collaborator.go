@@ -0,0 +1,11 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/collaborator/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+type ResourceCollaborators interface {
+	Save(*domain.ResourceCollaborators) error
+	Get(*types.ResourceObject) (domain.ResourceCollaborators, error)
+}
@@ -0,0 +1,108 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/collaborator/domain"
+	"github.com/opensourceways/xihe-server/collaborator/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func NewResourceCollaboratorsRepo(m mongodbClient) repository.ResourceCollaborators {
+	return &collaboratorRepoImpl{m}
+}
+
+type collaboratorRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *collaboratorRepoImpl) docFilter(obj *types.ResourceObject) bson.M {
+	return bson.M{
+		fieldOwner:      obj.Owner.Account(),
+		fieldType:       obj.Type.ResourceType(),
+		fieldResourceId: obj.Id,
+	}
+}
+
+func (impl *collaboratorRepoImpl) Save(r *domain.ResourceCollaborators) error {
+	if r.Id != "" {
+		return impl.update(r)
+	}
+
+	return impl.insert(r)
+}
+
+func (impl *collaboratorRepoImpl) insert(r *domain.ResourceCollaborators) error {
+	var obj DResourceCollaborators
+	toResourceCollaboratorsDoc(r, &obj)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return err
+	}
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(
+			ctx, impl.docFilter(&r.Resource), doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (impl *collaboratorRepoImpl) update(r *domain.ResourceCollaborators) error {
+	var obj DResourceCollaborators
+	toResourceCollaboratorsDoc(r, &obj)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return err
+	}
+
+	filter, err := impl.cli.ObjectIdFilter(r.Id)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(ctx, filter, doc, mongoCmdSet, r.Version)
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (impl *collaboratorRepoImpl) Get(obj *types.ResourceObject) (
+	r domain.ResourceCollaborators, err error,
+) {
+	var v DResourceCollaborators
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, impl.docFilter(obj), nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toResourceCollaborators(&r)
+
+	return
+}
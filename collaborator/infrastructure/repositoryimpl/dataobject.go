@@ -0,0 +1,63 @@
+package repositoryimpl
+
+import (
+	"github.com/opensourceways/xihe-server/collaborator/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+func toResourceCollaboratorsDoc(r *domain.ResourceCollaborators, doc *DResourceCollaborators) {
+	items := make([]dCollaborator, len(r.Collaborators))
+	for i := range r.Collaborators {
+		c := &r.Collaborators[i]
+
+		items[i] = dCollaborator{
+			Account:    c.Account.Account(),
+			Permission: c.Permission.Permission(),
+			AddedAt:    c.AddedAt,
+		}
+	}
+
+	*doc = DResourceCollaborators{
+		Owner:         r.Resource.Owner.Account(),
+		Type:          r.Resource.Type.ResourceType(),
+		ResourceId:    r.Resource.Id,
+		Collaborators: items,
+	}
+}
+
+func (doc *DResourceCollaborators) toResourceCollaborators(r *domain.ResourceCollaborators) (err error) {
+	if r.Resource.Owner, err = types.NewAccount(doc.Owner); err != nil {
+		return
+	}
+
+	if r.Resource.Type, err = types.NewResourceType(doc.Type); err != nil {
+		return
+	}
+
+	r.Collaborators = make([]domain.Collaborator, len(doc.Collaborators))
+	for i := range doc.Collaborators {
+		if err = doc.Collaborators[i].toCollaborator(&r.Collaborators[i]); err != nil {
+			return
+		}
+	}
+
+	r.Id = doc.Id.Hex()
+	r.Resource.Id = doc.ResourceId
+	r.Version = doc.Version
+
+	return
+}
+
+func (doc *dCollaborator) toCollaborator(c *domain.Collaborator) (err error) {
+	if c.Account, err = types.NewAccount(doc.Account); err != nil {
+		return
+	}
+
+	if c.Permission, err = domain.NewPermission(doc.Permission); err != nil {
+		return
+	}
+
+	c.AddedAt = doc.AddedAt
+
+	return
+}
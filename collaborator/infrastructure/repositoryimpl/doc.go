@@ -0,0 +1,29 @@
+package repositoryimpl
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	fieldType       = "type"
+	fieldOwner      = "owner"
+	fieldResourceId = "rid"
+	fieldVersion    = "version"
+)
+
+type DResourceCollaborators struct {
+	Id primitive.ObjectID `bson:"_id"           json:"-"`
+
+	Owner         string          `bson:"owner"         json:"owner"`
+	Type          string          `bson:"type"          json:"type"`
+	ResourceId    string          `bson:"rid"           json:"rid"`
+	Collaborators []dCollaborator `bson:"collaborators" json:"collaborators"`
+
+	// Version will be increased by 1 automatically.
+	// So, don't marshal it to avoid setting it occasionally.
+	Version int `bson:"version"    json:"-"`
+}
+
+type dCollaborator struct {
+	Account    string `bson:"account"    json:"account"`
+	Permission string `bson:"permission" json:"permission"`
+	AddedAt    int64  `bson:"added_at"   json:"added_at"`
+}
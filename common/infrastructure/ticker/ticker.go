@@ -0,0 +1,63 @@
+package ticker
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Ticker triggers the handle at each interval until it is stopped.
+type Ticker struct {
+	name   string
+	handle func(int64) error
+	ticker *time.Ticker
+	stop   chan struct{}
+	wg     sync.WaitGroup
+}
+
+// NewTicker returns the ticker which triggers the handle every interval seconds,
+// and the name is used to log the errors of handle.
+func NewTicker(name string, interval int64, handle func(int64) error) *Ticker {
+	return &Ticker{
+		name:   name,
+		handle: handle,
+		ticker: time.NewTicker(time.Duration(interval) * time.Second),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Start runs the ticker in background.
+func (t *Ticker) Start() {
+	t.wg.Add(1)
+
+	go t.run()
+}
+
+// Stop stops the ticker and waits for the running handle to exit.
+func (t *Ticker) Stop() {
+	t.ticker.Stop()
+	close(t.stop)
+
+	t.wg.Wait()
+}
+
+func (t *Ticker) run() {
+	defer t.wg.Done()
+
+	logrus.Debugf("start to %s", t.name)
+
+	for {
+		select {
+		case now := <-t.ticker.C:
+			if err := t.handle(now.Unix()); err != nil {
+				logrus.Errorf("%s failed, err:%s", t.name, err.Error())
+			}
+
+		case <-t.stop:
+			logrus.Infof("stop to %s", t.name)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,417 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+	"github.com/opensourceways/xihe-server/competition/domain/uploader"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+// CompetitionEditCmd is the information of competition which can be edited by organizer.
+type CompetitionEditCmd struct {
+	Name       domain.CompetitionName
+	Desc       domain.CompetitionDesc
+	Host       domain.CompetitionHost
+	Bonus      domain.CompetitionBonus
+	Duration   domain.CompetitionDuration
+	Poster     domain.URL
+	Tags       []domain.CompetitionTag
+	Doc        domain.URL
+	Forum      domain.Forum
+	Winners    domain.Winners
+	DatasetDoc domain.URL
+	DatasetURL domain.URL
+	Type       domain.CompetitionType
+	Order      domain.CompetitionScoreOrder
+	Metric     domain.ScoreMetric
+
+	PreliminaryRule domain.SubmissionRule
+	FinalRule       domain.SubmissionRule
+	TeamRule        domain.TeamRule
+	SelectionRule   domain.SelectionRule
+
+	// Organizers manage the competition after it is created.
+	Organizers []types.Account
+}
+
+func (cmd *CompetitionEditCmd) Validate() error {
+	b := cmd.Name != nil &&
+		cmd.Desc != nil &&
+		cmd.Host != nil &&
+		cmd.Bonus != nil &&
+		cmd.Duration != nil &&
+		cmd.Poster != nil &&
+		cmd.Doc != nil &&
+		cmd.Forum != nil &&
+		cmd.Winners != nil &&
+		cmd.DatasetDoc != nil &&
+		cmd.DatasetURL != nil &&
+		cmd.Type != nil &&
+		cmd.Order != nil &&
+		cmd.Metric != nil &&
+		len(cmd.Organizers) > 0
+
+	if !b {
+		return errors.New("invalid cmd")
+	}
+
+	if cmd.Metric.IsBuiltin() && cmd.Metric.SmallerIsBetter() != cmd.Order.SmallerIsBetter() {
+		return errors.New("the order does not match the metric")
+	}
+
+	return nil
+}
+
+func (cmd *CompetitionEditCmd) applyTo(c *domain.Competition) {
+	c.Name = cmd.Name
+	c.Desc = cmd.Desc
+	c.Host = cmd.Host
+	c.Bonus = cmd.Bonus
+	c.Duration = cmd.Duration
+	c.Poster = cmd.Poster
+	c.Tags = cmd.Tags
+	c.Doc = cmd.Doc
+	c.Forum = cmd.Forum
+	c.Winners = cmd.Winners
+	c.DatasetDoc = cmd.DatasetDoc
+	c.DatasetURL = cmd.DatasetURL
+	c.Type = cmd.Type
+	c.PreliminaryRule = cmd.PreliminaryRule
+	c.FinalRule = cmd.FinalRule
+	c.TeamRule = cmd.TeamRule
+	c.SelectionRule = cmd.SelectionRule
+	c.Organizers = cmd.Organizers
+}
+
+type CompetitionCreateCmd struct {
+	Operator types.Account
+
+	CompetitionEditCmd
+}
+
+func (cmd *CompetitionCreateCmd) toCompetition() (c domain.Competition) {
+	cmd.applyTo(&c)
+
+	c.Order = cmd.Order
+	c.Metric = cmd.Metric
+	c.Status = domain.CompetitionStatusPreparing
+	c.Phase = domain.CompetitionPhasePreliminary
+
+	return
+}
+
+type CompetitionUpdateCmd struct {
+	Operator      types.Account
+	CompetitionId string
+	Phase         domain.CompetitionPhase
+
+	CompetitionEditCmd
+}
+
+func (cmd *CompetitionUpdateCmd) Validate() error {
+	if cmd.Phase == nil {
+		return errors.New("invalid cmd")
+	}
+
+	return cmd.CompetitionEditCmd.Validate()
+}
+
+type CompetitionStatusChangeCmd struct {
+	Operator      types.Account
+	CompetitionId string
+	Status        domain.CompetitionStatus
+}
+
+type CompetitionFinalistsPromoteCmd struct {
+	Operator      types.Account
+	CompetitionId string
+	Competitors   []types.Account
+}
+
+func (cmd *CompetitionFinalistsPromoteCmd) Validate() error {
+	if len(cmd.Competitors) == 0 {
+		return errors.New("no competitors")
+	}
+
+	return nil
+}
+
+type GroundTruthUploadCmd struct {
+	Operator      types.Account
+	CompetitionId string
+	Phase         domain.CompetitionPhase
+	Data          io.Reader
+}
+
+type CompetitionAuditLogDTO struct {
+	Operator  string `json:"operator"`
+	Action    string `json:"action"`
+	Detail    string `json:"detail"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CompetitionAdminService is used to manage the competitions. The competition is
+// created by the administrator, and then it can only be managed by its organizers.
+type CompetitionAdminService interface {
+	Create(*CompetitionCreateCmd) (string, string, error)
+	Update(*CompetitionUpdateCmd) (string, error)
+	ChangeStatus(*CompetitionStatusChangeCmd) (string, error)
+	PromoteFinalists(*CompetitionFinalistsPromoteCmd) (string, error)
+	UploadGroundTruth(*GroundTruthUploadCmd) (string, error)
+	ListAuditLogs(cid string, operator types.Account) ([]CompetitionAuditLogDTO, string, error)
+}
+
+func NewCompetitionAdminService(
+	repo repository.Competition,
+	playerRepo repository.Player,
+	auditRepo repository.AuditLog,
+	uploader uploader.SubmissionFileUploader,
+) CompetitionAdminService {
+	return competitionAdminService{
+		repo:       repo,
+		playerRepo: playerRepo,
+		auditRepo:  auditRepo,
+		uploader:   uploader,
+	}
+}
+
+type competitionAdminService struct {
+	repo       repository.Competition
+	playerRepo repository.Player
+	auditRepo  repository.AuditLog
+	uploader   uploader.SubmissionFileUploader
+}
+
+func (s competitionAdminService) Create(cmd *CompetitionCreateCmd) (
+	cid string, code string, err error,
+) {
+	c := cmd.toCompetition()
+
+	if cid, err = s.repo.AddCompetition(&c); err != nil {
+		if repoerr.IsErrorDuplicateCreating(err) {
+			code = errorCompetitionExists
+		}
+
+		return
+	}
+
+	s.audit(cid, cmd.Operator, domain.AuditActionCreate, s.auditDetail(&c))
+
+	return
+}
+
+func (s competitionAdminService) Update(cmd *CompetitionUpdateCmd) (
+	code string, err error,
+) {
+	c, code, err := s.findCompetition(cmd.CompetitionId, cmd.Operator)
+	if err != nil {
+		return
+	}
+
+	if c.IsOver() {
+		code = errorIsOver
+		err = errors.New("competition is over")
+
+		return
+	}
+
+	if err = c.ChangePhase(cmd.Phase); err != nil {
+		code = errorInvalidPhaseTransition
+
+		return
+	}
+
+	if err = c.ChangeScoring(cmd.Order, cmd.Metric); err != nil {
+		code = errorHasStarted
+
+		return
+	}
+
+	cmd.applyTo(&c)
+
+	if err = s.repo.SaveCompetition(&c); err != nil {
+		return
+	}
+
+	s.audit(c.Id, cmd.Operator, domain.AuditActionUpdate, s.auditDetail(&c))
+
+	return
+}
+
+func (s competitionAdminService) ChangeStatus(cmd *CompetitionStatusChangeCmd) (
+	code string, err error,
+) {
+	c, code, err := s.findCompetition(cmd.CompetitionId, cmd.Operator)
+	if err != nil {
+		return
+	}
+
+	from := c.Status.CompetitionStatus()
+
+	if err = c.ChangeStatus(cmd.Status); err != nil {
+		code = errorInvalidStatusTransition
+
+		return
+	}
+
+	if err = s.repo.SaveCompetition(&c); err != nil {
+		return
+	}
+
+	s.audit(
+		c.Id, cmd.Operator, domain.AuditActionChangeStatus,
+		fmt.Sprintf("%s -> %s", from, cmd.Status.CompetitionStatus()),
+	)
+
+	return
+}
+
+func (s competitionAdminService) PromoteFinalists(cmd *CompetitionFinalistsPromoteCmd) (
+	code string, err error,
+) {
+	c, code, err := s.findCompetition(cmd.CompetitionId, cmd.Operator)
+	if err != nil {
+		return
+	}
+
+	if c.IsOver() {
+		code = errorIsOver
+		err = errors.New("competition is over")
+
+		return
+	}
+
+	promoted := make([]string, 0, len(cmd.Competitors))
+
+	for _, a := range cmd.Competitors {
+		p, version, err1 := s.playerRepo.FindPlayer(c.Id, a)
+		if err1 != nil {
+			if repoerr.IsErrorResourceNotExists(err1) {
+				code = errorNotCompetitor
+			}
+
+			err = err1
+
+			break
+		}
+
+		if p.IsFinalist {
+			continue
+		}
+
+		p.IsFinalist = true
+
+		if err = s.playerRepo.SavePlayer(&p, version); err != nil {
+			break
+		}
+
+		promoted = append(promoted, p.Name())
+	}
+
+	// some players may have been promoted before the failure.
+	if len(promoted) > 0 {
+		s.audit(
+			c.Id, cmd.Operator, domain.AuditActionPromoteFinalists,
+			strings.Join(promoted, ","),
+		)
+	}
+
+	return
+}
+
+func (s competitionAdminService) UploadGroundTruth(cmd *GroundTruthUploadCmd) (
+	code string, err error,
+) {
+	c, code, err := s.findCompetition(cmd.CompetitionId, cmd.Operator)
+	if err != nil {
+		return
+	}
+
+	if err = s.uploader.Upload(cmd.Data, c.GroundTruthPath(cmd.Phase)); err != nil {
+		return
+	}
+
+	s.audit(
+		c.Id, cmd.Operator, domain.AuditActionUploadGroundTruth,
+		"phase: "+cmd.Phase.CompetitionPhase(),
+	)
+
+	return
+}
+
+func (s competitionAdminService) ListAuditLogs(cid string, operator types.Account) (
+	dtos []CompetitionAuditLogDTO, code string, err error,
+) {
+	if _, code, err = s.findCompetition(cid, operator); err != nil {
+		return
+	}
+
+	v, err := s.auditRepo.FindAuditLogs(cid)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]CompetitionAuditLogDTO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		dtos[i] = CompetitionAuditLogDTO{
+			Operator:  item.Operator.Account(),
+			Action:    item.Action,
+			Detail:    item.Detail,
+			CreatedAt: utils.ToDate(item.CreatedAt),
+		}
+	}
+
+	return
+}
+
+// findCompetition finds the competition which is managed by the operator.
+func (s competitionAdminService) findCompetition(cid string, operator types.Account) (
+	c domain.Competition, code string, err error,
+) {
+	if c, err = s.repo.FindCompetition(cid); err != nil {
+		return
+	}
+
+	if !c.IsOrganizer(operator) {
+		code = errorNotOrganizer
+		err = errors.New("not the organizer of competition")
+	}
+
+	return
+}
+
+func (s competitionAdminService) auditDetail(c *domain.Competition) string {
+	return fmt.Sprintf(
+		"name: %s, phase: %s, status: %s, metric: %s, winners: %s",
+		c.Name.CompetitionName(), c.Phase.CompetitionPhase(),
+		c.Status.CompetitionStatus(), c.Metric.ScoreMetric(), c.Winners.Winners(),
+	)
+}
+
+// audit just logs the failure, because the change has been done.
+func (s competitionAdminService) audit(cid string, operator types.Account, action, detail string) {
+	err := s.auditRepo.AddAuditLog(&domain.AuditLog{
+		CompetitionId: cid,
+		Operator:      operator,
+		Action:        action,
+		Detail:        detail,
+		CreatedAt:     utils.Now(),
+	})
+	if err != nil {
+		logrus.Errorf(
+			"add audit log of competition(%s) failed, action:%s, err:%s",
+			cid, action, err.Error(),
+		)
+	}
+}
@@ -21,6 +21,13 @@ type CompetitionService interface {
 	DeleteMember(cid string, cmd *CmdToDeleteTeamMember) error
 	DissolveTeam(cid string, leader types.Account) error
 
+	// invitation
+	Invite(cid string, cmd *CompetitionTeamInviteCmd) (string, string, error)
+	RequestToJoin(cid string, cmd *CompetitionTeamRequestCmd) (string, string, error)
+	AcceptInvitation(cid string, cmd *CompetitionInvitationCmd) (string, error)
+	DeclineInvitation(cid string, cmd *CompetitionInvitationCmd) (string, error)
+	ListInvitations(cid string, user types.Account) ([]CompetitionInvitationDTO, error)
+
 	// competition
 	Get(cid string, competitor types.Account) (UserCompetitionDTO, error)
 	List(*CompetitionListCMD) ([]CompetitionSummaryDTO, error)
@@ -29,6 +36,7 @@ type CompetitionService interface {
 	Submit(*CompetitionSubmitCMD) (CompetitionSubmissionDTO, string, error)
 	GetSubmissions(string, types.Account) (CompetitionSubmissionsDTO, error)
 	GetRankingList(string) (CompetitonRankingDTO, error)
+	SelectSubmission(*CompetitionSubmissionSelectCmd) (string, error)
 	AddRelatedProject(*CompetitionAddReleatedProjectCMD) (string, error)
 }
 
@@ -38,6 +46,7 @@ func NewCompetitionService(
 	repo repository.Competition,
 	workRepo repository.Work,
 	playerRepo repository.Player,
+	invitationRepo repository.Invitation,
 	producer message.CalcScoreMessageProducer,
 	uploader uploader.SubmissionFileUploader,
 ) *competitionService {
@@ -45,6 +54,7 @@ func NewCompetitionService(
 		repo:             repo,
 		workRepo:         workRepo,
 		playerRepo:       playerRepo,
+		invitationRepo:   invitationRepo,
 		producer:         producer,
 		submissionServie: domain.NewSubmissionService(uploader),
 	}
@@ -54,6 +64,7 @@ type competitionService struct {
 	repo             repository.Competition
 	workRepo         repository.Work
 	playerRepo       repository.Player
+	invitationRepo   repository.Invitation
 	producer         message.CalcScoreMessageProducer
 	submissionServie domain.SubmissionService
 }
@@ -59,6 +59,13 @@ func (cmd *CompetitionSubmitCMD) Validate() error {
 	return nil
 }
 
+type CompetitionSubmissionSelectCmd struct {
+	CompetitionId string
+	SubmissionId  string
+	User          types.Account
+	Selected      bool
+}
+
 type CompetitionAddReleatedProjectCMD struct {
 	Id      string
 	User    types.Account
@@ -92,6 +99,11 @@ type CompetitionDTO struct {
 	Winners    string `json:"winners"`
 	DatasetDoc string `json:"dataset_doc"`
 	DatasetURL string `json:"dataset_url"`
+	Metric     string `json:"metric"`
+
+	MaxTeamSize       int   `json:"max_team_size"`
+	TeamMergeDeadline int64 `json:"team_merge_deadline"`
+	MaxSelected       int   `json:"max_selected_submissions"`
 }
 
 type UserCompetitionDTO struct {
@@ -104,9 +116,13 @@ type UserCompetitionDTO struct {
 }
 
 // ranking
+// CompetitonRankingDTO
+// The private rankings are only available when the competition is over.
 type CompetitonRankingDTO struct {
-	Final       []RankingDTO `json:"final"`
-	Preliminary []RankingDTO `json:"preliminary"`
+	Final              []RankingDTO `json:"final"`
+	Preliminary        []RankingDTO `json:"preliminary"`
+	PrivateFinal       []RankingDTO `json:"private_final,omitempty"`
+	PrivatePreliminary []RankingDTO `json:"private_preliminary,omitempty"`
 }
 
 type RankingDTO struct {
@@ -131,6 +147,43 @@ type CompetitionTeamDTO struct {
 	Members []CompetitionTeamMemberDTO `json:"members"`
 }
 
+type CompetitionTeamInviteCmd struct {
+	Leader     types.Account
+	Competitor types.Account
+}
+
+type CompetitionTeamRequestCmd = CompetitionTeamJoinCmd
+
+type CompetitionInvitationCmd struct {
+	User         types.Account
+	InvitationId string
+}
+
+// CompetitionInvitationDTO
+// Type is "invite" if the team invites the competitor, or "request" if the
+// competitor requests to join the team.
+type CompetitionInvitationDTO struct {
+	Id         string `json:"id"`
+	Type       string `json:"type"`
+	TeamName   string `json:"team_name"`
+	TeamLeader string `json:"team_leader"`
+	Competitor string `json:"competitor"`
+	CreatedAt  string `json:"created_at"`
+}
+
+func (s competitionService) toCompetitionInvitationDTO(
+	v *domain.Invitation, dto *CompetitionInvitationDTO,
+) {
+	*dto = CompetitionInvitationDTO{
+		Id:         v.Id,
+		Type:       v.Type,
+		TeamName:   v.TeamName,
+		TeamLeader: v.TeamLeader.Account(),
+		Competitor: v.Competitor.Account(),
+		CreatedAt:  utils.ToDate(v.CreatedAt),
+	}
+}
+
 type CompetitionTeamMemberDTO struct {
 	Name    string `json:"name"`
 	Role    string `json:"role"`
@@ -142,23 +195,52 @@ type CompetitionTeamMemberDTO struct {
 type CompetitionSubmissionsDTO struct {
 	RelatedProject string                     `json:"project"`
 	Details        []CompetitionSubmissionDTO `json:"details"`
+	Quota          SubmissionQuotaDTO         `json:"quota"`
+}
+
+// SubmissionQuotaDTO
+// The remaining counts are -1 if unlimited, and the unix times are 0 if not restricted.
+type SubmissionQuotaDTO struct {
+	Today   int   `json:"today"`
+	Total   int   `json:"total"`
+	OpenAt  int64 `json:"open_at"`
+	CloseAt int64 `json:"close_at"`
 }
 
 type CompetitionSubmissionDTO struct {
-	SubmitAt string  `json:"submit_at"`
-	FileName string  `json:"file_name"`
-	Status   string  `json:"status"`
-	Score    float32 `json:"score"`
+	Id           string   `json:"id"`
+	SubmitAt     string   `json:"submit_at"`
+	FileName     string   `json:"file_name"`
+	Status       string   `json:"status"`
+	Score        float32  `json:"score"`
+	PrivateScore *float32 `json:"private_score,omitempty"`
+	Selected     bool     `json:"selected"`
 }
 
 func (s competitionService) toCompetitionSubmissionDTO(
 	v *domain.Submission, dto *CompetitionSubmissionDTO,
 ) {
 	*dto = CompetitionSubmissionDTO{
+		Id:       v.Id,
 		SubmitAt: utils.ToDate(v.SubmitAt),
 		FileName: filepath.Base(v.OBSPath),
 		Status:   v.Status,
 		Score:    v.Score,
+		Selected: v.Selected,
+	}
+}
+
+func (s competitionService) toSubmissionQuotaDTO(
+	rule *domain.SubmissionRule, w *domain.Work,
+	phase domain.CompetitionPhase, dto *SubmissionQuotaDTO,
+) {
+	quota := rule.Quota(w, phase)
+
+	*dto = SubmissionQuotaDTO{
+		Today:   quota.Today,
+		Total:   quota.Total,
+		OpenAt:  rule.OpenAt,
+		CloseAt: rule.CloseAt,
 	}
 }
 
@@ -198,6 +280,10 @@ func (s competitionService) toCompetitionDTO(
 	dto.Winners = c.Winners.Winners()
 	dto.DatasetDoc = c.DatasetDoc.URL()
 	dto.DatasetURL = c.DatasetURL.URL()
+	dto.Metric = c.Metric.ScoreMetric()
+	dto.MaxTeamSize = c.TeamRule.MaxSize
+	dto.TeamMergeDeadline = c.TeamRule.MergeDeadline
+	dto.MaxSelected = c.SelectionRule.MaxSelected
 }
 
 type CmdToChangeCompetitionTeamName = CompetitionTeamCreateCmd
@@ -12,4 +12,21 @@ const (
 	errorDoesnotOwnProject   = "competition_doesnot_own_project"
 	errorDuplicateSubmission = "competition_duplicate_submission"
 	errorNoCorrespondingTeam = "competition_no_corresponding_team"
+
+	errorNotCompetitor           = "competition_not_competitor"
+	errorSubmissionClosed        = "competition_submission_closed"
+	errorSubmissionNotOpen       = "competition_submission_not_open"
+	errorSubmitExceedTotal       = "competition_submit_exceed_total"
+	errorCompetitionExists       = "competition_exists"
+	errorInvalidStatusTransition = "competition_invalid_status_transition"
+	errorInvalidPhaseTransition  = "competition_invalid_phase_transition"
+	errorNotOrganizer            = "competition_not_organizer"
+	errorHasStarted              = "competition_has_started"
+
+	errorTooManySelectedSubmissions = "competition_too_many_selected_submissions"
+
+	errorNotIndividual      = "competition_not_individual"
+	errorTeamMergeClosed    = "competition_team_merge_closed"
+	errorInvitationExists   = "competition_invitation_exists"
+	errorInvitationNotFound = "competition_invitation_not_found"
 )
@@ -0,0 +1,251 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func (s *competitionService) Invite(cid string, cmd *CompetitionTeamInviteCmd) (
+	id string, code string, err error,
+) {
+	c, code, err := s.getCompetitionToMerge(cid)
+	if err != nil {
+		return
+	}
+
+	team, _, err := s.playerRepo.FindPlayer(cid, cmd.Leader)
+	if err != nil {
+		return
+	}
+
+	if !team.IsTeamLeader() {
+		code = errorNoPermission
+		err = errors.New("only the team leader can invite")
+
+		return
+	}
+
+	if c.TeamRule.IsFull(&team) {
+		code = errorTeamMembersEnough
+		err = errors.New("the team is full")
+
+		return
+	}
+
+	p, _, err := s.playerRepo.FindPlayer(cid, cmd.Competitor)
+	if err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			code = errorNotCompetitor
+		}
+
+		return
+	}
+
+	if !p.IsIndividual() {
+		code = errorNotIndividual
+		err = errors.New("the competitor is not an individual")
+
+		return
+	}
+
+	return s.addInvitation(domain.NewTeamInvitation(&team, cmd.Competitor))
+}
+
+func (s *competitionService) RequestToJoin(cid string, cmd *CompetitionTeamRequestCmd) (
+	id string, code string, err error,
+) {
+	c, code, err := s.getCompetitionToMerge(cid)
+	if err != nil {
+		return
+	}
+
+	me, _, err := s.playerRepo.FindPlayer(cid, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !me.IsIndividual() {
+		code = errorNotIndividual
+		err = errors.New("you are not an individual competitor")
+
+		return
+	}
+
+	team, _, err := s.playerRepo.FindPlayer(cid, cmd.Leader)
+	if err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			code = errorNoCorrespondingTeam
+		}
+
+		return
+	}
+
+	if !team.IsATeam() {
+		code = errorNoCorrespondingTeam
+		err = errors.New("it is not a team")
+
+		return
+	}
+
+	if c.TeamRule.IsFull(&team) {
+		code = errorTeamMembersEnough
+		err = errors.New("the team is full")
+
+		return
+	}
+
+	return s.addInvitation(domain.NewTeamRequest(&team, cmd.User))
+}
+
+func (s *competitionService) addInvitation(inv domain.Invitation) (
+	id string, code string, err error,
+) {
+	if id, err = s.invitationRepo.AddInvitation(&inv); err != nil {
+		if repoerr.IsErrorDuplicateCreating(err) {
+			code = errorInvitationExists
+		}
+	}
+
+	return
+}
+
+func (s *competitionService) AcceptInvitation(cid string, cmd *CompetitionInvitationCmd) (
+	code string, err error,
+) {
+	inv, code, err := s.findInvitation(cid, cmd.InvitationId)
+	if err != nil {
+		return
+	}
+
+	c, code, err := s.getCompetitionToMerge(cid)
+	if err != nil {
+		return
+	}
+
+	me, mv, err := s.playerRepo.FindPlayer(cid, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !inv.CanBeAcceptedBy(&me) {
+		code = errorNoPermission
+		err = errors.New("no permission to accept the invitation")
+
+		return
+	}
+
+	var team, competitor domain.Player
+	var tv, cv int
+
+	if inv.IsRequest() {
+		team, tv = me, mv
+		competitor, cv, err = s.playerRepo.FindPlayer(cid, inv.Competitor)
+	} else {
+		competitor, cv = me, mv
+		// the leader may have been changed after the invitation was created.
+		team, tv, err = s.playerRepo.FindPlayerById(cid, inv.TeamId)
+	}
+
+	if err != nil {
+		// the team may have been dissolved after the invitation was created.
+		if repoerr.IsErrorResourceNotExists(err) {
+			code = errorNoCorrespondingTeam
+		}
+
+		return
+	}
+
+	if code, err = s.joinTeam(&competitor, cv, &team, tv, &c.TeamRule); err != nil {
+		return
+	}
+
+	// the competitor is not an individual any more, so all of its invitations are invalid.
+	if err1 := s.invitationRepo.DeleteInvitationsOfCompetitor(cid, inv.Competitor); err1 != nil {
+		logrus.Errorf(
+			"delete invitations of competitor(%s) in competition(%s) failed, err:%s",
+			inv.Competitor.Account(), cid, err1.Error(),
+		)
+	}
+
+	return
+}
+
+func (s *competitionService) DeclineInvitation(cid string, cmd *CompetitionInvitationCmd) (
+	code string, err error,
+) {
+	inv, code, err := s.findInvitation(cid, cmd.InvitationId)
+	if err != nil {
+		return
+	}
+
+	me, _, err := s.playerRepo.FindPlayer(cid, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !inv.CanBeDeclinedBy(&me) {
+		code = errorNoPermission
+		err = errors.New("no permission to decline the invitation")
+
+		return
+	}
+
+	err = s.invitationRepo.DeleteInvitation(inv.Id)
+
+	return
+}
+
+func (s *competitionService) findInvitation(cid, id string) (
+	inv domain.Invitation, code string, err error,
+) {
+	inv, err = s.invitationRepo.FindInvitation(id)
+	if err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			code = errorInvitationNotFound
+		}
+
+		return
+	}
+
+	if inv.CompetitionId != cid {
+		code = errorInvitationNotFound
+		err = errors.New("no such invitation")
+	}
+
+	return
+}
+
+// ListInvitations returns the invitations of the team if the user is a member
+// of a team, otherwise the ones of the user.
+func (s *competitionService) ListInvitations(cid string, user types.Account) (
+	[]CompetitionInvitationDTO, error,
+) {
+	p, _, err := s.playerRepo.FindPlayer(cid, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var v []domain.Invitation
+
+	if p.IsATeam() {
+		v, err = s.invitationRepo.FindInvitationsOfTeam(cid, p.Id)
+	} else {
+		v, err = s.invitationRepo.FindInvitationsOfCompetitor(cid, user)
+	}
+
+	if err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	dtos := make([]CompetitionInvitationDTO, len(v))
+	for i := range v {
+		s.toCompetitionInvitationDTO(&v[i], &dtos[i])
+	}
+
+	return dtos, nil
+}
@@ -68,6 +68,11 @@ func (s *competitionService) CreateTeam(cid string, cmd *CompetitionTeamCreateCm
 }
 
 func (s *competitionService) JoinTeam(cid string, cmd *CompetitionTeamJoinCmd) (code string, err error) {
+	c, code, err := s.getCompetitionToMerge(cid)
+	if err != nil {
+		return
+	}
+
 	me, pv, err := s.playerRepo.FindPlayer(cid, cmd.User)
 	if err != nil {
 		return
@@ -82,7 +87,38 @@ func (s *competitionService) JoinTeam(cid string, cmd *CompetitionTeamJoinCmd) (
 		return
 	}
 
-	if err = me.JoinTo(&team); err != nil {
+	return s.joinTeam(&me, pv, &team, version, &c.TeamRule)
+}
+
+// getCompetitionToMerge returns the competition if the teams can be merged now.
+func (s *competitionService) getCompetitionToMerge(cid string) (
+	c domain.Competition, code string, err error,
+) {
+	if c, err = s.repo.FindCompetition(cid); err != nil {
+		return
+	}
+
+	if c.IsOver() {
+		code = errorIsOver
+		err = errors.New("competition is over")
+
+		return
+	}
+
+	if c.TeamRule.IsMergeClosed(utils.Now()) {
+		code = errorTeamMergeClosed
+		err = errors.New("the deadline of merging teams has passed")
+	}
+
+	return
+}
+
+func (s *competitionService) joinTeam(
+	me *domain.Player, pv int,
+	team *domain.Player, version int,
+	rule *domain.TeamRule,
+) (code string, err error) {
+	if err = me.JoinTo(team, rule); err != nil {
 		if domain.IsErrorTeamMembersEnough(err) {
 			code = errorTeamMembersEnough
 		}
@@ -90,17 +126,19 @@ func (s *competitionService) JoinTeam(cid string, cmd *CompetitionTeamJoinCmd) (
 		return
 	}
 
-	if err = s.playerRepo.DeletePlayer(&me, pv); err != nil {
+	if err = s.playerRepo.DeletePlayer(me, pv); err != nil {
 		return
 	}
 
+	cid := me.CompetitionId
+
 	err = s.playerRepo.AddMember(
 		repository.PlayerVersion{
-			Player:  &team,
+			Player:  team,
 			Version: version,
 		},
 		repository.PlayerVersion{
-			Player:  &me,
+			Player:  me,
 			Version: pv,
 		},
 	)
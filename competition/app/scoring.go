@@ -0,0 +1,137 @@
+package app
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+	"github.com/opensourceways/xihe-server/competition/domain/scorer"
+)
+
+type CompetitionScoringService interface {
+	// Score scores the calculating submissions of the in-progress competitions
+	// which use the builtin metric.
+	Score() error
+}
+
+func NewCompetitionScoringService(
+	repo repository.Competition,
+	workRepo repository.Work,
+	scorer scorer.Scorer,
+) CompetitionScoringService {
+	return competitionScoringService{
+		repo:     repo,
+		workRepo: workRepo,
+		scorer:   scorer,
+	}
+}
+
+type competitionScoringService struct {
+	repo     repository.Competition
+	workRepo repository.Work
+	scorer   scorer.Scorer
+}
+
+func (s competitionScoringService) Score() error {
+	v, err := s.repo.FindCompetitions(&repository.CompetitionListOption{
+		Status: domain.CompetitionStatusInProgress,
+	})
+	if err != nil {
+		return err
+	}
+
+	for i := range v {
+		c, err := s.repo.FindCompetition(v[i].Id)
+		if err != nil {
+			logrus.Errorf(
+				"find competition(%s) to score failed, err:%s",
+				v[i].Id, err.Error(),
+			)
+
+			continue
+		}
+
+		if !c.Metric.IsBuiltin() {
+			continue
+		}
+
+		if err := s.scoreCompetition(&c); err != nil {
+			logrus.Errorf(
+				"score submissions of competition(%s) failed, err:%s",
+				c.Id, err.Error(),
+			)
+		}
+	}
+
+	return nil
+}
+
+// scoreCompetition scores the calculating submissions of the current phase.
+// The ground truth is loaded once when the first submission is scored.
+func (s competitionScoringService) scoreCompetition(c *domain.Competition) error {
+	ws, err := s.workRepo.FindWorks(c.Id)
+	if err != nil {
+		return err
+	}
+
+	var truth scorer.GroundTruth
+
+	for i := range ws {
+		w := &ws[i]
+
+		submissions := w.Submissions(c.Phase)
+		for j := range submissions {
+			item := &submissions[j]
+
+			if !item.IsCalculating() {
+				continue
+			}
+
+			if truth == nil {
+				// none of the submissions can be scored without the ground truth.
+				if truth, err = s.scorer.LoadGroundTruth(c.GroundTruthPath(c.Phase)); err != nil {
+					return err
+				}
+			}
+
+			if err := s.scoreSubmission(c, truth, w, item); err != nil {
+				logrus.Errorf(
+					"score submission(%s) of competition(%s) failed, err:%s",
+					item.Id, c.Id, err.Error(),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s competitionScoringService) scoreSubmission(
+	c *domain.Competition, truth scorer.GroundTruth,
+	w *domain.Work, submission *domain.Submission,
+) error {
+	result, err := truth.Score(c.Metric, submission.OBSPath)
+	if err != nil {
+		if !scorer.IsErrorInvalidSubmission(err) {
+			return err
+		}
+
+		logrus.Debugf(
+			"submission(%s) of competition(%s) is invalid, err:%s",
+			submission.Id, c.Id, err.Error(),
+		)
+
+		submission.SetFailed()
+	} else {
+		submission.SetScore(result.Public)
+
+		if result.HasPrivate {
+			submission.SetPrivateScore(result.Private)
+		}
+	}
+
+	return s.workRepo.SaveSubmission(w, &domain.PhaseSubmission{
+		Phase:      c.Phase,
+		Submission: *submission,
+	})
+}
@@ -13,7 +13,7 @@ import (
 func (s *competitionService) GetRankingList(cid string) (
 	dto CompetitonRankingDTO, err error,
 ) {
-	order, err := s.repo.FindScoreOrder(cid)
+	competition, err := s.repo.FindCompetition(cid)
 	if err != nil {
 		return
 	}
@@ -23,14 +23,28 @@ func (s *competitionService) GetRankingList(cid string) (
 		return
 	}
 
+	order := competition.Order
+
 	dto.Final = s.getRankingList(
-		results, domain.CompetitionPhaseFinal, order,
+		results, domain.CompetitionPhaseFinal, order, false,
 	)
 
 	dto.Preliminary = s.getRankingList(
-		results, domain.CompetitionPhasePreliminary, order,
+		results, domain.CompetitionPhasePreliminary, order, false,
 	)
 
+	// the private ranking is revealed only when the competition is over, and
+	// it is empty if the ground truth has no private split.
+	if competition.IsOver() {
+		dto.PrivateFinal = s.getRankingList(
+			results, domain.CompetitionPhaseFinal, order, true,
+		)
+
+		dto.PrivatePreliminary = s.getRankingList(
+			results, domain.CompetitionPhasePreliminary, order, true,
+		)
+	}
+
 	return
 }
 
@@ -38,9 +52,22 @@ func (s *competitionService) getRankingList(
 	ws []domain.Work,
 	phase domain.CompetitionPhase,
 	order domain.CompetitionScoreOrder,
+	private bool,
 ) []RankingDTO {
 	dtos := make([]RankingDTO, 0, len(ws))
 	for i := range ws {
+		if private {
+			if v := ws[i].BestPrivateOne(phase, order); v != nil {
+				dtos = append(dtos, RankingDTO{
+					Score:    v.PrivateScore,
+					TeamName: ws[i].PlayerName,
+					SubmitAt: utils.ToDate(v.SubmitAt),
+				})
+			}
+
+			continue
+		}
+
 		if v := ws[i].BestOne(phase, order); v != nil {
 			dtos = append(dtos, RankingDTO{
 				Score:    v.Score,
@@ -71,20 +98,25 @@ func (s *competitionService) GetSubmissions(cid string, user types.Account) (
 		return
 	}
 
+	phase := competition.Phase
 	w, _, err := s.workRepo.FindWork(
-		domain.NewWorkIndex(cid, p.Id), competition.Phase,
+		domain.NewWorkIndex(cid, p.Id), phase,
 	)
 	if err != nil {
-		if repoerr.IsErrorResourceNotExists(err) {
-			err = nil
+		if !repoerr.IsErrorResourceNotExists(err) {
+			return
 		}
 
-		return
+		// no submissions yet, the quota is full.
+		err = nil
+		w = domain.NewWork(cid, &p)
 	}
 
+	s.toSubmissionQuotaDTO(competition.SubmissionRule(phase), &w, phase, &dto.Quota)
+
 	dto.RelatedProject = w.Repo
 
-	results := w.Submissions(competition.Phase)
+	results := w.Submissions(phase)
 	if len(results) == 0 {
 		return
 	}
@@ -101,6 +133,12 @@ func (s *competitionService) GetSubmissions(cid string, user types.Account) (
 	items := make([]CompetitionSubmissionDTO, len(v))
 	for i := range v {
 		s.toCompetitionSubmissionDTO(v[i], &items[i])
+
+		// the private score is hidden until the competition is over.
+		if competition.IsOver() && v[i].HasPrivateScore {
+			score := v[i].PrivateScore
+			items[i].PrivateScore = &score
+		}
 	}
 
 	dto.Details = items
@@ -194,8 +232,26 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		return
 	}
 
-	// work
+	// rule
 	phase := competition.Phase
+	rule := competition.SubmissionRule(phase)
+	now := utils.Now()
+
+	if rule.IsNotOpen(now) {
+		code = errorSubmissionNotOpen
+		err = errors.New("submission is not open")
+
+		return
+	}
+
+	if rule.IsClosed(now) {
+		code = errorSubmissionClosed
+		err = errors.New("submission is closed")
+
+		return
+	}
+
+	// work
 	w, version, err := s.workRepo.FindWork(
 		domain.NewWorkIndex(competition.Id, p.Id), phase,
 	)
@@ -210,9 +266,18 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		}
 	}
 
-	if w.HasSubmittedToday(phase) {
+	quota := rule.Quota(&w, phase)
+
+	if quota.IsExhausted() {
+		code = errorSubmitExceedTotal
+		err = errors.New("exceed the total submissions")
+
+		return
+	}
+
+	if quota.IsExhaustedToday() {
 		code = errorSubmitTooMany
-		err = errors.New("submit more than one time per day")
+		err = errors.New("exceed the submissions per day")
 
 		return
 	}
@@ -229,10 +294,12 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 		return
 	}
 
-	// notify
-	info := w.NewSubmissionMessage(&ps)
-	if err = s.producer.NotifyCalcScore(&info); err != nil {
-		return
+	// notify the external scorer, the builtin metric is calculated by the scoring service.
+	if !competition.Metric.IsBuiltin() {
+		info := w.NewSubmissionMessage(&ps)
+		if err = s.producer.NotifyCalcScore(&info); err != nil {
+			return
+		}
 	}
 
 	dto.FileName = cmd.FileName
@@ -241,3 +308,57 @@ func (s *competitionService) Submit(cmd *CompetitionSubmitCMD) (
 
 	return
 }
+
+func (s *competitionService) SelectSubmission(cmd *CompetitionSubmissionSelectCmd) (
+	code string, err error,
+) {
+	competition, err := s.repo.FindCompetition(cmd.CompetitionId)
+	if err != nil {
+		return
+	}
+
+	if competition.IsOver() {
+		code = errorIsOver
+		err = errors.New("competition is over")
+
+		return
+	}
+
+	p, _, err := s.playerRepo.FindPlayer(cmd.CompetitionId, cmd.User)
+	if err != nil {
+		return
+	}
+
+	if !p.IsIndividualOrLeader() {
+		code = errorNoPermission
+		err = errors.New("no permission to select submission")
+
+		return
+	}
+
+	phase := competition.Phase
+	w, _, err := s.workRepo.FindWork(
+		domain.NewWorkIndex(competition.Id, p.Id), phase,
+	)
+	if err != nil {
+		return
+	}
+
+	submission, err := w.SelectSubmission(
+		phase, cmd.SubmissionId, cmd.Selected, &competition.SelectionRule,
+	)
+	if err != nil {
+		if domain.IsErrorTooManySelectedSubmissions(err) {
+			code = errorTooManySelectedSubmissions
+		}
+
+		return
+	}
+
+	err = s.workRepo.SaveSubmission(&w, &domain.PhaseSubmission{
+		Phase:      phase,
+		Submission: *submission,
+	})
+
+	return
+}
@@ -118,3 +118,268 @@ func (req *TransferLeaderRequest) ToCmd(leader types.Account) (
 }
 
 type DeleteMemberRequest = TransferLeaderRequest
+
+type InviteRequest struct {
+	Account string `json:"competitor_account"`
+}
+
+func (req *InviteRequest) ToCmd(leader types.Account) (
+	cmd app.CompetitionTeamInviteCmd, err error,
+) {
+	if cmd.Competitor, err = types.NewAccount(req.Account); err != nil {
+		return
+	}
+
+	cmd.Leader = leader
+
+	return
+}
+
+type RequestToJoinRequest = JoinTeamRequest
+
+type CompetitionCreateRequest struct {
+	Name       string   `json:"name"`
+	Desc       string   `json:"desc"`
+	Host       string   `json:"host"`
+	Bonus      int      `json:"bonus"`
+	Duration   string   `json:"duration"`
+	Poster     string   `json:"poster"`
+	Tags       []string `json:"tags"`
+	Doc        string   `json:"doc"`
+	Forum      string   `json:"forum"`
+	Winners    string   `json:"winners"`
+	DatasetDoc string   `json:"dataset_doc"`
+	DatasetURL string   `json:"dataset_url"`
+	Type       string   `json:"type"`
+	SmallerOk  bool     `json:"smaller_is_better"`
+	Metric     string   `json:"metric"`
+
+	// it allows a submission per day if the rule is not set.
+	PreliminaryRule *SubmissionRuleRequest `json:"preliminary_rule"`
+	FinalRule       *SubmissionRuleRequest `json:"final_rule"`
+
+	// it allows a team of three competitors if the rule is not set.
+	TeamRule *TeamRuleRequest `json:"team_rule"`
+
+	// it allows two selected submissions if the rule is not set.
+	SelectionRule *SelectionRuleRequest `json:"selection_rule"`
+
+	// the accounts of users who manage the competition.
+	Organizers []string `json:"organizers"`
+}
+
+type SubmissionRuleRequest struct {
+	DailyLimit int   `json:"daily_limit"`
+	TotalLimit int   `json:"total_limit"`
+	OpenAt     int64 `json:"open_at"`
+	CloseAt    int64 `json:"close_at"`
+}
+
+func (req *SubmissionRuleRequest) toRule() (domain.SubmissionRule, error) {
+	if req == nil {
+		return domain.DefaultSubmissionRule(), nil
+	}
+
+	return domain.NewSubmissionRule(req.DailyLimit, req.TotalLimit, req.OpenAt, req.CloseAt)
+}
+
+type TeamRuleRequest struct {
+	MaxSize       int   `json:"max_size"`
+	MergeDeadline int64 `json:"merge_deadline"`
+}
+
+func (req *TeamRuleRequest) toRule() (domain.TeamRule, error) {
+	if req == nil {
+		return domain.DefaultTeamRule(), nil
+	}
+
+	return domain.NewTeamRule(req.MaxSize, req.MergeDeadline)
+}
+
+type SelectionRuleRequest struct {
+	MaxSelected int `json:"max_selected"`
+}
+
+func (req *SelectionRuleRequest) toRule() (domain.SelectionRule, error) {
+	if req == nil {
+		return domain.DefaultSelectionRule(), nil
+	}
+
+	return domain.NewSelectionRule(req.MaxSelected)
+}
+
+func (req *CompetitionCreateRequest) toEditCmd(cmd *app.CompetitionEditCmd) (err error) {
+	if cmd.Name, err = domain.NewCompetitionName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = domain.NewCompetitionDesc(req.Desc); err != nil {
+		return
+	}
+
+	if cmd.Host, err = domain.NewCompetitionHost(req.Host); err != nil {
+		return
+	}
+
+	if cmd.Bonus, err = domain.NewCompetitionBonus(req.Bonus); err != nil {
+		return
+	}
+
+	if cmd.Duration, err = domain.NewCompetitionDuration(req.Duration); err != nil {
+		return
+	}
+
+	if cmd.Poster, err = domain.NewURL(req.Poster); err != nil {
+		return
+	}
+
+	if cmd.Doc, err = domain.NewURL(req.Doc); err != nil {
+		return
+	}
+
+	if cmd.Forum, err = domain.NewForum(req.Forum); err != nil {
+		return
+	}
+
+	if cmd.Winners, err = domain.NewWinners(req.Winners); err != nil {
+		return
+	}
+
+	if cmd.DatasetDoc, err = domain.NewURL(req.DatasetDoc); err != nil {
+		return
+	}
+
+	if cmd.DatasetURL, err = domain.NewURL(req.DatasetURL); err != nil {
+		return
+	}
+
+	if cmd.Type, err = domain.NewCompetitionType(req.Type); err != nil {
+		return
+	}
+
+	cmd.Tags = make([]domain.CompetitionTag, len(req.Tags))
+	for i := range req.Tags {
+		if cmd.Tags[i], err = domain.NewCompetitionTag(req.Tags[i]); err != nil {
+			return
+		}
+	}
+
+	cmd.Order = domain.NewCompetitionScoreOrder(req.SmallerOk)
+
+	if cmd.Metric, err = domain.NewScoreMetric(req.Metric); err != nil {
+		return
+	}
+
+	if cmd.PreliminaryRule, err = req.PreliminaryRule.toRule(); err != nil {
+		return
+	}
+
+	if cmd.FinalRule, err = req.FinalRule.toRule(); err != nil {
+		return
+	}
+
+	if cmd.TeamRule, err = req.TeamRule.toRule(); err != nil {
+		return
+	}
+
+	if cmd.SelectionRule, err = req.SelectionRule.toRule(); err != nil {
+		return
+	}
+
+	cmd.Organizers = make([]types.Account, len(req.Organizers))
+	for i := range req.Organizers {
+		if cmd.Organizers[i], err = types.NewAccount(req.Organizers[i]); err != nil {
+			return
+		}
+	}
+
+	err = cmd.Validate()
+
+	return
+}
+
+func (req *CompetitionCreateRequest) ToCmd(operator types.Account) (
+	cmd app.CompetitionCreateCmd, err error,
+) {
+	cmd.Operator = operator
+	err = req.toEditCmd(&cmd.CompetitionEditCmd)
+
+	return
+}
+
+type CompetitionUpdateRequest struct {
+	Phase string `json:"phase"`
+
+	CompetitionCreateRequest
+}
+
+func (req *CompetitionUpdateRequest) ToCmd(operator types.Account, cid string) (
+	cmd app.CompetitionUpdateCmd, err error,
+) {
+	if err = req.toEditCmd(&cmd.CompetitionEditCmd); err != nil {
+		return
+	}
+
+	if cmd.Phase, err = domain.NewCompetitionPhase(req.Phase); err != nil {
+		return
+	}
+
+	cmd.Operator = operator
+	cmd.CompetitionId = cid
+
+	err = cmd.Validate()
+
+	return
+}
+
+type CompetitionStatusChangeRequest struct {
+	Status string `json:"status"`
+}
+
+func (req *CompetitionStatusChangeRequest) ToCmd(operator types.Account, cid string) (
+	cmd app.CompetitionStatusChangeCmd, err error,
+) {
+	if cmd.Status, err = domain.NewCompetitionStatus(req.Status); err != nil {
+		return
+	}
+
+	cmd.Operator = operator
+	cmd.CompetitionId = cid
+
+	return
+}
+
+type PromoteFinalistsRequest struct {
+	Competitors []string `json:"competitors"`
+}
+
+func (req *PromoteFinalistsRequest) ToCmd(operator types.Account, cid string) (
+	cmd app.CompetitionFinalistsPromoteCmd, err error,
+) {
+	cmd.Competitors = make([]types.Account, len(req.Competitors))
+	for i := range req.Competitors {
+		if cmd.Competitors[i], err = types.NewAccount(req.Competitors[i]); err != nil {
+			return
+		}
+	}
+
+	cmd.Operator = operator
+	cmd.CompetitionId = cid
+
+	err = cmd.Validate()
+
+	return
+}
+
+type SelectSubmissionRequest struct {
+	Selected bool `json:"selected"`
+}
+
+func (req *SelectSubmissionRequest) ToCmd(user types.Account, cid, sid string) app.CompetitionSubmissionSelectCmd {
+	return app.CompetitionSubmissionSelectCmd{
+		CompetitionId: cid,
+		SubmissionId:  sid,
+		User:          user,
+		Selected:      req.Selected,
+	}
+}
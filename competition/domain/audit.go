@@ -0,0 +1,20 @@
+package domain
+
+import types "github.com/opensourceways/xihe-server/domain"
+
+const (
+	AuditActionCreate            = "create"
+	AuditActionUpdate            = "update"
+	AuditActionChangeStatus      = "change_status"
+	AuditActionPromoteFinalists  = "promote_finalists"
+	AuditActionUploadGroundTruth = "upload_ground_truth"
+)
+
+// AuditLog records a change made to the competition by the organizer.
+type AuditLog struct {
+	CompetitionId string
+	Operator      types.Account
+	Action        string
+	Detail        string
+	CreatedAt     int64
+}
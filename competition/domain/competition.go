@@ -1,5 +1,12 @@
 package domain
 
+import (
+	"errors"
+	"fmt"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
 type CompetitionSummary struct {
 	Id       string
 	Name     CompetitionName
@@ -21,15 +28,96 @@ type Competition struct {
 	DatasetDoc URL
 	DatasetURL URL
 
-	Type  CompetitionType
-	Phase CompetitionPhase
-	Order CompetitionScoreOrder
+	Type   CompetitionType
+	Phase  CompetitionPhase
+	Order  CompetitionScoreOrder
+	Metric ScoreMetric
+
+	PreliminaryRule SubmissionRule
+	FinalRule       SubmissionRule
+	TeamRule        TeamRule
+	SelectionRule   SelectionRule
+
+	// Organizers are the users who manage the competition.
+	Organizers []types.Account
+}
+
+func (c *Competition) IsOrganizer(a types.Account) bool {
+	for i := range c.Organizers {
+		if c.Organizers[i].Account() == a.Account() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChangeScoring changes how the submissions are scored, and it is
+// not allowed after the competition starts.
+func (c *Competition) ChangeScoring(order CompetitionScoreOrder, metric ScoreMetric) error {
+	b := c.Order != nil && c.Metric != nil &&
+		c.Order.SmallerIsBetter() == order.SmallerIsBetter() &&
+		c.Metric.ScoreMetric() == metric.ScoreMetric()
+	if b {
+		return nil
+	}
+
+	if !c.Status.IsPreparing() {
+		return errors.New("the competition has started")
+	}
+
+	c.Order = order
+	c.Metric = metric
+
+	return nil
 }
 
 func (c *Competition) IsOver() bool {
 	return c.Status != nil && c.Status.IsOver()
 }
 
+// ChangeStatus moves the competition forward, it is preparing -> in-progress -> over.
+func (c *Competition) ChangeStatus(s CompetitionStatus) error {
+	b := (c.Status.IsPreparing() && s.IsInProgress()) ||
+		(c.Status.IsInProgress() && s.IsOver())
+
+	if !b {
+		return errors.New("invalid status transition")
+	}
+
+	c.Status = s
+
+	return nil
+}
+
+// ChangePhase moves the competition forward, it is preliminary -> final.
+func (c *Competition) ChangePhase(p CompetitionPhase) error {
+	if c.Phase.CompetitionPhase() == p.CompetitionPhase() {
+		return nil
+	}
+
+	if !(c.Phase.IsPreliminary() && p.IsFinal()) {
+		return errors.New("invalid phase transition")
+	}
+
+	c.Phase = p
+
+	return nil
+}
+
+func (c *Competition) SubmissionRule(phase CompetitionPhase) *SubmissionRule {
+	if phase.IsFinal() {
+		return &c.FinalRule
+	}
+
+	return &c.PreliminaryRule
+}
+
+// GroundTruthPath is the hidden path of the ground truth used by the builtin metric.
+func (c *Competition) GroundTruthPath(phase CompetitionPhase) string {
+	return fmt.Sprintf("%s/ground_truth/%s", c.Id, phase.CompetitionPhase())
+}
+
 func (c *Competition) IsPreliminary() bool {
 	return c.Phase.IsPreliminary()
 }
@@ -41,6 +129,7 @@ func (c *Competition) IsFinal() bool {
 // CompetitionScoreOrder
 type CompetitionScoreOrder interface {
 	IsBetterThanB(a, b float32) bool
+	SmallerIsBetter() bool
 }
 
 func NewCompetitionScoreOrder(b bool) CompetitionScoreOrder {
@@ -56,3 +145,7 @@ func (order smallerIsBetter) IsBetterThanB(a, b float32) bool {
 
 	return a >= b
 }
+
+func (order smallerIsBetter) SmallerIsBetter() bool {
+	return bool(order)
+}
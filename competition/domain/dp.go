@@ -23,7 +23,16 @@ const (
 	competitionIdentityTeacher   = "teacher"
 	competitionIdentityDeveloper = "developer"
 
-	competitionSubmissionStatusSuccess = "success"
+	competitionSubmissionStatusFailed      = "failed"
+	competitionSubmissionStatusSuccess     = "success"
+	competitionSubmissionStatusCalculating = "calculating"
+
+	submissionUnlimited = -1
+
+	scoreMetricF1       = "f1"
+	scoreMetricMAP      = "map"
+	scoreMetricRMSE     = "rmse"
+	scoreMetricAccuracy = "accuracy"
 
 	competitionTagElectricity = "electricity"
 	competitionTagLearn       = "learn"
@@ -33,6 +42,9 @@ const (
 var (
 	CompetitionPhaseFinal       = competitionPhase("final")
 	CompetitionPhasePreliminary = competitionPhase("preliminary")
+
+	CompetitionStatusPreparing  = competitionStatus(competitionStatusPreparing)
+	CompetitionStatusInProgress = competitionStatus(competitionStatusInProgress)
 )
 
 // CompetitionType
@@ -87,6 +99,8 @@ func (r competitionPhase) IsPreliminary() bool {
 type CompetitionStatus interface {
 	CompetitionStatus() string
 	IsOver() bool
+	IsPreparing() bool
+	IsInProgress() bool
 }
 
 func NewCompetitionStatus(v string) (CompetitionStatus, error) {
@@ -111,6 +125,52 @@ func (r competitionStatus) IsOver() bool {
 	return string(r) == competitionStatusOver
 }
 
+func (r competitionStatus) IsPreparing() bool {
+	return string(r) == competitionStatusPreparing
+}
+
+func (r competitionStatus) IsInProgress() bool {
+	return string(r) == competitionStatusInProgress
+}
+
+// ScoreMetric
+// The submissions are scored by the external scorer if the metric is empty.
+type ScoreMetric interface {
+	ScoreMetric() string
+	IsBuiltin() bool
+	SmallerIsBetter() bool
+}
+
+func NewScoreMetric(v string) (ScoreMetric, error) {
+	b := v == "" ||
+		v == scoreMetricF1 ||
+		v == scoreMetricMAP ||
+		v == scoreMetricRMSE ||
+		v == scoreMetricAccuracy
+
+	if b {
+		return scoreMetric(v), nil
+	}
+
+	return nil, errors.New("invalid score metric")
+}
+
+type scoreMetric string
+
+func (r scoreMetric) ScoreMetric() string {
+	return string(r)
+}
+
+func (r scoreMetric) IsBuiltin() bool {
+	return r != ""
+}
+
+// SmallerIsBetter is the order of the builtin metric, the error is better
+// when it is smaller while the others are on the contrary.
+func (r scoreMetric) SmallerIsBetter() bool {
+	return r == scoreMetricRMSE
+}
+
 // CompetitionName
 type CompetitionName interface {
 	CompetitionName() string
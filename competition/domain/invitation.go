@@ -0,0 +1,72 @@
+package domain
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	invitationTypeInvite  = "invite"
+	invitationTypeRequest = "request"
+)
+
+// Invitation is a pending invitation from a team to an individual competitor,
+// or a pending request from an individual competitor to a team.
+// TeamLeader is the leader of team when the invitation is created.
+type Invitation struct {
+	Id            string
+	CompetitionId string
+	Type          string
+	TeamId        string
+	TeamName      string
+	TeamLeader    types.Account
+	Competitor    types.Account
+	CreatedAt     int64
+}
+
+func NewTeamInvitation(team *Player, competitor types.Account) Invitation {
+	return newInvitation(invitationTypeInvite, team, competitor)
+}
+
+func NewTeamRequest(team *Player, competitor types.Account) Invitation {
+	return newInvitation(invitationTypeRequest, team, competitor)
+}
+
+func newInvitation(t string, team *Player, competitor types.Account) Invitation {
+	return Invitation{
+		CompetitionId: team.CompetitionId,
+		Type:          t,
+		TeamId:        team.Id,
+		TeamName:      team.Name(),
+		TeamLeader:    team.Leader.Account,
+		Competitor:    competitor,
+		CreatedAt:     utils.Now(),
+	}
+}
+
+func (inv *Invitation) IsRequest() bool {
+	return inv.Type == invitationTypeRequest
+}
+
+func (inv *Invitation) isCompetitor(a types.Account) bool {
+	return inv.Competitor.Account() == a.Account()
+}
+
+func (inv *Invitation) isTeamLeader(p *Player) bool {
+	return p.Id == inv.TeamId && p.IsTeamLeader()
+}
+
+// CanBeAcceptedBy checks whether the player is the receiver of the invitation.
+// The player should be found by the current user.
+func (inv *Invitation) CanBeAcceptedBy(p *Player) bool {
+	if inv.IsRequest() {
+		return inv.isTeamLeader(p)
+	}
+
+	return p.IsIndividual() && inv.isCompetitor(p.user)
+}
+
+// CanBeDeclinedBy checks whether the player is either party of the invitation.
+func (inv *Invitation) CanBeDeclinedBy(p *Player) bool {
+	return inv.isTeamLeader(p) || inv.isCompetitor(p.user)
+}
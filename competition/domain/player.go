@@ -68,9 +68,9 @@ func (t *Team) remove(a types.Account) error {
 	return nil
 }
 
-func (t *Team) join(c *Competitor) error {
-	// TODO config
-	if len(t.Members) >= 2 {
+// join adds the competitor as long as the team including the leader is less than maxSize.
+func (t *Team) join(c *Competitor, maxSize int) error {
+	if len(t.Members)+1 >= maxSize {
 		return errorTeamMembersEnough
 	}
 
@@ -127,6 +127,10 @@ func (p *Player) isUserTheLeader() bool {
 	return p.user != nil && p.user.Account() == p.Leader.Account.Account()
 }
 
+func (p *Player) IsTeamLeader() bool {
+	return p.IsATeam() && p.isUserTheLeader()
+}
+
 func (p *Player) IsIndividualOrLeader() bool {
 	return p.IsIndividual() || p.isUserTheLeader()
 }
@@ -179,7 +183,7 @@ func (p *Player) ChangeTeamName(name TeamName) error {
 	return nil
 }
 
-func (p *Player) JoinTo(team *Player) error {
+func (p *Player) JoinTo(team *Player, rule *TeamRule) error {
 	if !p.IsIndividual() {
 		return errors.New("you are not an individual competitor")
 	}
@@ -188,15 +192,15 @@ func (p *Player) JoinTo(team *Player) error {
 		return errors.New("it is not a team")
 	}
 
-	return team.join(&p.Leader)
+	return team.join(&p.Leader, rule.MaxSize)
 }
 
-func (p *Player) join(c *Competitor) error {
+func (p *Player) join(c *Competitor, maxSize int) error {
 	if p.Leader.Account.Account() == c.Account.Account() {
 		return errors.New("invalid operation")
 	}
 
-	return p.Team.join(c)
+	return p.Team.join(c, maxSize)
 }
 
 func (p *Player) Quit() error {
@@ -16,6 +16,25 @@ type Competition interface {
 	FindCompetitions(*CompetitionListOption) ([]domain.CompetitionSummary, error)
 
 	FindScoreOrder(cid string) (domain.CompetitionScoreOrder, error)
+
+	AddCompetition(*domain.Competition) (string, error)
+	SaveCompetition(*domain.Competition) error
+}
+
+type AuditLog interface {
+	AddAuditLog(*domain.AuditLog) error
+	FindAuditLogs(cid string) ([]domain.AuditLog, error)
+}
+
+// Invitation stores the pending invitations and requests of teams.
+// It will be deleted when it is accepted or declined.
+type Invitation interface {
+	AddInvitation(*domain.Invitation) (string, error)
+	FindInvitation(id string) (domain.Invitation, error)
+	FindInvitationsOfCompetitor(cid string, a types.Account) ([]domain.Invitation, error)
+	FindInvitationsOfTeam(cid, teamId string) ([]domain.Invitation, error)
+	DeleteInvitation(id string) error
+	DeleteInvitationsOfCompetitor(cid string, a types.Account) error
 }
 
 type PlayerVersion struct {
@@ -34,6 +53,8 @@ type Player interface {
 
 	FindPlayer(cid string, a types.Account) (domain.Player, int, error)
 
+	FindPlayerById(cid, id string) (domain.Player, int, error)
+
 	FindCompetitionsUserApplied(types.Account) ([]string, error)
 
 	SavePlayer(p *domain.Player, version int) error
@@ -51,4 +72,5 @@ type Work interface {
 
 	FindWork(domain.WorkIndex, domain.CompetitionPhase) (domain.Work, int, error)
 	FindWorks(cid string) ([]domain.Work, error)
+	FindWorksByRepo(repo string) ([]domain.Work, error)
 }
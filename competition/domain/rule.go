@@ -0,0 +1,132 @@
+package domain
+
+import "errors"
+
+// SubmissionRule restricts the submissions of a player in a phase.
+// The zero value of each field means no restriction.
+type SubmissionRule struct {
+	DailyLimit int
+	TotalLimit int
+	OpenAt     int64
+	CloseAt    int64
+}
+
+func NewSubmissionRule(daily, total int, openAt, closeAt int64) (SubmissionRule, error) {
+	if daily < 0 || total < 0 || openAt < 0 || closeAt < 0 {
+		return SubmissionRule{}, errors.New("invalid submission rule")
+	}
+
+	if closeAt > 0 && closeAt <= openAt {
+		return SubmissionRule{}, errors.New("close time must be after the open time")
+	}
+
+	return SubmissionRule{
+		DailyLimit: daily,
+		TotalLimit: total,
+		OpenAt:     openAt,
+		CloseAt:    closeAt,
+	}, nil
+}
+
+// DefaultSubmissionRule is the rule which allows a submission per day.
+func DefaultSubmissionRule() SubmissionRule {
+	return SubmissionRule{DailyLimit: 1}
+}
+
+func (r *SubmissionRule) IsNotOpen(t int64) bool {
+	return r.OpenAt > 0 && t < r.OpenAt
+}
+
+func (r *SubmissionRule) IsClosed(t int64) bool {
+	return r.CloseAt > 0 && t >= r.CloseAt
+}
+
+// Quota returns the remaining submissions of the work.
+func (r *SubmissionRule) Quota(w *Work, phase CompetitionPhase) SubmissionQuota {
+	return SubmissionQuota{
+		Today: remaining(r.DailyLimit, w.submittedToday(phase)),
+		Total: remaining(r.TotalLimit, len(w.Submissions(phase))),
+	}
+}
+
+func remaining(limit, used int) int {
+	if limit == 0 {
+		return submissionUnlimited
+	}
+
+	if used >= limit {
+		return 0
+	}
+
+	return limit - used
+}
+
+// SubmissionQuota
+// Each field is the remaining count of submissions, it is negative if unlimited.
+type SubmissionQuota struct {
+	Today int
+	Total int
+}
+
+func (q *SubmissionQuota) IsExhaustedToday() bool {
+	return q.Today == 0
+}
+
+func (q *SubmissionQuota) IsExhausted() bool {
+	return q.Total == 0
+}
+
+// TeamRule restricts the teams of a competition.
+// MaxSize is the max number of competitors of a team including the leader.
+// The teams can't be merged after MergeDeadline if it is set.
+type TeamRule struct {
+	MaxSize       int
+	MergeDeadline int64
+}
+
+func NewTeamRule(maxSize int, mergeDeadline int64) (TeamRule, error) {
+	if maxSize < 1 || mergeDeadline < 0 {
+		return TeamRule{}, errors.New("invalid team rule")
+	}
+
+	return TeamRule{
+		MaxSize:       maxSize,
+		MergeDeadline: mergeDeadline,
+	}, nil
+}
+
+// DefaultTeamRule is the rule which allows a team of three competitors.
+func DefaultTeamRule() TeamRule {
+	return TeamRule{MaxSize: 3}
+}
+
+func (r *TeamRule) IsMergeClosed(t int64) bool {
+	return r.MergeDeadline > 0 && t >= r.MergeDeadline
+}
+
+func (r *TeamRule) IsFull(team *Player) bool {
+	return team.CompetitorsCount() >= r.MaxSize
+}
+
+// SelectionRule restricts the submissions which a player can choose to count
+// for the private ranking of a phase.
+type SelectionRule struct {
+	MaxSelected int
+}
+
+func NewSelectionRule(maxSelected int) (SelectionRule, error) {
+	if maxSelected < 1 {
+		return SelectionRule{}, errors.New("invalid selection rule")
+	}
+
+	return SelectionRule{MaxSelected: maxSelected}, nil
+}
+
+// DefaultSelectionRule is the rule which allows two selected submissions.
+func DefaultSelectionRule() SelectionRule {
+	return SelectionRule{MaxSelected: 2}
+}
+
+func (r *SelectionRule) IsFull(selected int) bool {
+	return selected >= r.MaxSelected
+}
@@ -0,0 +1,40 @@
+package scorer
+
+import "github.com/opensourceways/xihe-server/competition/domain"
+
+// ErrorInvalidSubmission means the submission can't be scored forever,
+// such as it is in a wrong format or misses some predictions.
+type ErrorInvalidSubmission struct {
+	error
+}
+
+func NewErrorInvalidSubmission(err error) ErrorInvalidSubmission {
+	return ErrorInvalidSubmission{err}
+}
+
+func IsErrorInvalidSubmission(err error) bool {
+	_, ok := err.(ErrorInvalidSubmission)
+
+	return ok
+}
+
+// Result is the scores calculated on the public and private split of the ground truth.
+// HasPrivate is false if the ground truth has no private split.
+type Result struct {
+	Public     float32
+	Private    float32
+	HasPrivate bool
+}
+
+// GroundTruth is the loaded ground truth of a competition phase,
+// and it is reused to score all the submissions of the phase.
+type GroundTruth interface {
+	// Score calculates the score of the submission file with the metric.
+	// It returns ErrorInvalidSubmission if the submission is invalid.
+	Score(metric domain.ScoreMetric, submission string) (Result, error)
+}
+
+type Scorer interface {
+	// LoadGroundTruth downloads and parses the ground truth file.
+	LoadGroundTruth(path string) (GroundTruth, error)
+}
@@ -29,18 +29,51 @@ type SubmissionMessage struct {
 }
 
 // Submission
+// Score is calculated on the public split of ground truth and PrivateScore on the private one.
+// HasPrivateScore is false if the ground truth has no private split, such as the
+// submission is scored by the external scorer which reports only one score.
+// Selected means the player chooses it to count for the private ranking.
 type Submission struct {
-	Id       string
-	Status   string
-	OBSPath  string
-	SubmitAt int64
-	Score    float32
+	Id              string
+	Status          string
+	OBSPath         string
+	SubmitAt        int64
+	Score           float32
+	PrivateScore    float32
+	HasPrivateScore bool
+	Selected        bool
 }
 
 func (info *Submission) isSuccess() bool {
 	return info.Status == competitionSubmissionStatusSuccess
 }
 
+func (info *Submission) IsCalculating() bool {
+	return info.Status == competitionSubmissionStatusCalculating
+}
+
+func (info *Submission) SetScore(public float32) {
+	info.Status = competitionSubmissionStatusSuccess
+	info.Score = public
+	info.clearPrivateScore()
+}
+
+func (info *Submission) SetPrivateScore(private float32) {
+	info.PrivateScore = private
+	info.HasPrivateScore = true
+}
+
+func (info *Submission) SetFailed() {
+	info.Status = competitionSubmissionStatusFailed
+	info.Score = 0
+	info.clearPrivateScore()
+}
+
+func (info *Submission) clearPrivateScore() {
+	info.PrivateScore = 0
+	info.HasPrivateScore = false
+}
+
 // PhaseSubmission
 type PhaseSubmission struct {
 	Phase CompetitionPhase
@@ -76,7 +109,7 @@ func (s *SubmissionService) Submit(
 			Id:       primitive.NewObjectID().Hex(),
 			SubmitAt: now,
 			OBSPath:  obspath,
-			Status:   "calculating",
+			Status:   competitionSubmissionStatusCalculating,
 		},
 		Phase: phase,
 	}, nil
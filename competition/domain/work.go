@@ -1,11 +1,18 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/opensourceways/xihe-server/utils"
 )
 
+var errorTooManySelectedSubmissions = errors.New("too many selected submissions")
+
+func IsErrorTooManySelectedSubmissions(err error) bool {
+	return errors.Is(err, errorTooManySelectedSubmissions)
+}
+
 type WorkIndex struct {
 	PlayerId      string
 	CompetitionId string
@@ -67,6 +74,71 @@ func (w *Work) BestOne(phase CompetitionPhase, order CompetitionScoreOrder) (
 	return
 }
 
+// BestPrivateOne returns the submission which has the best private score among
+// the selected ones. The best public one is used if no submission is selected.
+// It returns nil if the submission has no private score.
+func (w *Work) BestPrivateOne(phase CompetitionPhase, order CompetitionScoreOrder) (
+	r *Submission,
+) {
+	submissions := w.Submissions(phase)
+	for i := range submissions {
+		item := &submissions[i]
+
+		if !item.isSuccess() || !item.HasPrivateScore || !item.Selected {
+			continue
+		}
+
+		if r == nil || order.IsBetterThanB(item.PrivateScore, r.PrivateScore) {
+			r = item
+		}
+	}
+
+	if r == nil {
+		if r = w.BestOne(phase, order); r != nil && !r.HasPrivateScore {
+			r = nil
+		}
+	}
+
+	return
+}
+
+// SelectSubmission chooses the submission to count for the private ranking or not.
+func (w *Work) SelectSubmission(
+	phase CompetitionPhase, sid string, selected bool, rule *SelectionRule,
+) (*Submission, error) {
+	var r *Submission
+
+	n := 0
+	submissions := w.Submissions(phase)
+	for i := range submissions {
+		item := &submissions[i]
+
+		if item.Id == sid {
+			r = item
+		} else if item.Selected {
+			n++
+		}
+	}
+
+	if r == nil {
+		return nil, errors.New("no corresponding submission")
+	}
+
+	if selected {
+		if !r.isSuccess() {
+			return nil, errors.New("only the scored submission can be selected")
+		}
+
+		if rule.IsFull(n) {
+			return nil, errorTooManySelectedSubmissions
+		}
+	}
+
+	r.Selected = selected
+
+	return r, nil
+}
+
 func (w *Work) submissionOBSPathPrefix(phase CompetitionPhase) string {
 	return fmt.Sprintf(
 		"%s/%s/%s",
@@ -76,16 +148,18 @@ func (w *Work) submissionOBSPathPrefix(phase CompetitionPhase) string {
 	)
 }
 
-func (w *Work) HasSubmittedToday(phase CompetitionPhase) bool {
+func (w *Work) submittedToday(phase CompetitionPhase) int {
 	today := utils.Date()
 	submissions := w.Submissions(phase)
+
+	n := 0
 	for i := range submissions {
 		if utils.ToDate(submissions[i].SubmitAt) == today {
-			return true
+			n++
 		}
 	}
 
-	return false
+	return n
 }
 
 func (w *Work) NewSubmissionMessage(s *PhaseSubmission) SubmissionMessage {
@@ -102,8 +176,11 @@ func (w *Work) UpdateSubmission(info *SubmissionUpdatingInfo) *Submission {
 	submissions := w.Submissions(info.Phase)
 	for i := range submissions {
 		if item := &submissions[i]; item.Id == info.Id {
+			// the external scorer does not split the ground truth,
+			// so the submission has no private score.
 			item.Status = info.Status
 			item.Score = info.Score
+			item.clearPrivateScore()
 
 			return &submissions[i]
 		}
@@ -0,0 +1,60 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+)
+
+func NewAuditLogRepo(m mongodbClient) repository.AuditLog {
+	return auditLogRepoImpl{m}
+}
+
+type auditLogRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl auditLogRepoImpl) AddAuditLog(v *domain.AuditLog) error {
+	doc := toAuditLogDoc(v)
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().InsertOne(ctx, &doc)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl auditLogRepoImpl) FindAuditLogs(cid string) ([]domain.AuditLog, error) {
+	var v []dAuditLog
+
+	f := func(ctx context.Context) error {
+		cursor, err := impl.cli.Collection().Find(
+			ctx, bson.M{fieldCid: cid},
+			options.Find().SetSort(bson.M{fieldCreatedAt: -1}),
+		)
+		if err != nil {
+			return err
+		}
+
+		return cursor.All(ctx, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.AuditLog, len(v))
+	for i := range v {
+		if err := v[i].toAuditLog(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
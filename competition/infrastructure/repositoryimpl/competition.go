@@ -2,11 +2,13 @@ package repositoryimpl
 
 import (
 	"context"
+	"errors"
 
 	"github.com/opensourceways/xihe-server/competition/domain"
 	"github.com/opensourceways/xihe-server/competition/domain/repository"
 	repoerr "github.com/opensourceways/xihe-server/domain/repository"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 func NewCompetitionRepo(m mongodbClient) repository.Competition {
@@ -129,3 +131,58 @@ func (impl competitionRepoImpl) FindCompetitions(opt *repository.CompetitionList
 
 	return r, nil
 }
+
+func (impl competitionRepoImpl) AddCompetition(c *domain.Competition) (string, error) {
+	c.Id = primitive.NewObjectID().Hex()
+
+	obj := toCompetitionDoc(c)
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return "", err
+	}
+
+	f := func(ctx context.Context) error {
+		filter := bson.M{fieldName: obj.Name}
+
+		_, err := impl.cli.NewDocIfNotExist(ctx, filter, doc)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocExists(err) {
+			err = repoerr.NewErrorDuplicateCreating(err)
+		}
+
+		return "", err
+	}
+
+	return c.Id, nil
+}
+
+func (impl competitionRepoImpl) SaveCompetition(c *domain.Competition) error {
+	obj := toCompetitionDoc(c)
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		r, err := impl.cli.Collection().UpdateOne(
+			ctx, impl.docFilter(c.Id), bson.M{mongoCmdSet: doc},
+		)
+		if err != nil {
+			return err
+		}
+
+		if r.MatchedCount == 0 {
+			return repoerr.NewErrorResourceNotExists(
+				errors.New("competition does not exist"),
+			)
+		}
+
+		return nil
+	}
+
+	return withContext(f)
+}
@@ -58,6 +58,26 @@ func (doc *dCompetition) toCompetition(c *domain.Competition) (err error) {
 
 	c.Order = domain.NewCompetitionScoreOrder(doc.SmallerOk)
 
+	if c.Metric, err = domain.NewScoreMetric(doc.Metric); err != nil {
+		return
+	}
+
+	if c.PreliminaryRule, err = doc.PreliminaryRule.toSubmissionRule(); err != nil {
+		return
+	}
+
+	if c.FinalRule, err = doc.FinalRule.toSubmissionRule(); err != nil {
+		return
+	}
+
+	if c.TeamRule, err = doc.TeamRule.toTeamRule(); err != nil {
+		return
+	}
+
+	if c.SelectionRule, err = doc.SelectionRule.toSelectionRule(); err != nil {
+		return
+	}
+
 	if c.Doc, err = domain.NewURL(doc.Doc); err != nil {
 		return
 	}
@@ -78,11 +98,158 @@ func (doc *dCompetition) toCompetition(c *domain.Competition) (err error) {
 		return
 	}
 
+	c.Organizers = make([]types.Account, len(doc.Organizers))
+	for i := range doc.Organizers {
+		if c.Organizers[i], err = types.NewAccount(doc.Organizers[i]); err != nil {
+			return
+		}
+	}
+
 	err = doc.toCompetitionSummary(&c.CompetitionSummary)
 
 	return
 }
 
+func toCompetitionDoc(c *domain.Competition) dCompetition {
+	tags := make([]string, len(c.Tags))
+	for i := range c.Tags {
+		tags[i] = c.Tags[i].CompetitionTag()
+	}
+
+	organizers := make([]string, len(c.Organizers))
+	for i := range c.Organizers {
+		organizers[i] = c.Organizers[i].Account()
+	}
+
+	return dCompetition{
+		Id:         c.Id,
+		Name:       c.Name.CompetitionName(),
+		Desc:       c.Desc.CompetitionDesc(),
+		Host:       c.Host.CompetitionHost(),
+		Type:       c.Type.CompetitionType(),
+		Tags:       tags,
+		Phase:      c.Phase.CompetitionPhase(),
+		Status:     c.Status.CompetitionStatus(),
+		Duration:   c.Duration.CompetitionDuration(),
+		Doc:        c.Doc.URL(),
+		Forum:      c.Forum.Forum(),
+		Poster:     c.Poster.URL(),
+		Winners:    c.Winners.Winners(),
+		DatasetDoc: c.DatasetDoc.URL(),
+		DatasetURL: c.DatasetURL.URL(),
+		Bonus:      c.Bonus.CompetitionBonus(),
+		SmallerOk:  c.Order.SmallerIsBetter(),
+		Metric:     c.Metric.ScoreMetric(),
+
+		PreliminaryRule: toSubmissionRuleDoc(&c.PreliminaryRule),
+		FinalRule:       toSubmissionRuleDoc(&c.FinalRule),
+		TeamRule:        toTeamRuleDoc(&c.TeamRule),
+		SelectionRule:   toSelectionRuleDoc(&c.SelectionRule),
+
+		Organizers: organizers,
+	}
+}
+
+func toSubmissionRuleDoc(r *domain.SubmissionRule) *dSubmissionRule {
+	return &dSubmissionRule{
+		DailyLimit: r.DailyLimit,
+		TotalLimit: r.TotalLimit,
+		OpenAt:     r.OpenAt,
+		CloseAt:    r.CloseAt,
+	}
+}
+
+func (doc *dSubmissionRule) toSubmissionRule() (domain.SubmissionRule, error) {
+	if doc == nil {
+		return domain.DefaultSubmissionRule(), nil
+	}
+
+	return domain.NewSubmissionRule(doc.DailyLimit, doc.TotalLimit, doc.OpenAt, doc.CloseAt)
+}
+
+func toTeamRuleDoc(r *domain.TeamRule) *dTeamRule {
+	return &dTeamRule{
+		MaxSize:       r.MaxSize,
+		MergeDeadline: r.MergeDeadline,
+	}
+}
+
+func (doc *dTeamRule) toTeamRule() (domain.TeamRule, error) {
+	if doc == nil {
+		return domain.DefaultTeamRule(), nil
+	}
+
+	return domain.NewTeamRule(doc.MaxSize, doc.MergeDeadline)
+}
+
+func toSelectionRuleDoc(r *domain.SelectionRule) *dSelectionRule {
+	return &dSelectionRule{
+		MaxSelected: r.MaxSelected,
+	}
+}
+
+func (doc *dSelectionRule) toSelectionRule() (domain.SelectionRule, error) {
+	if doc == nil {
+		return domain.DefaultSelectionRule(), nil
+	}
+
+	return domain.NewSelectionRule(doc.MaxSelected)
+}
+
+func toAuditLogDoc(v *domain.AuditLog) dAuditLog {
+	return dAuditLog{
+		CompetitionId: v.CompetitionId,
+		Operator:      v.Operator.Account(),
+		Action:        v.Action,
+		Detail:        v.Detail,
+		CreatedAt:     v.CreatedAt,
+	}
+}
+
+func (doc *dAuditLog) toAuditLog(v *domain.AuditLog) (err error) {
+	if v.Operator, err = types.NewAccount(doc.Operator); err != nil {
+		return
+	}
+
+	v.CompetitionId = doc.CompetitionId
+	v.Action = doc.Action
+	v.Detail = doc.Detail
+	v.CreatedAt = doc.CreatedAt
+
+	return
+}
+
+func toInvitationDoc(v *domain.Invitation) dInvitation {
+	return dInvitation{
+		CompetitionId: v.CompetitionId,
+		Type:          v.Type,
+		TeamId:        v.TeamId,
+		TeamName:      v.TeamName,
+		TeamLeader:    v.TeamLeader.Account(),
+		Competitor:    v.Competitor.Account(),
+		CreatedAt:     v.CreatedAt,
+	}
+}
+
+func (doc *dInvitation) toInvitation(v *domain.Invitation) (err error) {
+	if v.TeamLeader, err = types.NewAccount(doc.TeamLeader); err != nil {
+		return
+	}
+
+	if v.Competitor, err = types.NewAccount(doc.Competitor); err != nil {
+		return
+	}
+
+	v.Id = doc.Id.Hex()
+	v.CompetitionId = doc.CompetitionId
+	v.Type = doc.Type
+	v.TeamId = doc.TeamId
+	v.TeamName = doc.TeamName
+	v.CreatedAt = doc.CreatedAt
+
+	return
+}
+
 func (doc *dWork) toWork(w *domain.Work) {
 	w.CompetitionId = doc.CompetitionId
 	w.PlayerName = doc.PlayerName
@@ -118,6 +285,11 @@ func (doc *dSubmission) toSubmission(s *domain.Submission) {
 		OBSPath:  doc.OBSPath,
 		SubmitAt: doc.SubmitAt,
 		Score:    float32(doc.Score),
+		Selected: doc.Selected,
+	}
+
+	if doc.PrivateScore != nil {
+		s.SetPrivateScore(float32(*doc.PrivateScore))
 	}
 }
 
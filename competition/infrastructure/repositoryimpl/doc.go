@@ -18,6 +18,10 @@ const (
 	fieldLeader      = "leader"
 	fieldStatus      = "status"
 	fieldTags        = "tags"
+	fieldName        = "name"
+	fieldCreatedAt   = "created_at"
+	fieldTeamId      = "team_id"
+	fieldCompetitor  = "competitor"
 )
 
 type dCompetition struct {
@@ -38,6 +42,50 @@ type dCompetition struct {
 	DatasetURL string   `bson:"dataset_url"     json:"dataset_url"`
 	Bonus      int      `bson:"bonus"           json:"bonus"`
 	SmallerOk  bool     `bson:"order"           json:"order"`
+	Metric     string   `bson:"metric"          json:"metric"`
+
+	// the rules are nil for the competitions created before the rules exist.
+	PreliminaryRule *dSubmissionRule `bson:"preliminary_rule"  json:"preliminary_rule"`
+	FinalRule       *dSubmissionRule `bson:"final_rule"        json:"final_rule"`
+	TeamRule        *dTeamRule       `bson:"team_rule"         json:"team_rule"`
+	SelectionRule   *dSelectionRule  `bson:"selection_rule"    json:"selection_rule"`
+
+	Organizers []string `bson:"organizers" json:"organizers"`
+}
+
+type dSubmissionRule struct {
+	DailyLimit int   `bson:"daily_limit"   json:"daily_limit"`
+	TotalLimit int   `bson:"total_limit"   json:"total_limit"`
+	OpenAt     int64 `bson:"open_at"       json:"open_at"`
+	CloseAt    int64 `bson:"close_at"      json:"close_at"`
+}
+
+type dTeamRule struct {
+	MaxSize       int   `bson:"max_size"         json:"max_size"`
+	MergeDeadline int64 `bson:"merge_deadline"   json:"merge_deadline"`
+}
+
+type dSelectionRule struct {
+	MaxSelected int `bson:"max_selected"   json:"max_selected"`
+}
+
+type dAuditLog struct {
+	CompetitionId string `bson:"cid"            json:"cid"`
+	Operator      string `bson:"operator"       json:"operator"`
+	Action        string `bson:"action"         json:"action"`
+	Detail        string `bson:"detail"         json:"detail"`
+	CreatedAt     int64  `bson:"created_at"     json:"created_at"`
+}
+
+type dInvitation struct {
+	Id            primitive.ObjectID `bson:"_id"            json:"-"`
+	CompetitionId string             `bson:"cid"            json:"cid"`
+	Type          string             `bson:"type"           json:"type"`
+	TeamId        string             `bson:"team_id"        json:"team_id"`
+	TeamName      string             `bson:"team_name"      json:"team_name"`
+	TeamLeader    string             `bson:"leader"         json:"leader"`
+	Competitor    string             `bson:"competitor"     json:"competitor"`
+	CreatedAt     int64              `bson:"created_at"     json:"created_at"`
 }
 
 type dWork struct {
@@ -50,12 +98,17 @@ type dWork struct {
 	Version       int           `bson:"version"        json:"-"`
 }
 
+// dSubmission
+// PrivateScore is nil if the submission has no private score, such as the ground truth
+// has no private split or the submission is scored before the ground truth is split.
 type dSubmission struct {
-	Id       string  `bson:"id"          json:"id"`
-	Status   string  `bson:"status"      json:"status"`
-	OBSPath  string  `bson:"path"        json:"path"`
-	SubmitAt int64   `bson:"submit_at"   json:"submit_at"`
-	Score    float64 `bson:"score"       json:"score"`
+	Id           string   `bson:"id"              json:"id"`
+	Status       string   `bson:"status"          json:"status"`
+	OBSPath      string   `bson:"path"            json:"path"`
+	SubmitAt     int64    `bson:"submit_at"       json:"submit_at"`
+	Score        float64  `bson:"score"           json:"score"`
+	PrivateScore *float64 `bson:"private_score"   json:"private_score,omitempty"`
+	Selected     bool     `bson:"selected"        json:"selected"`
 }
 
 // dPlayer
@@ -0,0 +1,151 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/repository"
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+)
+
+func NewInvitationRepo(m mongodbClient) repository.Invitation {
+	return invitationRepoImpl{m}
+}
+
+type invitationRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl invitationRepoImpl) AddInvitation(v *domain.Invitation) (string, error) {
+	obj := toInvitationDoc(v)
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return "", err
+	}
+
+	// only one pending invitation or request between the team and competitor.
+	filter := bson.M{
+		fieldCid:        v.CompetitionId,
+		fieldTeamId:     v.TeamId,
+		fieldCompetitor: v.Competitor.Account(),
+	}
+
+	var id string
+
+	f := func(ctx context.Context) error {
+		s, err := impl.cli.NewDocIfNotExist(ctx, filter, doc)
+		id = s
+
+		return err
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocExists(err) {
+			err = repoerr.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return id, err
+}
+
+func (impl invitationRepoImpl) FindInvitation(id string) (r domain.Invitation, err error) {
+	filter, err := impl.cli.ObjectIdFilter(id)
+	if err != nil {
+		return
+	}
+
+	var v dInvitation
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, filter, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+	} else {
+		err = v.toInvitation(&r)
+	}
+
+	return
+}
+
+func (impl invitationRepoImpl) FindInvitationsOfCompetitor(cid string, a types.Account) (
+	[]domain.Invitation, error,
+) {
+	return impl.find(bson.M{
+		fieldCid:        cid,
+		fieldCompetitor: a.Account(),
+	})
+}
+
+func (impl invitationRepoImpl) FindInvitationsOfTeam(cid, teamId string) (
+	[]domain.Invitation, error,
+) {
+	return impl.find(bson.M{
+		fieldCid:    cid,
+		fieldTeamId: teamId,
+	})
+}
+
+func (impl invitationRepoImpl) find(filter bson.M) ([]domain.Invitation, error) {
+	var v []dInvitation
+
+	f := func(ctx context.Context) error {
+		cursor, err := impl.cli.Collection().Find(
+			ctx, filter,
+			options.Find().SetSort(bson.M{fieldCreatedAt: -1}),
+		)
+		if err != nil {
+			return err
+		}
+
+		return cursor.All(ctx, &v)
+	}
+
+	if err := withContext(f); err != nil || len(v) == 0 {
+		return nil, err
+	}
+
+	r := make([]domain.Invitation, len(v))
+	for i := range v {
+		if err := v[i].toInvitation(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl invitationRepoImpl) DeleteInvitation(id string) error {
+	filter, err := impl.cli.ObjectIdFilter(id)
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteOne(ctx, filter)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (impl invitationRepoImpl) DeleteInvitationsOfCompetitor(cid string, a types.Account) error {
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.Collection().DeleteMany(ctx, bson.M{
+			fieldCid:        cid,
+			fieldCompetitor: a.Account(),
+		})
+
+		return err
+	}
+
+	return withContext(f)
+}
@@ -75,6 +75,7 @@ func (repo playerRepoImpl) genPlayerDoc(p *domain.Player) (bson.M, error) {
 		CompetitionId: p.CompetitionId,
 		Competitors:   cs,
 		Leader:        p.Leader.Account.Account(),
+		IsFinalist:    p.IsFinalist,
 		Enabled:       true,
 	}
 	if p.IsATeam() {
@@ -176,6 +177,36 @@ func (impl playerRepoImpl) FindPlayer(cid string, a types.Account) (
 	return
 }
 
+// FindPlayerById
+func (impl playerRepoImpl) FindPlayerById(cid, id string) (
+	p domain.Player, version int, err error,
+) {
+	filter, err := impl.playerFilter(&domain.Player{
+		PlayerIndex: domain.NewPlayerIndex(cid, id),
+	})
+	if err != nil {
+		return
+	}
+
+	var v dPlayer
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, filter, nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+	} else {
+		if err = v.toPlayer(&p); err == nil {
+			version = v.Version
+		}
+	}
+
+	return
+}
+
 // FindCompetitionsUserApplied
 func (impl playerRepoImpl) FindCompetitionsUserApplied(a types.Account) (
 	r []string, err error,
@@ -111,12 +111,20 @@ func (impl workRepoImpl) AddSubmission(
 func (impl workRepoImpl) SaveSubmission(
 	w *domain.Work, submission *domain.PhaseSubmission,
 ) error {
+	var privateScore *float64
+	if submission.HasPrivateScore {
+		v := float64(submission.PrivateScore)
+		privateScore = &v
+	}
+
 	doc, err := genDoc(dSubmission{
-		Status:   submission.Status,
-		Score:    float64(submission.Score),
-		SubmitAt: submission.SubmitAt,
-		Id:       submission.Id,
-		OBSPath:  submission.OBSPath,
+		Status:       submission.Status,
+		Score:        float64(submission.Score),
+		SubmitAt:     submission.SubmitAt,
+		Id:           submission.Id,
+		OBSPath:      submission.OBSPath,
+		PrivateScore: privateScore,
+		Selected:     submission.Selected,
 	})
 	if err != nil {
 		return err
@@ -187,3 +195,25 @@ func (impl workRepoImpl) FindWorks(cid string) (ws []domain.Work, err error) {
 
 	return
 }
+
+func (impl workRepoImpl) FindWorksByRepo(repo string) (ws []domain.Work, err error) {
+	var v []dWork
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDocs(
+			ctx, bson.M{fieldRepo: repo},
+			bson.M{fieldFinal: 0, fieldPreliminary: 0}, &v,
+		)
+	}
+
+	if err = withContext(f); err != nil || len(v) == 0 {
+		return
+	}
+
+	ws = make([]domain.Work, len(v))
+	for i := range v {
+		v[i].toWork(&ws[i])
+	}
+
+	return
+}
@@ -0,0 +1,107 @@
+package scorerimpl
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+func accuracy(predictions, truth *records) (float64, error) {
+	n := 0
+	for _, id := range truth.ids {
+		if predictions.label(id) == truth.label(id) {
+			n++
+		}
+	}
+
+	return float64(n) / float64(len(truth.ids)), nil
+}
+
+// macroF1 is the unweighted mean of F1 of each class.
+func macroF1(predictions, truth *records) (float64, error) {
+	type counter struct {
+		tp, fp, fn int
+	}
+
+	classes := map[string]*counter{}
+	get := func(class string) *counter {
+		c, ok := classes[class]
+		if !ok {
+			c = new(counter)
+			classes[class] = c
+		}
+
+		return c
+	}
+
+	for _, id := range truth.ids {
+		p, t := predictions.label(id), truth.label(id)
+		if p == t {
+			get(t).tp++
+		} else {
+			get(p).fp++
+			get(t).fn++
+		}
+	}
+
+	sum := 0.0
+	for _, c := range classes {
+		sum += 2 * float64(c.tp) / float64(2*c.tp+c.fp+c.fn)
+	}
+
+	return sum / float64(len(classes)), nil
+}
+
+func rootMeanSquaredError(predictions, truth *records) (float64, error) {
+	sum := 0.0
+	for _, id := range truth.ids {
+		p, err := strconv.ParseFloat(predictions.label(id), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid prediction of id: %s", id)
+		}
+
+		t, err := strconv.ParseFloat(truth.label(id), 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ground truth of id: %s", id)
+		}
+
+		sum += (p - t) * (p - t)
+	}
+
+	return math.Sqrt(sum / float64(len(truth.ids))), nil
+}
+
+// meanAveragePrecision takes the labels of prediction as a ranked list
+// and the labels of ground truth as the relevant ones.
+func meanAveragePrecision(predictions, truth *records) (float64, error) {
+	sum := 0.0
+	for _, id := range truth.ids {
+		sum += averagePrecision(predictions.labels[id], truth.labels[id])
+	}
+
+	return sum / float64(len(truth.ids)), nil
+}
+
+func averagePrecision(ranked, relevant []string) float64 {
+	isRelevant := make(map[string]bool, len(relevant))
+	for _, v := range relevant {
+		isRelevant[v] = true
+	}
+
+	seen := make(map[string]bool, len(ranked))
+	hits, sum := 0, 0.0
+
+	for i, v := range ranked {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+
+		if isRelevant[v] {
+			hits++
+			sum += float64(hits) / float64(i+1)
+		}
+	}
+
+	return sum / float64(len(isRelevant))
+}
@@ -0,0 +1,186 @@
+package scorerimpl
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestRecords(t *testing.T, v map[string][]string, ids ...string) *records {
+	r := newRecords()
+	for _, id := range ids {
+		if err := r.add(id, v[id]); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return r
+}
+
+func single(labels ...string) map[string][]string {
+	r := make(map[string][]string, len(labels))
+	for i, v := range labels {
+		r[string(rune('a'+i))] = []string{v}
+	}
+
+	return r
+}
+
+func singleIds(n int) []string {
+	r := make([]string, n)
+	for i := range r {
+		r[i] = string(rune('a' + i))
+	}
+
+	return r
+}
+
+func TestMetrics(t *testing.T) {
+	cases := []struct {
+		name        string
+		metric      metric
+		predictions []string
+		truth       []string
+		want        float64
+		wantErr     bool
+	}{
+		{
+			name:        "accuracy",
+			metric:      accuracy,
+			predictions: []string{"1", "1", "1", "0"},
+			truth:       []string{"1", "0", "1", "0"},
+			want:        0.75,
+		},
+		{
+			name:        "accuracy of all wrong",
+			metric:      accuracy,
+			predictions: []string{"0", "1"},
+			truth:       []string{"1", "0"},
+			want:        0,
+		},
+		{
+			name:        "macro f1",
+			metric:      macroF1,
+			predictions: []string{"1", "1", "1", "0"},
+			truth:       []string{"1", "0", "1", "0"},
+			want:        (4.0/5 + 2.0/3) / 2,
+		},
+		{
+			name:        "macro f1 with the class only predicted",
+			metric:      macroF1,
+			predictions: []string{"1", "2"},
+			truth:       []string{"1", "1"},
+			want:        (2.0/3 + 0) / 2,
+		},
+		{
+			name:        "root mean squared error",
+			metric:      rootMeanSquaredError,
+			predictions: []string{"1", "2", "5"},
+			truth:       []string{"1", "2", "3"},
+			want:        math.Sqrt(4.0 / 3),
+		},
+		{
+			name:        "root mean squared error of invalid prediction",
+			metric:      rootMeanSquaredError,
+			predictions: []string{"1", "x"},
+			truth:       []string{"1", "2"},
+			wantErr:     true,
+		},
+		{
+			name:        "root mean squared error of invalid ground truth",
+			metric:      rootMeanSquaredError,
+			predictions: []string{"1", "2"},
+			truth:       []string{"1", "x"},
+			wantErr:     true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			predictions := newTestRecords(t, single(c.predictions...), singleIds(len(c.predictions))...)
+			truth := newTestRecords(t, single(c.truth...), singleIds(len(c.truth))...)
+
+			v, err := c.metric(predictions, truth)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expect an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if math.Abs(v-c.want) > 1e-9 {
+				t.Fatalf("expect %v, got %v", c.want, v)
+			}
+		})
+	}
+}
+
+func TestMeanAveragePrecision(t *testing.T) {
+	cases := []struct {
+		name        string
+		predictions map[string][]string
+		truth       map[string][]string
+		want        float64
+	}{
+		{
+			name: "all hit",
+			predictions: map[string][]string{
+				"q1": {"a", "b"},
+				"q2": {"x"},
+			},
+			truth: map[string][]string{
+				"q1": {"b", "a"},
+				"q2": {"x"},
+			},
+			want: 1,
+		},
+		{
+			name: "partial hit",
+			predictions: map[string][]string{
+				"q1": {"a", "c", "b"},
+				"q2": {"y", "x"},
+			},
+			truth: map[string][]string{
+				"q1": {"a", "b"},
+				"q2": {"x"},
+			},
+			want: ((1+2.0/3)/2 + 1.0/2) / 2,
+		},
+		{
+			name: "missed relevant label",
+			predictions: map[string][]string{
+				"q1": {"a"},
+				"q2": {"y"},
+			},
+			truth: map[string][]string{
+				"q1": {"a", "b"},
+				"q2": {"x"},
+			},
+			want: (1.0/2 + 0) / 2,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			predictions := newTestRecords(t, c.predictions, "q1", "q2")
+			truth := newTestRecords(t, c.truth, "q1", "q2")
+
+			v, err := meanAveragePrecision(predictions, truth)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if math.Abs(v-c.want) > 1e-9 {
+				t.Fatalf("expect %v, got %v", c.want, v)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package scorerimpl
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const splitPrivate = "private"
+
+// records is the labels of each sample. The labels are ranked for mAP,
+// and only the first one is used by the other metrics.
+// private is the samples of private split, it only makes sense for ground truth.
+type records struct {
+	ids     []string
+	labels  map[string][]string
+	private map[string]bool
+}
+
+func newRecords() *records {
+	return &records{
+		labels:  map[string][]string{},
+		private: map[string]bool{},
+	}
+}
+
+func (r *records) isEmpty() bool {
+	return len(r.ids) == 0
+}
+
+func (r *records) add(id string, labels []string) error {
+	if id == "" {
+		return errors.New("empty id")
+	}
+
+	if len(labels) == 0 {
+		return fmt.Errorf("no label of id: %s", id)
+	}
+
+	if _, ok := r.labels[id]; ok {
+		return fmt.Errorf("duplicate id: %s", id)
+	}
+
+	r.append(id, labels)
+
+	return nil
+}
+
+func (r *records) append(id string, labels []string) {
+	r.ids = append(r.ids, id)
+	r.labels[id] = labels
+}
+
+func (r *records) hasPrivateSplit() bool {
+	return len(r.private) > 0
+}
+
+func (r *records) setSplit(id, split string) {
+	if strings.ToLower(split) == splitPrivate {
+		r.private[id] = true
+	}
+}
+
+// split returns the public and private samples. The whole samples
+// are used as the split which has no samples.
+func (r *records) split() (public, private *records) {
+	public, private = newRecords(), newRecords()
+
+	for _, id := range r.ids {
+		if r.private[id] {
+			private.append(id, r.labels[id])
+		} else {
+			public.append(id, r.labels[id])
+		}
+	}
+
+	if public.isEmpty() {
+		public = r
+	}
+
+	if private.isEmpty() {
+		private = r
+	}
+
+	return
+}
+
+func (r *records) label(id string) string {
+	return r.labels[id][0]
+}
+
+// cover checks whether all the samples of ground truth are predicted.
+func (r *records) cover(truth *records) error {
+	for _, id := range truth.ids {
+		if _, ok := r.labels[id]; !ok {
+			return fmt.Errorf("missing prediction of id: %s", id)
+		}
+	}
+
+	return nil
+}
+
+// parseRecords parses the csv or json file.
+// The csv file has a header and two columns, id and label, the multiple labels are separated by space.
+// The json file is an array of object with id and label, the label can be an array.
+// The ground truth can have an extra column or field, split, which is public or private.
+func parseRecords(path string, data []byte) (*records, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return parseCSV(data)
+
+	case ".json":
+		return parseJSON(data)
+	}
+
+	// the ground truth has no extension, try both.
+	if r, err := parseCSV(data); err == nil {
+		return r, nil
+	}
+
+	return parseJSON(data)
+}
+
+func parseCSV(data []byte) (*records, error) {
+	// the number of columns is decided by the header.
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = 0
+	reader.TrimLeadingSpace = true
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, errors.New("no header")
+	}
+
+	if n := len(rows[0]); n != 2 && n != 3 {
+		return nil, errors.New("invalid columns")
+	}
+
+	r := newRecords()
+
+	for _, row := range rows[1:] {
+		id := strings.TrimSpace(row[0])
+
+		if err := r.add(id, strings.Fields(row[1])); err != nil {
+			return nil, err
+		}
+
+		if len(row) > 2 {
+			r.setSplit(id, strings.TrimSpace(row[2]))
+		}
+	}
+
+	return r, nil
+}
+
+type jsonRecord struct {
+	Id    interface{} `json:"id"`
+	Label interface{} `json:"label"`
+	Split string      `json:"split"`
+}
+
+func parseJSON(data []byte) (*records, error) {
+	var items []jsonRecord
+
+	// the numbers are decoded as json.Number to keep them as they are.
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&items); err != nil {
+		return nil, err
+	}
+
+	r := newRecords()
+
+	for i := range items {
+		item := &items[i]
+
+		id := toJSONString(item.Id)
+
+		if err := r.add(id, toJSONLabels(item.Label)); err != nil {
+			return nil, err
+		}
+
+		r.setSplit(id, item.Split)
+	}
+
+	return r, nil
+}
+
+func toJSONString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
+
+func toJSONLabels(v interface{}) []string {
+	if v == nil {
+		return nil
+	}
+
+	items, ok := v.([]interface{})
+	if !ok {
+		return []string{fmt.Sprint(v)}
+	}
+
+	r := make([]string, 0, len(items))
+	for i := range items {
+		if s := toJSONString(items[i]); s != "" {
+			r = append(r, s)
+		}
+	}
+
+	return r
+}
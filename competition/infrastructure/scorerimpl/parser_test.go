@@ -0,0 +1,157 @@
+package scorerimpl
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRecords(t *testing.T) {
+	cases := []struct {
+		name        string
+		path        string
+		data        string
+		wantIds     []string
+		wantLabels  map[string][]string
+		wantPrivate []string
+		wantErr     bool
+	}{
+		{
+			name:    "csv",
+			path:    "submission.csv",
+			data:    "id,label\n1,cat\n2, dog\n",
+			wantIds: []string{"1", "2"},
+			wantLabels: map[string][]string{
+				"1": {"cat"},
+				"2": {"dog"},
+			},
+		},
+		{
+			name:    "csv with multiple labels and split",
+			path:    "ground_truth.CSV",
+			data:    "id,label,split\n1,a b c,public\n2,d,Private\n",
+			wantIds: []string{"1", "2"},
+			wantLabels: map[string][]string{
+				"1": {"a", "b", "c"},
+				"2": {"d"},
+			},
+			wantPrivate: []string{"2"},
+		},
+		{
+			name:    "csv with invalid columns",
+			path:    "submission.csv",
+			data:    "id\n1\n",
+			wantErr: true,
+		},
+		{
+			name:    "csv without header",
+			path:    "submission.csv",
+			data:    "",
+			wantErr: true,
+		},
+		{
+			name:    "csv with duplicate id",
+			path:    "submission.csv",
+			data:    "id,label\n1,cat\n1,dog\n",
+			wantErr: true,
+		},
+		{
+			name:    "csv with empty label",
+			path:    "submission.csv",
+			data:    "id,label\n1,\n",
+			wantErr: true,
+		},
+		{
+			name:    "json",
+			path:    "submission.json",
+			data:    `[{"id": 1, "label": 1.50}, {"id": "2", "label": ["a", "b"]}]`,
+			wantIds: []string{"1", "2"},
+			wantLabels: map[string][]string{
+				"1": {"1.50"},
+				"2": {"a", "b"},
+			},
+		},
+		{
+			name:    "json with split",
+			path:    "ground_truth.json",
+			data:    `[{"id": "1", "label": "a", "split": "private"}, {"id": "2", "label": "b"}]`,
+			wantIds: []string{"1", "2"},
+			wantLabels: map[string][]string{
+				"1": {"a"},
+				"2": {"b"},
+			},
+			wantPrivate: []string{"1"},
+		},
+		{
+			name:    "json without id",
+			path:    "submission.json",
+			data:    `[{"label": "a"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "json without label",
+			path:    "submission.json",
+			data:    `[{"id": "1", "label": []}]`,
+			wantErr: true,
+		},
+		{
+			name:    "csv without extension",
+			path:    "ground_truth",
+			data:    "id,label\n1,a\n",
+			wantIds: []string{"1"},
+			wantLabels: map[string][]string{
+				"1": {"a"},
+			},
+		},
+		{
+			name:    "json without extension",
+			path:    "ground_truth",
+			data:    `[{"id": "1", "label": "a"}]`,
+			wantIds: []string{"1"},
+			wantLabels: map[string][]string{
+				"1": {"a"},
+			},
+		},
+		{
+			name:    "invalid data without extension",
+			path:    "ground_truth",
+			data:    "id",
+			wantErr: true,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			r, err := parseRecords(c.path, []byte(c.data))
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expect an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(r.ids, c.wantIds) {
+				t.Fatalf("expect ids %v, got %v", c.wantIds, r.ids)
+			}
+
+			if !reflect.DeepEqual(r.labels, c.wantLabels) {
+				t.Fatalf("expect labels %v, got %v", c.wantLabels, r.labels)
+			}
+
+			private := map[string]bool{}
+			for _, id := range c.wantPrivate {
+				private[id] = true
+			}
+
+			if !reflect.DeepEqual(r.private, private) {
+				t.Fatalf("expect private %v, got %v", private, r.private)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package scorerimpl
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/competition/domain"
+	"github.com/opensourceways/xihe-server/competition/domain/scorer"
+)
+
+type fileDownloader interface {
+	Download(path string) ([]byte, error)
+}
+
+// metric calculates the score of the predictions against the ground truth.
+type metric func(predictions, truth *records) (float64, error)
+
+// metrics is the builtin metrics, a new metric can be plugged in by adding it here.
+var metrics = map[string]metric{
+	"f1":       macroF1,
+	"map":      meanAveragePrecision,
+	"rmse":     rootMeanSquaredError,
+	"accuracy": accuracy,
+}
+
+func NewScorer(d fileDownloader) scorer.Scorer {
+	return &scorerImpl{d}
+}
+
+type scorerImpl struct {
+	downloader fileDownloader
+}
+
+func (impl *scorerImpl) LoadGroundTruth(path string) (scorer.GroundTruth, error) {
+	truth, err := impl.load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if truth.isEmpty() {
+		return nil, errors.New("empty ground truth")
+	}
+
+	public, private := truth.split()
+
+	return &groundTruth{
+		scorerImpl: impl,
+		truth:      truth,
+		public:     public,
+		private:    private,
+	}, nil
+}
+
+func (impl *scorerImpl) load(path string) (*records, error) {
+	data, err := impl.downloader.Download(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseRecords(path, data)
+}
+
+type groundTruth struct {
+	*scorerImpl
+
+	truth   *records
+	public  *records
+	private *records
+}
+
+func (t *groundTruth) Score(m domain.ScoreMetric, submission string) (
+	r scorer.Result, err error,
+) {
+	f, ok := metrics[m.ScoreMetric()]
+	if !ok {
+		err = errors.New("unsupported metric")
+
+		return
+	}
+
+	predictions, err := t.load(submission)
+	if err != nil {
+		err = scorer.NewErrorInvalidSubmission(err)
+
+		return
+	}
+
+	if err = predictions.cover(t.truth); err != nil {
+		err = scorer.NewErrorInvalidSubmission(err)
+
+		return
+	}
+
+	v, err := f(predictions, t.public)
+	if err != nil {
+		err = scorer.NewErrorInvalidSubmission(err)
+
+		return
+	}
+	r.Public = float32(v)
+
+	if !t.truth.hasPrivateSplit() {
+		return
+	}
+
+	if v, err = f(predictions, t.private); err != nil {
+		err = scorer.NewErrorInvalidSubmission(err)
+
+		return
+	}
+	r.Private = float32(v)
+	r.HasPrivate = true
+
+	return
+}
@@ -0,0 +1,12 @@
+package watchimpl
+
+import (
+	"github.com/opensourceways/xihe-server/common/infrastructure/ticker"
+)
+
+func NewWatcher(cfg *Config, handle func() error) *ticker.Ticker {
+	return ticker.NewTicker(
+		"score competition submissions", cfg.Interval,
+		func(int64) error { return handle() },
+	)
+}
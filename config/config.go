@@ -12,6 +12,7 @@ import (
 	asyncrepoimpl "github.com/opensourceways/xihe-server/async-server/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/bigmodel/infrastructure/bigmodels"
 	cloudrepoimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
 	"github.com/opensourceways/xihe-server/common/infrastructure/redis"
 	"github.com/opensourceways/xihe-server/controller"
@@ -21,6 +22,7 @@ import (
 	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/finetuneimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/gitlab"
+	"github.com/opensourceways/xihe-server/infrastructure/inferenceimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/messages"
 	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
 )
@@ -57,20 +59,22 @@ type Config struct {
 	MaxRetry        int `json:"max_retry"`
 	ActivityKeepNum int `json:"activity_keep_num"`
 
-	Competition competitionimpl.Config `json:"competition"  required:"true"`
-	Challenge   challengeimpl.Config   `json:"challenge"    required:"true"`
-	Training    trainingimpl.Config    `json:"training"     required:"true"`
-	Finetune    finetuneimpl.Config    `json:"finetune"     required:"true"`
-	BigModel    bigmodels.Config       `json:"bigmodel"     required:"true"`
-	Authing     authingimpl.Config     `json:"authing"      required:"true"`
-	Mongodb     Mongodb                `json:"mongodb"      required:"true"`
-	Postgresql  PostgresqlConfig       `json:"postgresql"   required:"true"`
-	Redis       Redis                  `json:"redis"        required:"true"`
-	Gitlab      gitlab.Config          `json:"gitlab"       required:"true"`
-	Domain      domain.Config          `json:"domain"       required:"true"`
-	App         app.Config             `json:"app"          required:"true"`
-	API         controller.APIConfig   `json:"api"          required:"true"`
-	MQ          MQ                     `json:"mq"           required:"true"`
+	Competition competitionimpl.Config      `json:"competition"  required:"true"`
+	Challenge   challengeimpl.Config        `json:"challenge"    required:"true"`
+	Training    trainingimpl.Config         `json:"training"     required:"true"`
+	Workspace   workspaceimpl.Config        `json:"cloud_workspace" required:"true"`
+	Finetune    finetuneimpl.Config         `json:"finetune"     required:"true"`
+	Predict     inferenceimpl.PredictConfig `json:"inference_predict"`
+	BigModel    bigmodels.Config            `json:"bigmodel"     required:"true"`
+	Authing     authingimpl.Config          `json:"authing"      required:"true"`
+	Mongodb     Mongodb                     `json:"mongodb"      required:"true"`
+	Postgresql  PostgresqlConfig            `json:"postgresql"   required:"true"`
+	Redis       Redis                       `json:"redis"        required:"true"`
+	Gitlab      gitlab.Config               `json:"gitlab"       required:"true"`
+	Domain      domain.Config               `json:"domain"       required:"true"`
+	App         app.Config                  `json:"app"          required:"true"`
+	API         controller.APIConfig        `json:"api"          required:"true"`
+	MQ          MQ                          `json:"mq"           required:"true"`
 }
 
 func (cfg *Config) GetMQConfig() mq.MQConfig {
@@ -84,7 +88,9 @@ func (cfg *Config) configItems() []interface{} {
 		&cfg.Competition,
 		&cfg.Challenge,
 		&cfg.Training,
+		&cfg.Workspace,
 		&cfg.Finetune,
+		&cfg.Predict,
 		&cfg.BigModel,
 		&cfg.Authing,
 		&cfg.Domain,
@@ -164,20 +170,33 @@ type MongodbCollections struct {
 	Project           string `json:"project"                required:"true"`
 	Activity          string `json:"activity"               required:"true"`
 	Training          string `json:"training"               required:"true"`
+	Sweep             string `json:"sweep"                  required:"true"`
+	TrainingMetric    string `json:"training_metric"        required:"true"`
+	ModelLineage      string `json:"model_lineage"          required:"true"`
+	ProjectFork       string `json:"project_fork"           required:"true"`
+	TrainingSchedule  string `json:"training_schedule"      required:"true"`
 	Finetune          string `json:"finetune"               required:"true"`
 	Evaluate          string `json:"evaluate"               required:"true"`
+	EvaluateReport    string `json:"evaluate_report"        required:"true"`
 	Inference         string `json:"inference"              required:"true"`
+	InferenceDeploy   string `json:"inference_deployment"   required:"true"`
+	InferenceUsage    string `json:"inference_usage"        required:"true"`
 	AIQuestion        string `json:"aiquestion"             required:"true"`
 	Competition       string `json:"competition"            required:"true"`
 	QuestionPool      string `json:"question_pool"          required:"true"`
 	WuKongPicture     string `json:"wukong_picture"         required:"true"`
 	CompetitionWork   string `json:"competition_work"       required:"true"`
 	CompetitionPlayer string `json:"competition_player"     required:"true"`
+	CompetitionAudit  string `json:"competition_audit_log"  required:"true"`
+	CompetitionInvite string `json:"competition_invitation" required:"true"`
 	Course            string `json:"course"                 required:"true"`
 	CoursePlayer      string `json:"course_player"          required:"true"`
 	CourseWork        string `json:"course_work"            required:"true"`
 	CourseRecord      string `json:"course_record"          required:"true"`
 	CloudConf         string `json:"cloud_conf"             required:"true"`
+	Organization      string `json:"organization"           required:"true"`
+	Collaborator      string `json:"collaborator"           required:"true"`
+	AccessToken       string `json:"access_token"           required:"true"`
 }
 
 type MQ struct {
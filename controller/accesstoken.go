@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	userapp "github.com/opensourceways/xihe-server/user/app"
+	userdomain "github.com/opensourceways/xihe-server/user/domain"
+	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
+	userorgcli "github.com/opensourceways/xihe-server/user/infrastructure/orgcli"
+)
+
+const (
+	headerAuthorization   = "Authorization"
+	bearerPrefix          = "Bearer "
+	keyAccessTokenPayload = "access-token-payload"
+)
+
+// AccessTokenChecker authenticates the requests which carry
+// the personal access token instead of the cookie token.
+type AccessTokenChecker struct {
+	s  userapp.AccessTokenService
+	us userapp.UserService
+}
+
+func NewAccessTokenChecker(
+	token userrepo.AccessToken,
+	user userrepo.User,
+	ps platform.User,
+	sender message.Sender,
+	org orgapp.OrgService,
+) *AccessTokenChecker {
+	return &AccessTokenChecker{
+		s:  userapp.NewAccessTokenService(token),
+		us: userapp.NewUserService(user, ps, sender, encryptHelperToken, userorgcli.NewOrgCli(org)),
+	}
+}
+
+// allowAccessTokenMiddleware lets the scripts access the api with
+// the personal access token which has the scope. The requests without
+// it will fall back to the cookie token.
+// The requests with it will be rejected if the checker is not set.
+func allowAccessTokenMiddleware(
+	ctl *baseController, checker *AccessTokenChecker, scope userdomain.AccessTokenScope,
+) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		token := ctl.getAccessToken(ctx)
+		if token == "" {
+			ctx.Next()
+
+			return
+		}
+
+		if checker == nil {
+			ctx.JSON(
+				http.StatusUnauthorized,
+				newResponseCodeMsg(errorInvalidToken, "access token is not supported"),
+			)
+			ctx.Abort()
+
+			return
+		}
+
+		pl, ok := checker.check(ctl, ctx, token, scope)
+		if !ok {
+			ctx.Abort()
+
+			return
+		}
+
+		ctx.Set(keyAccessTokenPayload, pl)
+
+		ctx.Next()
+	}
+}
+
+func (ctl baseController) getAccessToken(ctx *gin.Context) string {
+	v := ctx.GetHeader(headerAuthorization)
+	if !strings.HasPrefix(v, bearerPrefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(v, bearerPrefix))
+}
+
+func (checker *AccessTokenChecker) check(
+	ctl *baseController, ctx *gin.Context, token string, scope userdomain.AccessTokenScope,
+) (pl oldUserTokenPayload, ok bool) {
+	owner, code, err := checker.s.Check(token, scope)
+	if err != nil {
+		if code == "" {
+			ctl.sendRespWithInternalError(ctx, newResponseError(err))
+		} else {
+			ctx.JSON(http.StatusUnauthorized, newResponseCodeError(code, err))
+		}
+
+		return
+	}
+
+	u, err := checker.us.GetByAccount(owner)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	pl = oldUserTokenPayload{
+		Account:                 u.Account,
+		Email:                   u.Email,
+		PlatformToken:           u.Platform.Token,
+		PlatformUserNamespaceId: u.Platform.NamespaceId,
+	}
+	ok = true
+
+	return
+}
+
+//	@Summary		CreateAccessToken
+//	@Description	create personal access token
+//	@Tags			User
+//	@Param			body	body	accessTokenCreateRequest	true	"body of creating access token"
+//	@Accept			json
+//	@Success		201	{object}			userapp.AccessTokenCreateDTO
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/user/tokens [post]
+func (ctl *UserController) CreateAccessToken(ctx *gin.Context) {
+	req := accessTokenCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd, err := req.toCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, code, err := ctl.token.Create(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, v)
+	}
+}
+
+//	@Summary		ListAccessTokens
+//	@Description	list personal access tokens
+//	@Tags			User
+//	@Accept			json
+//	@Success		200	{object}		[]userapp.AccessTokenDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/user/tokens [get]
+func (ctl *UserController) ListAccessTokens(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.token.List(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		DeleteAccessToken
+//	@Description	revoke personal access token
+//	@Tags			User
+//	@Param			id	path	string	true	"id of access token"
+//	@Accept			json
+//	@Success		204
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/user/tokens/{id} [delete]
+func (ctl *UserController) DeleteAccessToken(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if err := ctl.token.Delete(pl.DomainAccount(), ctx.Param("id")); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
@@ -211,6 +211,13 @@ func (ctl baseController) checkUserApiTokenBase(
 ) (
 	pl oldUserTokenPayload, visitor bool, ok bool,
 ) {
+	// the request has been authenticated by the personal access token.
+	if v, exist := ctx.Get(keyAccessTokenPayload); exist {
+		if pl, ok = v.(oldUserTokenPayload); ok {
+			return
+		}
+	}
+
 	token, err := ctl.getCookieToken(ctx)
 	if err != nil {
 		return
@@ -8,24 +8,51 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/opensourceways/xihe-server/cloud/app"
+	"github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
 func AddRouterForCloudController(
 	rg *gin.RouterGroup,
 	s app.CloudService,
+	credit app.CreditService,
+	workspace app.WorkspaceService,
+	project repository.Project,
 ) {
 	ctl := CloudController{
-		s: s,
+		s:         s,
+		credit:    credit,
+		workspace: workspace,
+		project:   project,
 	}
 
 	rg.GET("/v1/cloud", ctl.List)
+	rg.GET("/v1/cloud/:cid", ctl.GetHttp)
+	rg.POST("/v1/cloud/pod/:pid/extend", ctl.ExtendPod)
+	rg.DELETE("/v1/cloud/pod/:pid", ctl.ReleasePod)
+
+	rg.POST("/v1/cloud/:cid/waitlist", ctl.JoinWaitlist)
+	rg.GET("/v1/cloud/:cid/waitlist", ctl.GetWaiting)
+	rg.DELETE("/v1/cloud/:cid/waitlist", ctl.LeaveWaitlist)
+
+	rg.GET("/v1/cloud/workspace", ctl.GetWorkspace)
+	rg.POST("/v1/cloud/workspace/snapshot", ctl.SnapshotWorkspace)
+
+	rg.GET("/v1/cloud/credit", ctl.GetCredit)
+	rg.GET("/v1/cloud/credit/transactions", ctl.ListCreditTransactions)
+	rg.POST(
+		"/v1/cloud/credit/:account/grant",
+		checkAdminMiddleware(&ctl.baseController), ctl.GrantCredit,
+	)
 }
 
 type CloudController struct {
 	baseController
 
-	s app.CloudService
+	s         app.CloudService
+	credit    app.CreditService
+	workspace app.WorkspaceService
+	project   repository.Project
 }
 
 //	@Summary		List
@@ -201,3 +228,78 @@ func (ctl *CloudController) GetHttp(ctx *gin.Context) {
 		ctl.sendRespOfGet(ctx, dto)
 	}
 }
+
+//	@Summary		ExtendPod
+//	@Description	extend the expiry of running cloud pod asynchronously, and the
+//	@Description	extended hours are charged after the pod instance is extended.
+//	@Description	The container manager can't extend the pod instance for now,
+//	@Description	so the expiry will not change until it supports.
+//	@Tags			Cloud
+//	@Param			pid		path	string					true	"cloud pod id"
+//	@Param			body	body	cloudPodExtendRequest	true	"body of extending pod"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/pod/{pid}/extend [post]
+func (ctl *CloudController) ExtendPod(ctx *gin.Context) {
+	req := cloudPodExtendRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := req.toCmd(pl.DomainAccount(), ctx.Param("pid"))
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	if code, err := ctl.s.ExtendPod(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		utils.DoLog("", pl.Account, "extend jupyter", cmd.PodId, "success")
+
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+//	@Summary		ReleasePod
+//	@Description	release the running cloud pod asynchronously. The pod keeps
+//	@Description	occupying the resource until its instance is released, and then
+//	@Description	the credit of the whole hours which it has not used will be refunded.
+//	@Description	The container manager can't release the pod instance for now,
+//	@Description	so the pod will run until it expires and nothing will be refunded.
+//	@Tags			Cloud
+//	@Param			pid	path	string	true	"cloud pod id"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/pod/{pid} [delete]
+func (ctl *CloudController) ReleasePod(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.RelasePodCmd{
+		User:  pl.DomainAccount(),
+		PodId: ctx.Param("pid"),
+	}
+
+	if code, err := ctl.s.ReleasePod(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		utils.DoLog("", pl.Account, "release jupyter", cmd.PodId, "success")
+
+		ctl.sendRespOfDelete(ctx)
+	}
+}
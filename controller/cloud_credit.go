@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/cloud/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		GetCredit
+//	@Description	get the credit balance of user
+//	@Tags			Cloud
+//	@Accept			json
+//	@Success		200	{object}		app.CreditBalanceDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/cloud/credit [get]
+func (ctl *CloudController) GetCredit(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.credit.GetBalance(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		ListCreditTransactions
+//	@Description	list the credit transactions of user
+//	@Tags			Cloud
+//	@Param			count_per_page	query	int	false	"count per page"
+//	@Param			page_num		query	int	false	"page num which starts from 1"
+//	@Accept			json
+//	@Success		200	{object}			app.CreditTransactionsDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/credit/transactions [get]
+func (ctl *CloudController) ListCreditTransactions(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.CreditTransactionListCmd{
+		User: pl.DomainAccount(),
+	}
+
+	var err error
+
+	if v := ctl.getQueryParameter(ctx, "count_per_page"); v != "" {
+		if cmd.CountPerPage, err = strconv.Atoi(v); err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+	}
+
+	if v := ctl.getQueryParameter(ctx, "page_num"); v != "" {
+		if cmd.PageNum, err = strconv.Atoi(v); err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+	}
+
+	if v, err := ctl.credit.ListTransactions(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		GrantCredit
+//	@Description	grant credit to user
+//	@Tags			Cloud
+//	@Param			account	path	string					true	"account of user"
+//	@Param			body	body	cloudCreditGrantRequest	true	"body of granting credit"
+//	@Accept			json
+//	@Success		201	{object}			app.CreditBalanceDTO
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/credit/{account}/grant [post]
+func (ctl *CloudController) GrantCredit(ctx *gin.Context) {
+	req := cloudCreditGrantRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	user, err := domain.NewAccount(ctx.Param("account"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cmd := req.toCmd(user)
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, err := ctl.credit.Grant(&cmd)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	// the administrator has been authenticated by the middleware.
+	pl, _, _ := ctl.checkUserApiTokenNoRefresh(ctx, false)
+
+	utils.DoLog(
+		"", pl.Account, "grant credit",
+		fmt.Sprintf("user: %s, amount: %d", user.Account(), cmd.Amount), "success",
+	)
+
+	ctl.sendRespOfPost(ctx, v)
+}
@@ -15,3 +15,45 @@ func (req *cloudSubscribeRequest) toCmd(user domain.Account) cloudapp.SubscribeC
 		CloudId: req.CloudId,
 	}
 }
+
+type cloudPodExtendRequest struct {
+	Hours int `json:"hours"`
+}
+
+func (req *cloudPodExtendRequest) toCmd(user domain.Account, pid string) cloudapp.ExtendPodCmd {
+	return cloudapp.ExtendPodCmd{
+		User:  user,
+		PodId: pid,
+		Hours: req.Hours,
+	}
+}
+
+type cloudCreditGrantRequest struct {
+	Amount int64  `json:"amount"`
+	Remark string `json:"remark"`
+}
+
+func (req *cloudCreditGrantRequest) toCmd(user domain.Account) cloudapp.CreditGrantCmd {
+	return cloudapp.CreditGrantCmd{
+		User:   user,
+		Amount: req.Amount,
+		Remark: req.Remark,
+	}
+}
+
+type cloudWorkspaceSnapshotRequest struct {
+	ProjectName string `json:"project_name"`
+	Dir         string `json:"dir"`
+}
+
+func (req *cloudWorkspaceSnapshotRequest) toCmd() (
+	name domain.ResourceName, dir domain.Directory, err error,
+) {
+	if name, err = domain.NewResourceName(req.ProjectName); err != nil {
+		return
+	}
+
+	dir, err = domain.NewDirectory(req.Dir)
+
+	return
+}
@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/cloud/app"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		JoinWaitlist
+//	@Description	wait for the idle resource of cloud
+//	@Tags			Cloud
+//	@Param			cid	path	string	true	"cloud config id"
+//	@Accept			json
+//	@Success		201	{object}			app.WaitingDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/{cid}/waitlist [post]
+func (ctl *CloudController) JoinWaitlist(ctx *gin.Context) {
+	pl, cmd, ok := ctl.waitlistCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.s.JoinWaitlist(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		utils.DoLog("", pl.Account, "join cloud waitlist", cmd.CloudId, "success")
+
+		ctl.sendRespOfPost(ctx, v)
+	}
+}
+
+//	@Summary		GetWaiting
+//	@Description	get the position of user in the waitlist of cloud
+//	@Tags			Cloud
+//	@Param			cid	path	string	true	"cloud config id"
+//	@Accept			json
+//	@Success		200	{object}			app.WaitingDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/{cid}/waitlist [get]
+func (ctl *CloudController) GetWaiting(ctx *gin.Context) {
+	_, cmd, ok := ctl.waitlistCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.s.GetWaiting(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		LeaveWaitlist
+//	@Description	leave the waitlist of cloud
+//	@Tags			Cloud
+//	@Param			cid	path	string	true	"cloud config id"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/{cid}/waitlist [delete]
+func (ctl *CloudController) LeaveWaitlist(ctx *gin.Context) {
+	pl, cmd, ok := ctl.waitlistCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if err := ctl.s.LeaveWaitlist(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		utils.DoLog("", pl.Account, "leave cloud waitlist", cmd.CloudId, "success")
+
+		ctl.sendRespOfDelete(ctx)
+	}
+}
+
+func (ctl *CloudController) waitlistCmd(ctx *gin.Context) (
+	pl oldUserTokenPayload, cmd app.SubscribeCloudCmd, ok bool,
+) {
+	if pl, _, ok = ctl.checkUserApiToken(ctx, false); !ok {
+		return
+	}
+
+	cmd = app.SubscribeCloudCmd{
+		User:    pl.DomainAccount(),
+		CloudId: ctx.Param("cid"),
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		ok = false
+	}
+
+	return
+}
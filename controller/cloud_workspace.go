@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/cloud/app"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		GetWorkspace
+//	@Description	get the persistent workspace of user
+//	@Tags			Cloud
+//	@Accept			json
+//	@Success		200	{object}		app.WorkspaceDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/cloud/workspace [get]
+func (ctl *CloudController) GetWorkspace(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.workspace.Get(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		SnapshotWorkspace
+//	@Description	save the files of workspace to the project of user
+//	@Tags			Cloud
+//	@Param			body	body	cloudWorkspaceSnapshotRequest	true	"body of saving workspace"
+//	@Accept			json
+//	@Success		201	{object}			app.WorkspaceSnapshotDTO
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/cloud/workspace/snapshot [post]
+func (ctl *CloudController) SnapshotWorkspace(ctx *gin.Context) {
+	req := cloudWorkspaceSnapshotRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	name, dir, err := req.toCmd()
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	p, err := ctl.project.GetSummaryByName(pl.DomainAccount(), name)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	cmd := app.WorkspaceSnapshotCmd{
+		User:     pl.PlatformUserInfo(),
+		RepoId:   p.RepoId,
+		RepoName: p.Name,
+		Dir:      dir,
+	}
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, code, err := ctl.workspace.Snapshot(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		utils.DoLog("", pl.Account, "snapshot workspace", p.Id, "success")
+
+		ctl.sendRespOfPost(ctx, v)
+	}
+}
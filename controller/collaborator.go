@@ -0,0 +1,272 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+)
+
+func AddRouterForCollaboratorController(
+	rg *gin.RouterGroup,
+	model repository.Model,
+	project repository.Project,
+	dataset repository.Dataset,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
+) {
+	ctl := CollaboratorController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+		s:       collaborator,
+		model:   model,
+		project: project,
+		dataset: dataset,
+	}
+
+	rg.GET("/v1/collaborator/:type/:owner/:name", ctl.List)
+	rg.POST("/v1/collaborator/:type/:owner/:name", checkUserEmailMiddleware(&ctl.baseController), ctl.Add)
+	rg.PUT("/v1/collaborator/:type/:owner/:name", checkUserEmailMiddleware(&ctl.baseController), ctl.Update)
+	rg.DELETE("/v1/collaborator/:type/:owner/:name/:account", ctl.Remove)
+}
+
+type CollaboratorController struct {
+	baseController
+
+	resourcePermission
+
+	s       collaboratorapp.CollaboratorService
+	model   repository.Model
+	project repository.Project
+	dataset repository.Dataset
+}
+
+//	@Summary		List
+//	@Description	list collaborators of resource
+//	@Tags			Collaborator
+//	@Param			type	path	string	true	"resource type: project, model, dataset"
+//	@Param			owner	path	string	true	"owner of resource"
+//	@Param			name	path	string	true	"name of resource"
+//	@Accept			json
+//	@Success		200	{object}			[]collaboratorapp.CollaboratorDTO
+//	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/collaborator/{type}/{owner}/{name} [get]
+func (ctl *CollaboratorController) List(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	repo, ok := ctl.getResource(ctx)
+	if !ok {
+		return
+	}
+
+	obj := repo.resourceObject()
+	if !ctl.checkResource(ctx, &pl, obj, false) {
+		return
+	}
+
+	if v, err := ctl.s.List(obj); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		Add
+//	@Description	add collaborator to private resource
+//	@Tags			Collaborator
+//	@Param			type	path	string				true	"resource type: project, model, dataset"
+//	@Param			owner	path	string				true	"owner of resource"
+//	@Param			name	path	string				true	"name of resource"
+//	@Param			body	body	collaboratorRequest	true	"body of collaborator"
+//	@Accept			json
+//	@Success		201
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/collaborator/{type}/{owner}/{name} [post]
+func (ctl *CollaboratorController) Add(ctx *gin.Context) {
+	cmd, ok := ctl.getCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.s.Add(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+//	@Summary		Update
+//	@Description	update the permission of collaborator
+//	@Tags			Collaborator
+//	@Param			type	path	string				true	"resource type: project, model, dataset"
+//	@Param			owner	path	string				true	"owner of resource"
+//	@Param			name	path	string				true	"name of resource"
+//	@Param			body	body	collaboratorRequest	true	"body of collaborator"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/collaborator/{type}/{owner}/{name} [put]
+func (ctl *CollaboratorController) Update(ctx *gin.Context) {
+	cmd, ok := ctl.getCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.s.Update(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+//	@Summary		Remove
+//	@Description	remove collaborator of resource
+//	@Tags			Collaborator
+//	@Param			type	path	string	true	"resource type: project, model, dataset"
+//	@Param			owner	path	string	true	"owner of resource"
+//	@Param			name	path	string	true	"name of resource"
+//	@Param			account	path	string	true	"account of collaborator"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/collaborator/{type}/{owner}/{name}/{account} [delete]
+func (ctl *CollaboratorController) Remove(ctx *gin.Context) {
+	account, err := domain.NewAccount(ctx.Param("account"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	repo, ok := ctl.getResourceForManaging(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	cmd := collaboratorapp.CollaboratorRemoveCmd{
+		Resource:     *repo.resourceObject(),
+		RepoId:       repo.RepoId,
+		Collaborator: account,
+	}
+
+	if code, err := ctl.s.Remove(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
+
+func (ctl *CollaboratorController) getCmd(ctx *gin.Context) (
+	cmd collaboratorapp.CollaboratorCmd, ok bool,
+) {
+	req := collaboratorRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	repo, ok := ctl.getResourceForManaging(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	cmd, err := req.toCmd(&repo)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		ok = false
+	}
+
+	return
+}
+
+func (ctl *CollaboratorController) getResourceForManaging(
+	ctx *gin.Context, pl *oldUserTokenPayload,
+) (repo resourceSummary, ok bool) {
+	if repo, ok = ctl.getResource(ctx); !ok {
+		return
+	}
+
+	if ok = ctl.canManage(pl, repo.Owner); !ok {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
+			errorNotAllowed, "not allowed",
+		))
+	}
+
+	return
+}
+
+func (ctl *CollaboratorController) getResource(ctx *gin.Context) (
+	repo resourceSummary, ok bool,
+) {
+	owner, err := domain.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	name, err := domain.NewResourceName(ctx.Param("name"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if repo.rt, err = domain.NewResourceType(ctx.Param("type")); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	switch repo.rt.ResourceType() {
+	case domain.ResourceTypeModel.ResourceType():
+		repo.ResourceSummary, err = ctl.model.GetSummaryByName(owner, name)
+
+	case domain.ResourceTypeProject.ResourceType():
+		repo.ResourceSummary, err = ctl.project.GetSummaryByName(owner, name)
+
+	case domain.ResourceTypeDataset.ResourceType():
+		repo.ResourceSummary, err = ctl.dataset.GetSummaryByName(owner, name)
+	}
+
+	if err != nil {
+		if repository.IsErrorResourceNotExists(err) {
+			ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
+				errorResourceNotExists, "resource not exists",
+			))
+		} else {
+			ctl.sendRespWithInternalError(ctx, newResponseError(err))
+		}
+
+		return
+	}
+
+	ok = true
+
+	return
+}
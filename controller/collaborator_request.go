@@ -0,0 +1,31 @@
+package controller
+
+import (
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
+	collaboratordomain "github.com/opensourceways/xihe-server/collaborator/domain"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type collaboratorRequest struct {
+	Account    string `json:"account"`
+	Permission string `json:"permission"`
+}
+
+func (req *collaboratorRequest) toCmd(repo *resourceSummary) (
+	cmd collaboratorapp.CollaboratorCmd, err error,
+) {
+	if cmd.Collaborator, err = domain.NewAccount(req.Account); err != nil {
+		return
+	}
+
+	if cmd.Permission, err = collaboratordomain.NewPermission(req.Permission); err != nil {
+		return
+	}
+
+	cmd.Resource = *repo.resourceObject()
+	cmd.RepoId = repo.RepoId
+
+	err = cmd.Validate()
+
+	return
+}
@@ -10,15 +10,19 @@ import (
 	"github.com/opensourceways/xihe-server/competition/domain"
 	types "github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	userdomain "github.com/opensourceways/xihe-server/user/domain"
 )
 
 func AddRouterForCompetitionController(
 	rg *gin.RouterGroup,
 	s app.CompetitionService,
+	admin app.CompetitionAdminService,
 	project repository.Project,
+	checker *AccessTokenChecker,
 ) {
 	ctl := CompetitionController{
 		s:       s,
+		admin:   admin,
 		project: project,
 	}
 
@@ -28,7 +32,12 @@ func AddRouterForCompetitionController(
 	rg.GET("/v1/competition/:id/ranking", ctl.GetRankingList)
 	rg.GET("/v1/competition/:id/submissions", ctl.GetSubmissions)
 	rg.POST("/v1/competition/:id/team", ctl.CreateTeam)
-	rg.POST("/v1/competition/:id/submissions", ctl.Submit)
+	rg.POST(
+		"/v1/competition/:id/submissions",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeCompetition),
+		ctl.Submit,
+	)
+	rg.PUT("/v1/competition/:id/submissions/:sid", ctl.SelectSubmission)
 	rg.POST("/v1/competition/:id/competitor", ctl.Apply)
 	rg.PUT("/v1/competition/:id/team", ctl.JoinTeam)
 	rg.PUT("/v1/competition/:id/realted_project", checkUserEmailMiddleware(&ctl.baseController), ctl.AddRelatedProject)
@@ -37,12 +46,26 @@ func AddRouterForCompetitionController(
 	rg.PUT("/v1/competition/:id/team/action/quit", ctl.QuitTeam)
 	rg.PUT("/v1/competition/:id/team/action/delete_member", ctl.DeleteMember)
 	rg.PUT("/v1/competition/:id/team/action/dissolve", ctl.Dissolve)
+
+	rg.GET("/v1/competition/:id/team/invitations", ctl.ListInvitations)
+	rg.POST("/v1/competition/:id/team/invitations", ctl.Invite)
+	rg.POST("/v1/competition/:id/team/requests", ctl.RequestToJoin)
+	rg.PUT("/v1/competition/:id/team/invitations/:iid/action/accept", ctl.AcceptInvitation)
+	rg.PUT("/v1/competition/:id/team/invitations/:iid/action/decline", ctl.DeclineInvitation)
+
+	rg.POST("/v1/competition", checkAdminMiddleware(&ctl.baseController), ctl.Create)
+	rg.PUT("/v1/competition/:id", ctl.Update)
+	rg.PUT("/v1/competition/:id/status", ctl.ChangeStatus)
+	rg.POST("/v1/competition/:id/finalists", ctl.PromoteFinalists)
+	rg.PUT("/v1/competition/:id/ground_truth", ctl.UploadGroundTruth)
+	rg.GET("/v1/competition/:id/audit_logs", ctl.ListAuditLogs)
 }
 
 type CompetitionController struct {
 	baseController
 
 	s       app.CompetitionService
+	admin   app.CompetitionAdminService
 	project repository.Project
 }
 
@@ -260,7 +283,8 @@ func (ctl *CompetitionController) GetMyTeam(ctx *gin.Context) {
 }
 
 //	@Summary		GetRankingList
-//	@Description	get ranking list of competition
+//	@Description	get ranking list of competition. The private ranking is revealed when the competition
+//	@Description	is over, and only the submissions scored on a private split of ground truth are ranked in it.
 //	@Tags			Competition
 //	@Param			id	path	string	true	"competition id"
 //	@Accept			json
@@ -353,6 +377,39 @@ func (ctl *CompetitionController) Submit(ctx *gin.Context) {
 	}
 }
 
+//	@Summary		SelectSubmission
+//	@Description	choose the submission to count for the private ranking or not
+//	@Tags			Competition
+//	@Param			id		path	string						true	"competition id"
+//	@Param			sid		path	string						true	"submission id"
+//	@Param			body	body	cc.SelectSubmissionRequest	true	"body of selecting submission"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/submissions/{sid} [put]
+func (ctl *CompetitionController) SelectSubmission(ctx *gin.Context) {
+	req := cc.SelectSubmissionRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := req.ToCmd(pl.DomainAccount(), ctx.Param("id"), ctx.Param("sid"))
+
+	if code, err := ctl.s.SelectSubmission(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
 //	@Summary		AddRelatedProject
 //	@Description	add related project
 //	@Tags			Competition
@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/competition/app"
+	cc "github.com/opensourceways/xihe-server/competition/controller"
+	"github.com/opensourceways/xihe-server/competition/domain"
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		Create
+//	@Description	create a competition which is managed by the organizers.
+//	@Description	it is only for the administrators.
+//	@Tags			Competition
+//	@Param			body	body	cc.CompetitionCreateRequest	true	"body of creating competition"
+//	@Accept			json
+//	@Success		201	{string}			string	"competition id"
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition [post]
+func (ctl *CompetitionController) Create(ctx *gin.Context) {
+	req := cc.CompetitionCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	operator := ctl.operator(ctx)
+
+	cmd, err := req.ToCmd(operator)
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	cid, code, err := ctl.admin.Create(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", operator.Account(), "create competition", "id: "+cid, "success")
+
+	ctl.sendRespOfPost(ctx, cid)
+}
+
+//	@Summary		Update
+//	@Description	update the competition, which is only for its organizers.
+//	@Description	the metric and order can't be changed after the competition starts.
+//	@Tags			Competition
+//	@Param			id		path	string						true	"competition id"
+//	@Param			body	body	cc.CompetitionUpdateRequest	true	"body of updating competition"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id} [put]
+func (ctl *CompetitionController) Update(ctx *gin.Context) {
+	req := cc.CompetitionUpdateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	operator := pl.DomainAccount()
+
+	cmd, err := req.ToCmd(operator, ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.admin.Update(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", operator.Account(), "update competition", "id: "+cmd.CompetitionId, "success")
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+//	@Summary		ChangeStatus
+//	@Description	move the competition from preparing to in-progress, or from in-progress to over.
+//	@Description	it is only for the organizers of competition.
+//	@Tags			Competition
+//	@Param			id		path	string								true	"competition id"
+//	@Param			body	body	cc.CompetitionStatusChangeRequest	true	"body of changing status"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/status [put]
+func (ctl *CompetitionController) ChangeStatus(ctx *gin.Context) {
+	req := cc.CompetitionStatusChangeRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	operator := pl.DomainAccount()
+
+	cmd, err := req.ToCmd(operator, ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.admin.ChangeStatus(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog(
+		"", operator.Account(), "change competition status",
+		"id: "+cmd.CompetitionId+", status: "+req.Status, "success",
+	)
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+//	@Summary		PromoteFinalists
+//	@Description	promote the competitors to be finalists, which is only for the organizers of competition
+//	@Tags			Competition
+//	@Param			id		path	string						true	"competition id"
+//	@Param			body	body	cc.PromoteFinalistsRequest	true	"body of promoting finalists"
+//	@Accept			json
+//	@Success		201
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/finalists [post]
+func (ctl *CompetitionController) PromoteFinalists(ctx *gin.Context) {
+	req := cc.PromoteFinalistsRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	operator := pl.DomainAccount()
+
+	cmd, err := req.ToCmd(operator, ctx.Param("id"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.admin.PromoteFinalists(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog(
+		"", operator.Account(), "promote competition finalists",
+		"id: "+cmd.CompetitionId+", competitors: "+strings.Join(req.Competitors, ","),
+		"success",
+	)
+
+	ctl.sendRespOfPost(ctx, "success")
+}
+
+//	@Summary		UploadGroundTruth
+//	@Description	upload the hidden ground truth used by the builtin metric,
+//	@Description	which is only for the organizers of competition
+//	@Tags			Competition
+//	@Param			id		path		string	true	"competition id"
+//	@Param			phase	formData	string	true	"competition phase, such as preliminary, final"
+//	@Param			file	formData	file	true	"ground truth file"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/ground_truth [put]
+func (ctl *CompetitionController) UploadGroundTruth(ctx *gin.Context) {
+	phase, err := domain.NewCompetitionPhase(ctx.PostForm("phase"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	f, err := ctx.FormFile("file")
+	if err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	p, err := f.Open()
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	defer p.Close()
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	operator := pl.DomainAccount()
+
+	cmd := app.GroundTruthUploadCmd{
+		Operator:      operator,
+		CompetitionId: ctx.Param("id"),
+		Phase:         phase,
+		Data:          p,
+	}
+
+	if code, err := ctl.admin.UploadGroundTruth(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog(
+		"", operator.Account(), "upload competition ground truth",
+		"id: "+cmd.CompetitionId+", phase: "+phase.CompetitionPhase(), "success",
+	)
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+//	@Summary		ListAuditLogs
+//	@Description	list the audit logs of competition, which is only for its organizers
+//	@Tags			Competition
+//	@Param			id	path	string	true	"competition id"
+//	@Accept			json
+//	@Success		200	{object}		app.CompetitionAuditLogDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/competition/{id}/audit_logs [get]
+func (ctl *CompetitionController) ListAuditLogs(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.admin.ListAuditLogs(ctx.Param("id"), pl.DomainAccount()); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+func (ctl *CompetitionController) operator(ctx *gin.Context) types.Account {
+	// the administrator has been authenticated by the middleware.
+	pl, _, _ := ctl.checkUserApiTokenNoRefresh(ctx, false)
+
+	return pl.DomainAccount()
+}
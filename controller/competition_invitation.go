@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/competition/app"
+	cc "github.com/opensourceways/xihe-server/competition/controller"
+)
+
+//	@Summary		Invite
+//	@Description	the team leader invites an individual competitor to join the team
+//	@Tags			Competition
+//	@Param			id		path	string				true	"competition id"
+//	@Param			body	body	cc.InviteRequest	true	"body of inviting competitor"
+//	@Accept			json
+//	@Success		201	{string}			string	"invitation id"
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/team/invitations [post]
+func (ctl *CompetitionController) Invite(ctx *gin.Context) {
+	req := cc.InviteRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd, err := req.ToCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if id, code, err := ctl.s.Invite(ctx.Param("id"), &cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, id)
+	}
+}
+
+//	@Summary		RequestToJoin
+//	@Description	an individual competitor requests to join a team
+//	@Tags			Competition
+//	@Param			id		path	string					true	"competition id"
+//	@Param			body	body	cc.RequestToJoinRequest	true	"body of requesting to join team"
+//	@Accept			json
+//	@Success		201	{string}			string	"request id"
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/competition/{id}/team/requests [post]
+func (ctl *CompetitionController) RequestToJoin(ctx *gin.Context) {
+	req := cc.RequestToJoinRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd, err := req.ToCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if id, code, err := ctl.s.RequestToJoin(ctx.Param("id"), &cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, id)
+	}
+}
+
+//	@Summary		ListInvitations
+//	@Description	list the pending invitations and requests of the team or the competitor
+//	@Tags			Competition
+//	@Param			id	path	string	true	"competition id"
+//	@Accept			json
+//	@Success		200	{object}		app.CompetitionInvitationDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/competition/{id}/team/invitations [get]
+func (ctl *CompetitionController) ListInvitations(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.s.ListInvitations(ctx.Param("id"), pl.DomainAccount())
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		AcceptInvitation
+//	@Description	accept the invitation by the competitor, or the request by the team leader
+//	@Tags			Competition
+//	@Param			id	path	string	true	"competition id"
+//	@Param			iid	path	string	true	"invitation id"
+//	@Accept			json
+//	@Success		202
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/competition/{id}/team/invitations/{iid}/action/accept [put]
+func (ctl *CompetitionController) AcceptInvitation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.CompetitionInvitationCmd{
+		User:         pl.DomainAccount(),
+		InvitationId: ctx.Param("iid"),
+	}
+
+	if code, err := ctl.s.AcceptInvitation(ctx.Param("id"), &cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+//	@Summary		DeclineInvitation
+//	@Description	decline or withdraw the invitation or the request
+//	@Tags			Competition
+//	@Param			id	path	string	true	"competition id"
+//	@Param			iid	path	string	true	"invitation id"
+//	@Accept			json
+//	@Success		202
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/competition/{id}/team/invitations/{iid}/action/decline [put]
+func (ctl *CompetitionController) DeclineInvitation(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.CompetitionInvitationCmd{
+		User:         pl.DomainAccount(),
+		InvitationId: ctx.Param("iid"),
+	}
+
+	if code, err := ctl.s.DeclineInvitation(ctx.Param("id"), &cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
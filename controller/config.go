@@ -70,6 +70,13 @@ type APIConfig struct {
 	MinSurvivalTimeOfInference     int    `json:"min_survival_time_of_inference"`
 	MaxTagsNumToSearchResource     int    `json:"max_tags_num_to_search_resource"`
 	MaxTagKindsNumToSearchResource int    `json:"max_tag_kinds_num_to_search_resource"`
+	MaxLineageDepth                int    `json:"max_lineage_depth"`
+	MaxInferenceDeploymentNum      int    `json:"max_inference_deployment_num"`
+	MaxInferencePredictBodySize    int64  `json:"max_inference_predict_body_size"`
+	MaxInferenceUsageDays          int    `json:"max_inference_usage_days"`
+
+	// Admins is the accounts of administrators.
+	Admins []string `json:"admins"`
 }
 
 func (cfg *APIConfig) SetDefault() {
@@ -108,6 +115,22 @@ func (cfg *APIConfig) SetDefault() {
 	if cfg.MaxTagKindsNumToSearchResource <= 0 {
 		cfg.MaxTagKindsNumToSearchResource = 5
 	}
+
+	if cfg.MaxLineageDepth <= 0 {
+		cfg.MaxLineageDepth = 5
+	}
+
+	if cfg.MaxInferenceDeploymentNum <= 0 {
+		cfg.MaxInferenceDeploymentNum = 3
+	}
+
+	if cfg.MaxInferencePredictBodySize <= 0 {
+		cfg.MaxInferencePredictBodySize = 1 << 20
+	}
+
+	if cfg.MaxInferenceUsageDays <= 0 {
+		cfg.MaxInferenceUsageDays = 30
+	}
 }
 
 func (cfg *APIConfig) Validate() (err error) {
@@ -124,6 +147,16 @@ func (cfg *APIConfig) Validate() (err error) {
 	return
 }
 
+func (cfg *APIConfig) isAdmin(account string) bool {
+	for _, v := range cfg.Admins {
+		if v == account {
+			return true
+		}
+	}
+
+	return false
+}
+
 type Tags struct {
 	ModelTagDomains         []string `json:"model"            required:"true"`
 	ProjectTagDomains       []string `json:"project"          required:"true"`
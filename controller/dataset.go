@@ -6,10 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -25,8 +27,15 @@ func AddRouterForDatasetController(
 	like repository.Like,
 	sender message.Sender,
 	newPlatformRepository func(token, namespace string) platform.Repository,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
 ) {
 	ctl := DatasetController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		user: user,
 		repo: repo,
 		tags: tags,
@@ -50,6 +59,8 @@ func AddRouterForDatasetController(
 type DatasetController struct {
 	baseController
 
+	resourcePermission
+
 	user userrepo.User
 	repo repository.Dataset
 	tags repository.Tags
@@ -92,7 +103,7 @@ func (ctl *DatasetController) Check(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
@@ -148,7 +159,7 @@ func (ctl *DatasetController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(cmd.Owner) {
+	if !ctl.canWrite(&pl, cmd.Owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't create dataset for other user",
@@ -157,9 +168,14 @@ func (ctl *DatasetController) Create(ctx *gin.Context) {
 		return
 	}
 
-	pr := ctl.newPlatformRepository(
-		pl.PlatformToken, pl.PlatformUserNamespaceId,
-	)
+	namespace, err := ctl.platformNamespace(&pl, cmd.Owner)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	pr := ctl.newPlatformRepository(pl.PlatformToken, namespace)
 
 	d, err := ctl.s.Create(&cmd, pr)
 	if err != nil {
@@ -207,7 +223,7 @@ func (ctl *DatasetController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canManage(&pl, owner) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access other's dataset",
@@ -281,7 +297,7 @@ func (ctl *DatasetController) Update(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't update dataset for other user",
@@ -353,7 +369,11 @@ func (ctl *DatasetController) Get(ctx *gin.Context) {
 		return
 	}
 
-	d, err := ctl.s.GetByName(owner, name, !visitor && pl.isMyself(owner))
+	allowPrivacy := !visitor && ctl.canReadByName(
+		&pl, domain.ResourceTypeDataset, owner, name, ctl.repo.GetSummaryByName,
+	)
+
+	d, err := ctl.s.GetByName(owner, name, allowPrivacy)
 	if err != nil {
 		if isErrorOfAccessingPrivateRepo(err) {
 			ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
@@ -430,7 +450,7 @@ func (ctl *DatasetController) List(ctx *gin.Context) {
 		return
 	}
 
-	if visitor || pl.isNotMe(owner) {
+	if visitor || !ctl.canRead(&pl, owner) {
 		if cmd.RepoType == nil {
 			type1, _ := domain.NewRepoType(domain.RepoTypePublic)
 			type2, _ := domain.NewRepoType(domain.RepoTypeOnline)
@@ -569,7 +589,7 @@ func (ctl *DatasetController) checkPermission(ctx *gin.Context) (d domain.Datase
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		AddEvaluateReport
+//	@Description	add the results and artifacts to the report of evaluation.
+//	@Description	it is called by the evaluation itself, the results are self-reported
+//	@Description	and the artifacts must be in the log or output directory of training.
+//	@Tags			Training
+//	@Param			pid		path	string						true	"project id"
+//	@Param			id		path	string						true	"training id"
+//	@Param			eid		path	string						true	"evaluate id"
+//	@Param			body	body	EvaluateReportAddRequest	true	"body of evaluate report"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/evaluate/{eid}/report [post]
+func (ctl *TrainingController) AddEvaluateReport(ctx *gin.Context) {
+	req := EvaluateReportAddRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.EvaluateReportAddCmd{}
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, true)
+	if !ok {
+		return
+	}
+
+	cmd.Index = ctl.getEvaluateIndex(ctx, owner)
+
+	if code, err := ctl.report.Add(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "add evaluate report",
+		fmt.Sprintf("projectid: %s, trainingid: %s, evaluateid: %s",
+			ctx.Param("pid"), ctx.Param("id"), ctx.Param("eid")), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
+//	@Summary		GetEvaluateReport
+//	@Description	get the report of evaluation
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			id	path	string	true	"training id"
+//	@Param			eid	path	string	true	"evaluate id"
+//	@Accept			json
+//	@Success		200	{object}		app.EvaluateReportDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/evaluate/{eid}/report [get]
+func (ctl *TrainingController) GetEvaluateReport(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	index := ctl.getEvaluateIndex(ctx, owner)
+
+	if v, code, err := ctl.report.Get(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		ExportEvaluateReport
+//	@Description	export the report of evaluation
+//	@Tags			Training
+//	@Param			pid		path	string	true	"project id"
+//	@Param			id		path	string	true	"training id"
+//	@Param			eid		path	string	true	"evaluate id"
+//	@Param			format	query	string	false	"json or csv, json by default"
+//	@Accept			json
+//	@Success		200
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/evaluate/{eid}/report/export [get]
+func (ctl *TrainingController) ExportEvaluateReport(ctx *gin.Context) {
+	format := ctx.DefaultQuery("format", app.EvaluateReportFormatJSON)
+
+	contentType := ""
+	switch format {
+	case app.EvaluateReportFormatJSON:
+		contentType = "application/json"
+
+	case app.EvaluateReportFormatCSV:
+		contentType = "text/csv"
+
+	default:
+		ctl.sendBadRequestParam(ctx, errors.New("unknown format"))
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	index := ctl.getEvaluateIndex(ctx, owner)
+
+	data, code, err := ctl.report.Export(&index, format)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.Header(
+		"Content-Disposition",
+		fmt.Sprintf("attachment; filename=evaluate-%s.%s", index.Id, format),
+	)
+	ctx.Data(http.StatusOK, contentType, data)
+}
+
+func (ctl *TrainingController) getEvaluateIndex(
+	ctx *gin.Context, owner domain.Account,
+) app.EvaluateIndex {
+	return app.EvaluateIndex{
+		TrainingIndex: domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: owner,
+				Id:    ctx.Param("pid"),
+			},
+			TrainingId: ctx.Param("id"),
+		},
+		Id: ctx.Param("eid"),
+	}
+}
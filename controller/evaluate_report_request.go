@@ -0,0 +1,58 @@
+package controller
+
+import (
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type EvaluateReportAddRequest struct {
+	Results   []EvaluateResultRequest   `json:"results"`
+	Artifacts []EvaluateArtifactRequest `json:"artifacts"`
+}
+
+type EvaluateResultRequest struct {
+	Momentum     string             `json:"momentum"`
+	BatchSize    string             `json:"batch_size"`
+	LearningRate string             `json:"learning_rate"`
+	Metrics      map[string]float64 `json:"metrics"`
+}
+
+type EvaluateArtifactRequest struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+}
+
+func (req *EvaluateReportAddRequest) toCmd(cmd *app.EvaluateReportAddCmd) (err error) {
+	cmd.Results = make([]domain.EvaluateResult, len(req.Results))
+
+	for i := range req.Results {
+		item := &req.Results[i]
+
+		r := &cmd.Results[i]
+		r.Parms = domain.EvaluateParms{
+			Momentum:     item.Momentum,
+			BatchSize:    item.BatchSize,
+			LearningRate: item.LearningRate,
+		}
+
+		r.Metrics = make([]domain.EvaluateMetric, 0, len(item.Metrics))
+		for k, v := range item.Metrics {
+			m := domain.EvaluateMetric{Value: v}
+			if m.Name, err = domain.NewCustomizedKey(k); err != nil {
+				return
+			}
+
+			r.Metrics = append(r.Metrics, m)
+		}
+	}
+
+	cmd.Artifacts = make([]domain.EvaluateArtifact, len(req.Artifacts))
+	for i := range req.Artifacts {
+		cmd.Artifacts[i] = domain.EvaluateArtifact{
+			Name: req.Artifacts[i].Name,
+			Path: req.Artifacts[i].Path,
+		}
+	}
+
+	return cmd.Validate()
+}
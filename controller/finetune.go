@@ -13,7 +13,9 @@ import (
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/finetune"
 	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 )
 
 func AddRouterForFinetuneController(
@@ -21,11 +23,24 @@ func AddRouterForFinetuneController(
 	fs finetune.Finetune,
 	repo repository.Finetune,
 	sender message.Sender,
+	rf platform.RepoFile,
+	user userrepo.User,
+	model repository.Model,
+	project repository.Project,
+	dataset repository.Dataset,
+	activity repository.Activity,
+	newPlatformRepository func(token, namespace string) platform.Repository,
 ) {
 	ctl := FinetuneController{
 		fs: app.NewFinetuneService(
 			fs, repo, sender,
 		),
+		export: app.NewFinetuneExportService(
+			fs, repo, rf,
+			app.NewModelService(user, model, project, dataset, activity, nil, sender),
+		),
+		entitlement:           app.NewFinetuneEntitlementService(fs, repo),
+		newPlatformRepository: newPlatformRepository,
 	}
 
 	rg.POST("/v1/finetune", ctl.Create)
@@ -33,6 +48,25 @@ func AddRouterForFinetuneController(
 	rg.GET("/v1/finetune/ws", ctl.WatchFinetunes)
 	rg.GET("/v1/finetune/:id/log", ctl.Log)
 	rg.GET("/v1/finetune/:id/log/ws", ctl.WatchSingle)
+	rg.GET("/v1/finetune/:id/artifacts", ctl.ListArtifacts)
+	rg.POST("/v1/finetune/:id/export", ctl.Export)
+
+	rg.GET(
+		"/v1/finetune/entitlement/:account",
+		checkAdminMiddleware(&ctl.baseController), ctl.GetEntitlement,
+	)
+	rg.PUT(
+		"/v1/finetune/entitlement/:account",
+		checkAdminMiddleware(&ctl.baseController), ctl.Grant,
+	)
+	rg.POST(
+		"/v1/finetune/entitlement/:account/extend",
+		checkAdminMiddleware(&ctl.baseController), ctl.Extend,
+	)
+	rg.DELETE(
+		"/v1/finetune/entitlement/:account",
+		checkAdminMiddleware(&ctl.baseController), ctl.Revoke,
+	)
 	rg.PUT("/v1/finetune/:id", ctl.Terminate)
 	rg.DELETE("v1/finetune/:id", ctl.Delete)
 }
@@ -40,7 +74,11 @@ func AddRouterForFinetuneController(
 type FinetuneController struct {
 	baseController
 
-	fs app.FinetuneService
+	fs          app.FinetuneService
+	export      app.FinetuneExportService
+	entitlement app.FinetuneEntitlementService
+
+	newPlatformRepository func(string, string) platform.Repository
 }
 
 //	@Summary		Create
@@ -373,6 +411,28 @@ func (ctl *FinetuneController) Log(ctx *gin.Context) {
 	}
 }
 
+//	@Summary		ListArtifacts
+//	@Description	list the downloadable artifacts of finetune, such as log and output.
+//	@Description	the output is not listed until the finetune service can provide it.
+//	@Tags			Finetune
+//	@Param			id	path	string	true	"finetune id"
+//	@Accept			json
+//	@Success		200	{object}		app.FinetuneArtifactDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/finetune/{id}/artifacts [get]
+func (ctl *FinetuneController) ListArtifacts(ctx *gin.Context) {
+	index, ok := ctl.finetuneIndex(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.fs.GetArtifacts(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
 func downloadLog(link string) ([]byte, error) {
 	if link == "" {
 		return nil, nil
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		GetEntitlement
+//	@Description	get the finetune entitlement of user
+//	@Tags			Finetune
+//	@Param			account	path	string	true	"account of user"
+//	@Accept			json
+//	@Success		200	{object}		app.FinetuneEntitlementDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/finetune/entitlement/{account} [get]
+func (ctl *FinetuneController) GetEntitlement(ctx *gin.Context) {
+	user, ok := ctl.entitlementAccount(ctx)
+	if !ok {
+		return
+	}
+
+	if v, code, err := ctl.entitlement.Get(user); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		Grant
+//	@Description	grant the user to finetune or replace the quota of user
+//	@Tags			Finetune
+//	@Param			account	path	string					true	"account of user"
+//	@Param			body	body	FinetuneGrantRequest	true	"body of granting finetune"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/finetune/entitlement/{account} [put]
+func (ctl *FinetuneController) Grant(ctx *gin.Context) {
+	req := FinetuneGrantRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	user, ok := ctl.entitlementAccount(ctx)
+	if !ok {
+		return
+	}
+
+	cmd := app.FinetuneGrantCmd{User: user}
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.entitlement.Grant(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctl.logEntitlement(ctx, "grant finetune", fmt.Sprintf(
+		"user: %s, expiry: %d, max concurrent jobs: %d, max gpu hours: %d",
+		user.Account(), cmd.Expiry, cmd.Quota.MaxConcurrentJobs, cmd.Quota.MaxGPUHours,
+	))
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+//	@Summary		Extend
+//	@Description	extend the expiry of finetune entitlement
+//	@Tags			Finetune
+//	@Param			account	path	string					true	"account of user"
+//	@Param			body	body	FinetuneExtendRequest	true	"body of extending finetune"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/finetune/entitlement/{account}/extend [post]
+func (ctl *FinetuneController) Extend(ctx *gin.Context) {
+	req := FinetuneExtendRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	user, ok := ctl.entitlementAccount(ctx)
+	if !ok {
+		return
+	}
+
+	cmd := app.FinetuneExtendCmd{
+		User: user,
+		Days: req.Days,
+	}
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.entitlement.Extend(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctl.logEntitlement(ctx, "extend finetune", fmt.Sprintf(
+		"user: %s, days: %d", user.Account(), cmd.Days,
+	))
+
+	ctl.sendRespOfPut(ctx, "success")
+}
+
+//	@Summary		Revoke
+//	@Description	revoke the finetune entitlement of user
+//	@Tags			Finetune
+//	@Param			account	path	string	true	"account of user"
+//	@Accept			json
+//	@Success		204
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/finetune/entitlement/{account} [delete]
+func (ctl *FinetuneController) Revoke(ctx *gin.Context) {
+	user, ok := ctl.entitlementAccount(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.entitlement.Revoke(user); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctl.logEntitlement(ctx, "revoke finetune", "user: "+user.Account())
+
+	ctl.sendRespOfDelete(ctx)
+}
+
+func (ctl *FinetuneController) entitlementAccount(ctx *gin.Context) (
+	domain.Account, bool,
+) {
+	v, err := domain.NewAccount(ctx.Param("account"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return nil, false
+	}
+
+	return v, true
+}
+
+func (ctl *FinetuneController) logEntitlement(ctx *gin.Context, action, info string) {
+	// the administrator has been authenticated by the middleware.
+	pl, _, _ := ctl.checkUserApiTokenNoRefresh(ctx, false)
+
+	utils.DoLog("", pl.Account, action, info, "success")
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		Export
+//	@Description	export a successful finetune as a new model whose model card records the finetune parameters.
+//	@Description	the output is committed to the model only if the finetune service can provide it, and the file of response is empty otherwise.
+//	@Tags			Finetune
+//	@Param			id		path	string					true	"finetune id"
+//	@Param			body	body	FinetuneExportRequest	true	"body of exporting finetune"
+//	@Accept			json
+//	@Success		201	{object}			app.FinetuneExportDTO
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/finetune/{id}/export [post]
+func (ctl *FinetuneController) Export(ctx *gin.Context) {
+	req := FinetuneExportRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.FinetuneExportCmd{
+		User: pl.PlatformUserInfo(),
+	}
+	cmd.Index.Owner = pl.DomainAccount()
+	cmd.Index.Id = ctx.Param("id")
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.export.Export(
+		&cmd, ctl.newPlatformRepository(pl.PlatformToken, pl.PlatformUserNamespaceId),
+	)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "export finetune",
+		fmt.Sprintf("finetuneid: %s, model: %s/%s", cmd.Index.Id, v.Owner, v.Name),
+		"success",
+	)
+
+	ctx.JSON(http.StatusCreated, newResponseData(v))
+}
@@ -43,3 +43,63 @@ func (req *FinetuneCreateRequest) toCmd(user domain.Account) (
 
 	return
 }
+
+type FinetuneExportRequest struct {
+	Name     string `json:"name"`
+	Desc     string `json:"desc"`
+	Title    string `json:"title"`
+	Protocol string `json:"protocol"`
+	RepoType string `json:"repo_type"`
+}
+
+func (req *FinetuneExportRequest) toCmd(cmd *app.FinetuneExportCmd) (err error) {
+	if cmd.Name, err = domain.NewResourceName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = domain.NewResourceDesc(req.Desc); err != nil {
+		return
+	}
+
+	if req.Title == "" {
+		req.Title = req.Name
+	}
+
+	if cmd.Title, err = domain.NewResourceTitle(req.Title); err != nil {
+		return
+	}
+
+	if cmd.Protocol, err = domain.NewProtocolName(req.Protocol); err != nil {
+		return
+	}
+
+	if cmd.RepoType, err = domain.NewRepoType(req.RepoType); err != nil {
+		return
+	}
+
+	return cmd.Validate()
+}
+
+type FinetuneGrantRequest struct {
+	Expiry            int64    `json:"expiry"`
+	MaxConcurrentJobs int      `json:"max_concurrent_jobs"`
+	MaxGPUHours       int      `json:"max_gpu_hours"`
+	Models            []string `json:"models"`
+}
+
+func (req *FinetuneGrantRequest) toCmd(cmd *app.FinetuneGrantCmd) (err error) {
+	cmd.Expiry = req.Expiry
+
+	cmd.Quota, err = domain.NewFinetuneQuota(
+		req.MaxConcurrentJobs, req.MaxGPUHours, req.Models,
+	)
+	if err == nil {
+		err = cmd.Validate()
+	}
+
+	return
+}
+
+type FinetuneExtendRequest struct {
+	Days int `json:"days"`
+}
@@ -3,34 +3,60 @@ package controller
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/inference"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	userdomain "github.com/opensourceways/xihe-server/user/domain"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
 const (
-	inferenceAllowedUserName = "MindSpore"
+	inferenceAllowedUserName  = "MindSpore"
+	defaultInferenceUsageDays = 7
 )
 
 func AddRouterForInferenceController(
 	rg *gin.RouterGroup,
 	p platform.RepoFile,
 	repo repository.Inference,
+	deployment repository.InferenceDeployment,
+	usage repository.InferenceUsage,
 	project repository.Project,
+	predictor inference.Predictor,
 	sender message.Sender,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
+	checker *AccessTokenChecker,
 ) {
+	usageService := app.NewInferenceUsageService(usage, sender)
+
 	ctl := InferenceController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		s: app.NewInferenceService(
 			p, repo, sender, apiConfig.MinSurvivalTimeOfInference,
 		),
+		deployment: app.NewInferenceDeploymentService(
+			p, repo, deployment, predictor,
+			usageService, sender,
+			apiConfig.MinSurvivalTimeOfInference,
+			apiConfig.MaxInferenceDeploymentNum,
+		),
+		usage:   usageService,
 		project: project,
 	}
 
@@ -38,12 +64,33 @@ func AddRouterForInferenceController(
 	ctl.inferenceBootFile, _ = domain.NewFilePath(apiConfig.InferenceBootFile)
 
 	rg.GET("/v1/inference/project/:owner/:pid", ctl.Create)
+
+	rg.GET("/v1/inference/usage/:owner/:pid", ctl.GetUsage)
+
+	rg.GET("/v1/inference/instance", ctl.ListRunning)
+	rg.POST("/v1/inference/instance/:owner/:pid/:commit/:id/stop", ctl.Stop)
+	rg.POST("/v1/inference/instance/:owner/:pid/:commit/:id/restart", ctl.Restart)
+
+	rg.POST("/v1/inference/deployment/:owner/:pid", ctl.CreateDeployment)
+	rg.GET("/v1/inference/deployment/:owner/:pid", ctl.ListDeployments)
+	rg.GET("/v1/inference/deployment/:owner/:pid/:id", ctl.GetDeployment)
+	rg.PUT("/v1/inference/deployment/:owner/:pid/:id", ctl.RollDeployment)
+	rg.POST("/v1/inference/deployment/:owner/:pid/:id/start", ctl.StartDeployment)
+	rg.POST(
+		"/v1/inference/deployment/:owner/:pid/:id/predict",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeInference),
+		ctl.Predict,
+	)
 }
 
 type InferenceController struct {
 	baseController
 
-	s app.InferenceService
+	resourcePermission
+
+	s          app.InferenceService
+	deployment app.InferenceDeploymentService
+	usage      app.InferenceUsageService
 
 	project repository.Project
 
@@ -221,6 +268,173 @@ func (ctl *InferenceController) Create(ctx *gin.Context) {
 	ws.WriteJSON(newResponseCodeMsg(errorSystemError, "timeout"))
 }
 
+//	@Summary		ListRunning
+//	@Description	list the running inference instances of the projects owned by user
+//	@Tags			Inference
+//	@Accept			json
+//	@Success		200	{object}		app.InferenceInstanceDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/instance [get]
+func (ctl *InferenceController) ListRunning(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.s.ListRunning(pl.DomainAccount())
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		Stop
+//	@Description	stop the inference instance to release the resource early.
+//	@Description	The container manager can't stop the container yet, so it runs until its survival time is over.
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			commit	path	string	true	"last commit of inference"
+//	@Param			id		path	string	true	"inference id"
+//	@Accept			json
+//	@Success		202
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/instance/{owner}/{pid}/{commit}/{id}/stop [post]
+func (ctl *InferenceController) Stop(ctx *gin.Context) {
+	pl, index, ok := ctl.getInferenceIndex(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.s.Stop(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "stop inference",
+		fmt.Sprintf("projectid: %s, inferenceid: %s", index.Project.Id, index.Id), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
+//	@Summary		Restart
+//	@Description	restart the inference instance with the same commit
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			commit	path	string	true	"last commit of inference"
+//	@Param			id		path	string	true	"inference id"
+//	@Accept			json
+//	@Success		202	{object}		app.InferenceDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/instance/{owner}/{pid}/{commit}/{id}/restart [post]
+func (ctl *InferenceController) Restart(ctx *gin.Context) {
+	pl, index, ok := ctl.getInferenceIndex(ctx)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.project.GetSummary(index.Project.Owner, index.Project.Id)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	level, err := ctl.getResourceLevel(index.Project.Owner, index.Project.Id)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	cmd := app.InferenceRestartCmd{
+		Index:         index,
+		ProjectName:   v.Name,
+		ResourceLevel: level,
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	dto, code, err := ctl.s.Restart(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "restart inference",
+		fmt.Sprintf("projectid: %s, inferenceid: %s", index.Project.Id, index.Id), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData(dto))
+}
+
+func (ctl *InferenceController) getInferenceIndex(ctx *gin.Context) (
+	pl oldUserTokenPayload, index app.InferenceIndex, ok bool,
+) {
+	if pl, index.Project, ok = ctl.getProjectIndex(ctx, true); !ok {
+		return
+	}
+
+	index.Id = ctx.Param("id")
+	index.LastCommit = ctx.Param("commit")
+
+	return
+}
+
+//	@Summary		GetUsage
+//	@Description	get the daily usage and top callers of the inference of project
+//	@Description	The usage counts the calls of the prediction api which are proxied to the inference instances.
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			days	query	int		false	"the number of recent days, default is 7"
+//	@Accept			json
+//	@Success		200	{object}			app.InferenceUsageDTO
+//	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/inference/usage/{owner}/{pid} [get]
+func (ctl *InferenceController) GetUsage(ctx *gin.Context) {
+	_, project, ok := ctl.getProjectIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	cmd := app.InferenceUsageCmd{
+		Project: project,
+		Days:    defaultInferenceUsageDays,
+	}
+
+	if v := ctx.Query("days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Days = days
+	}
+
+	if err := cmd.Validate(apiConfig.MaxInferenceUsageDays); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if v, err := ctl.usage.Get(&cmd); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
 func (ctl *InferenceController) getResourceLevel(owner domain.Account, pid string) (level string, err error) {
 	resources, err := ctl.project.FindUserProjects(
 		[]repository.UserResourceListOption{
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		CreateDeployment
+//	@Description	create an inference deployment pinned to a commit of project
+//	@Tags			Inference
+//	@Param			owner	path	string						true	"project owner"
+//	@Param			pid		path	string						true	"project id"
+//	@Param			body	body	InferenceDeploymentRequest	true	"body of creating deployment"
+//	@Accept			json
+//	@Success		201	{object}			inferenceDeploymentCreateResp
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid} [post]
+func (ctl *InferenceController) CreateDeployment(ctx *gin.Context) {
+	req := InferenceDeploymentRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, project, ok := ctl.getProjectIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.project.GetSummary(project.Owner, project.Id)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	level, err := ctl.getResourceLevel(project.Owner, project.Id)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	cmd := app.InferenceDeploymentCreateCmd{
+		Project:       project,
+		ProjectName:   v.Name,
+		ResourceLevel: level,
+		Commit:        req.Commit,
+		InferenceDir:  ctl.inferenceDir,
+		BootFile:      ctl.inferenceBootFile,
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	u := ctl.platformUserInfo(&pl, project.Owner)
+
+	id, code, err := ctl.deployment.Create(&u, &cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create inference deployment",
+		fmt.Sprintf("projectid: %s, deploymentid: %s", project.Id, id), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(inferenceDeploymentCreateResp{id}))
+}
+
+//	@Summary		RollDeployment
+//	@Description	roll the inference deployment to a new commit of project
+//	@Tags			Inference
+//	@Param			owner	path	string						true	"project owner"
+//	@Param			pid		path	string						true	"project id"
+//	@Param			id		path	string						true	"deployment id"
+//	@Param			body	body	InferenceDeploymentRequest	true	"body of rolling deployment"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		400	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid}/{id} [put]
+func (ctl *InferenceController) RollDeployment(ctx *gin.Context) {
+	req := InferenceDeploymentRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, project, ok := ctl.getProjectIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	cmd := app.InferenceDeploymentRollCmd{
+		Index: app.InferenceDeploymentIndex{
+			Project: project,
+			Id:      ctx.Param("id"),
+		},
+		Commit:       req.Commit,
+		InferenceDir: ctl.inferenceDir,
+		BootFile:     ctl.inferenceBootFile,
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	u := ctl.platformUserInfo(&pl, project.Owner)
+
+	if code, err := ctl.deployment.Roll(&u, &cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "roll inference deployment",
+		fmt.Sprintf("projectid: %s, deploymentid: %s, commit: %s",
+			project.Id, cmd.Index.Id, req.Commit), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
+//	@Summary		StartDeployment
+//	@Description	start the inference deployment again after its instance exits or fails
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			id		path	string	true	"deployment id"
+//	@Accept			json
+//	@Success		202
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid}/{id}/start [post]
+func (ctl *InferenceController) StartDeployment(ctx *gin.Context) {
+	pl, project, ok := ctl.getProjectIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	index := app.InferenceDeploymentIndex{
+		Project: project,
+		Id:      ctx.Param("id"),
+	}
+
+	if code, err := ctl.deployment.Start(&index); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "start inference deployment",
+		fmt.Sprintf("projectid: %s, deploymentid: %s", project.Id, index.Id), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
+//	@Summary		GetDeployment
+//	@Description	get the status of inference deployment
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			id		path	string	true	"deployment id"
+//	@Accept			json
+//	@Success		200	{object}		app.InferenceDeploymentDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid}/{id} [get]
+func (ctl *InferenceController) GetDeployment(ctx *gin.Context) {
+	_, project, ok := ctl.getProjectIndex(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.deployment.Get(&app.InferenceDeploymentIndex{
+		Project: project,
+		Id:      ctx.Param("id"),
+	})
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		ListDeployments
+//	@Description	list the inference deployments of project
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Accept			json
+//	@Success		200	{object}		app.InferenceDeploymentDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid} [get]
+func (ctl *InferenceController) ListDeployments(ctx *gin.Context) {
+	_, project, ok := ctl.getProjectIndex(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.deployment.List(&project)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		Predict
+//	@Description	call the prediction api of the inference deployment.
+//	@Description	It can be authenticated by the access token with the scope of inference.
+//	@Tags			Inference
+//	@Param			owner	path	string	true	"project owner"
+//	@Param			pid		path	string	true	"project id"
+//	@Param			id		path	string	true	"deployment id"
+//	@Accept			json
+//	@Produce		json
+//	@Success		200
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/inference/deployment/{owner}/{pid}/{id}/predict [post]
+func (ctl *InferenceController) Predict(ctx *gin.Context) {
+	pl, project, ok := ctl.getProjectIndex(ctx, false)
+	if !ok {
+		return
+	}
+
+	body, err := io.ReadAll(
+		io.LimitReader(ctx.Request.Body, apiConfig.MaxInferencePredictBodySize+1),
+	)
+	if err != nil || !json.Valid(body) {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	if int64(len(body)) > apiConfig.MaxInferencePredictBodySize {
+		ctl.sendBadRequestParam(ctx, errors.New("request body is too large"))
+
+		return
+	}
+
+	index := app.InferenceDeploymentIndex{
+		Project: project,
+		Id:      ctx.Param("id"),
+	}
+
+	status, resp, code, err := ctl.deployment.Predict(&index, pl.DomainAccount(), body)
+	if err != nil {
+		if code == "" {
+			log.Errorf(
+				"predict by inference deployment(%s) failed, user:%s, err:%s",
+				index.Id, pl.Account, err.Error(),
+			)
+		}
+
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	ctx.Data(status, "application/json", resp)
+}
+
+func (ctl *InferenceController) getProjectIndex(ctx *gin.Context, write bool) (
+	pl oldUserTokenPayload, project domain.ResourceIndex, ok bool,
+) {
+	if pl, _, ok = ctl.checkUserApiToken(ctx, false); !ok {
+		return
+	}
+
+	owner, err := domain.NewAccount(ctx.Param("owner"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		ok = false
+
+		return
+	}
+
+	project = domain.ResourceIndex{
+		Owner: owner,
+		Id:    ctx.Param("pid"),
+	}
+
+	ok = ctl.checkResource(
+		ctx, &pl,
+		&domain.ResourceObject{
+			Type:          domain.ResourceTypeProject,
+			ResourceIndex: project,
+		},
+		write,
+	)
+
+	return
+}
+
+// platformUserInfo returns the user to read the repo of the project,
+// the same as the one used by creating inference.
+func (ctl *InferenceController) platformUserInfo(
+	pl *oldUserTokenPayload, owner domain.Account,
+) platform.UserInfo {
+	if pl.isNotMe(owner) {
+		return platform.UserInfo{User: owner}
+	}
+
+	return pl.PlatformUserInfo()
+}
@@ -0,0 +1,11 @@
+package controller
+
+type inferenceDeploymentCreateResp struct {
+	Id string `json:"id"`
+}
+
+// InferenceDeploymentRequest specifies the commit of the project to deploy.
+// The latest commit of the inference directory will be used if it is empty.
+type InferenceDeploymentRequest struct {
+	Commit string `json:"commit"`
+}
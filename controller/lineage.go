@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
+	competitionrepo "github.com/opensourceways/xihe-server/competition/domain/repository"
+	courserepo "github.com/opensourceways/xihe-server/course/domain/repository"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+)
+
+func AddRouterForLineageController(
+	rg *gin.RouterGroup,
+	project repository.Project,
+	model repository.Model,
+	dataset repository.Dataset,
+	training repository.Training,
+	lineage repository.ModelLineage,
+	fork repository.ProjectFork,
+	competition competitionrepo.Competition,
+	work competitionrepo.Work,
+	course courserepo.Course,
+	coursePlayer courserepo.Player,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
+) {
+	ctl := LineageController{
+		s: app.NewLineageService(
+			project, model, dataset, training, lineage, fork,
+			competition, work, course, coursePlayer,
+		),
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+	}
+
+	rg.GET("/v1/lineage/:type/:owner/:id", ctl.Get)
+}
+
+type LineageController struct {
+	baseController
+
+	s app.LineageService
+
+	resourcePermission
+}
+
+//	@Summary		Get
+//	@Description	get the lineage graph of resource, competition or course
+//	@Tags			Lineage
+//	@Param			type		path	string	true	"project, model, dataset, competition or course"
+//	@Param			owner		path	string	true	"owner of resource, it is ignored for competition and course"
+//	@Param			id			path	string	true	"id of node"
+//	@Param			direction	query	string	false	"upstream, downstream or both, default is both"
+//	@Param			depth		query	int		false	"max depth of traversal, default is 2"
+//	@Accept			json
+//	@Success		200	{object}			app.LineageGraphDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/lineage/{type}/{owner}/{id} [get]
+func (ctl *LineageController) Get(ctx *gin.Context) {
+	pl, visitor, ok := ctl.checkUserApiToken(ctx, true)
+	if !ok {
+		return
+	}
+
+	cmd := app.LineageGetCmd{
+		Node: app.LineageNode{
+			Type: ctx.Param("type"),
+			Id:   ctx.Param("id"),
+		},
+	}
+
+	if !visitor {
+		cmd.CanRead = func(obj *domain.ResourceObject) bool {
+			return ctl.canReadResource(&pl, obj)
+		}
+	}
+
+	if t := cmd.Node.Type; t != app.LineageNodeTypeCompetition && t != app.LineageNodeTypeCourse {
+		owner, err := domain.NewAccount(ctx.Param("owner"))
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Node.Owner = owner
+	}
+
+	switch ctl.getQueryParameter(ctx, "direction") {
+	case "", "both":
+		cmd.Upstream = true
+		cmd.Downstream = true
+
+	case "upstream":
+		cmd.Upstream = true
+
+	case "downstream":
+		cmd.Downstream = true
+
+	default:
+		ctl.sendBadRequestParam(ctx, errors.New("unknown direction"))
+
+		return
+	}
+
+	if v := ctl.getQueryParameter(ctx, "depth"); v != "" {
+		depth, err := strconv.Atoi(v)
+		if err != nil {
+			ctl.sendBadRequestParam(ctx, err)
+
+			return
+		}
+
+		cmd.Depth = depth
+	}
+
+	if err := cmd.Validate(apiConfig.MaxLineageDepth); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.s.Get(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
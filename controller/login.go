@@ -11,8 +11,10 @@ import (
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	userapp "github.com/opensourceways/xihe-server/user/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
+	userorgcli "github.com/opensourceways/xihe-server/user/infrastructure/orgcli"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
@@ -62,10 +64,15 @@ func AddRouterForLoginController(
 	auth authing.User,
 	login repository.Login,
 	sender message.Sender,
+	org orgapp.OrgService,
 ) {
+	us := userapp.NewUserService(
+		repo, ps, sender, encryptHelperToken, userorgcli.NewOrgCli(org),
+	)
+
 	pc := LoginController{
 		auth: auth,
-		us:   userapp.NewUserService(repo, ps, sender, encryptHelperToken),
+		us:   us,
 		ls:   app.NewLoginService(login),
 	}
 
@@ -30,3 +30,28 @@ func checkUserEmailMiddleware(ctl *baseController) gin.HandlerFunc {
 
 	}
 }
+
+// checkAdminMiddleware allows only the administrators to call the api.
+func checkAdminMiddleware(ctl *baseController) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		pl, _, ok := ctl.checkUserApiTokenNoRefresh(ctx, false)
+		if !ok {
+			ctx.Abort()
+
+			return
+		}
+
+		if !apiConfig.isAdmin(pl.Account) {
+			ctl.sendCodeMessage(
+				ctx, errorNotAllowed,
+				errors.New("this interface is only for the administrators"),
+			)
+
+			ctx.Abort()
+
+			return
+		}
+
+		ctx.Next()
+	}
+}
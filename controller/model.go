@@ -6,10 +6,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -25,8 +27,15 @@ func AddRouterForModelController(
 	like repository.Like,
 	sender message.Sender,
 	newPlatformRepository func(token, namespace string) platform.Repository,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
 ) {
 	ctl := ModelController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		user:    user,
 		repo:    repo,
 		dataset: dataset,
@@ -54,6 +63,8 @@ func AddRouterForModelController(
 type ModelController struct {
 	baseController
 
+	resourcePermission
+
 	user    userrepo.User
 	repo    repository.Model
 	dataset repository.Dataset
@@ -97,7 +108,7 @@ func (ctl *ModelController) Check(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
@@ -153,7 +164,7 @@ func (ctl *ModelController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(cmd.Owner) {
+	if !ctl.canWrite(&pl, cmd.Owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't create model for other user",
@@ -162,9 +173,14 @@ func (ctl *ModelController) Create(ctx *gin.Context) {
 		return
 	}
 
-	pr := ctl.newPlatformRepository(
-		pl.PlatformToken, pl.PlatformUserNamespaceId,
-	)
+	namespace, err := ctl.platformNamespace(&pl, cmd.Owner)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	pr := ctl.newPlatformRepository(pl.PlatformToken, namespace)
 
 	d, err := ctl.s.Create(&cmd, pr)
 	if err != nil {
@@ -212,7 +228,7 @@ func (ctl *ModelController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canManage(&pl, owner) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access other's model",
@@ -285,7 +301,7 @@ func (ctl *ModelController) Update(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't update model for other user",
@@ -357,7 +373,11 @@ func (ctl *ModelController) Get(ctx *gin.Context) {
 		return
 	}
 
-	m, err := ctl.s.GetByName(owner, name, !visitor && pl.isMyself(owner))
+	allowPrivacy := !visitor && ctl.canReadByName(
+		&pl, domain.ResourceTypeModel, owner, name, ctl.repo.GetSummaryByName,
+	)
+
+	m, err := ctl.s.GetByName(owner, name, allowPrivacy)
 	if err != nil {
 		if isErrorOfAccessingPrivateRepo(err) {
 			ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
@@ -434,7 +454,7 @@ func (ctl *ModelController) List(ctx *gin.Context) {
 		return
 	}
 
-	if visitor || pl.isNotMe(owner) {
+	if visitor || !ctl.canRead(&pl, owner) {
 		if cmd.RepoType == nil {
 			type1, _ := domain.NewRepoType(domain.RepoTypePublic)
 			type2, _ := domain.NewRepoType(domain.RepoTypeOnline)
@@ -551,7 +571,11 @@ func (ctl *ModelController) AddRelatedDataset(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) && data.IsPrivate() {
+	obj := &domain.ResourceObject{Type: domain.ResourceTypeDataset}
+	obj.Owner = owner
+	obj.Id = data.Id
+
+	if data.IsPrivate() && !ctl.canReadResource(&pl, obj) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access private dataset",
@@ -689,7 +713,7 @@ func (ctl *ModelController) checkPermission(ctx *gin.Context) (
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
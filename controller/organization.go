@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/domain"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+)
+
+func AddRouterForOrganizationController(
+	rg *gin.RouterGroup,
+	s orgapp.OrgService,
+) {
+	ctl := OrganizationController{
+		s: s,
+	}
+
+	rg.POST("/v1/organization", checkUserEmailMiddleware(&ctl.baseController), ctl.Create)
+	rg.GET("/v1/organization", ctl.List)
+	rg.GET("/v1/organization/:name", ctl.Get)
+	rg.POST("/v1/organization/:name/member", checkUserEmailMiddleware(&ctl.baseController), ctl.AddMember)
+	rg.PUT("/v1/organization/:name/member", checkUserEmailMiddleware(&ctl.baseController), ctl.UpdateMember)
+	rg.DELETE("/v1/organization/:name/member/:account", ctl.RemoveMember)
+}
+
+type OrganizationController struct {
+	baseController
+
+	s orgapp.OrgService
+}
+
+//	@Summary		Create
+//	@Description	create organization
+//	@Tags			Organization
+//	@Param			body	body	orgCreateRequest	true	"body of creating organization"
+//	@Accept			json
+//	@Success		201	{object}			orgapp.OrgDTO
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/organization [post]
+func (ctl *OrganizationController) Create(ctx *gin.Context) {
+	req := orgCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd, err := req.toCmd(pl.DomainAccount())
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if dto, code, err := ctl.s.Create(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, dto)
+	}
+}
+
+//	@Summary		List
+//	@Description	list organizations which the user is member of
+//	@Tags			Organization
+//	@Accept			json
+//	@Success		200	{object}		[]orgapp.OrgDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/organization [get]
+func (ctl *OrganizationController) List(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if v, err := ctl.s.ListByMember(pl.DomainAccount()); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		Get
+//	@Description	get organization
+//	@Tags			Organization
+//	@Param			name	path	string	true	"name of organization"
+//	@Accept			json
+//	@Success		200	{object}			orgapp.OrgDTO
+//	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/organization/{name} [get]
+func (ctl *OrganizationController) Get(ctx *gin.Context) {
+	name, err := domain.NewAccount(ctx.Param("name"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if _, _, ok := ctl.checkUserApiToken(ctx, true); !ok {
+		return
+	}
+
+	if v, err := ctl.s.Get(name); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		AddMember
+//	@Description	add member to organization
+//	@Tags			Organization
+//	@Param			name	path	string				true	"name of organization"
+//	@Param			body	body	orgMemberRequest	true	"body of member"
+//	@Accept			json
+//	@Success		201
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/organization/{name}/member [post]
+func (ctl *OrganizationController) AddMember(ctx *gin.Context) {
+	cmd, ok := ctl.getMemberCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.s.AddMember(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPost(ctx, "success")
+	}
+}
+
+//	@Summary		UpdateMember
+//	@Description	change the role of member
+//	@Tags			Organization
+//	@Param			name	path	string				true	"name of organization"
+//	@Param			body	body	orgMemberRequest	true	"body of member"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse	request	body
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/organization/{name}/member [put]
+func (ctl *OrganizationController) UpdateMember(ctx *gin.Context) {
+	cmd, ok := ctl.getMemberCmd(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.s.UpdateMember(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfPut(ctx, "success")
+	}
+}
+
+//	@Summary		RemoveMember
+//	@Description	remove member from organization
+//	@Tags			Organization
+//	@Param			name	path	string	true	"name of organization"
+//	@Param			account	path	string	true	"account of member"
+//	@Accept			json
+//	@Success		204
+//	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/organization/{name}/member/{account} [delete]
+func (ctl *OrganizationController) RemoveMember(ctx *gin.Context) {
+	name, err := domain.NewAccount(ctx.Param("name"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	member, err := domain.NewAccount(ctx.Param("account"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := orgapp.OrgRemoveMemberCmd{
+		Org:      name,
+		Operator: pl.DomainAccount(),
+		Member:   member,
+	}
+
+	if code, err := ctl.s.RemoveMember(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfDelete(ctx)
+	}
+}
+
+func (ctl *OrganizationController) getMemberCmd(ctx *gin.Context) (
+	cmd orgapp.OrgMemberCmd, ok bool,
+) {
+	req := orgMemberRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	name, err := domain.NewAccount(ctx.Param("name"))
+	if err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	if cmd, err = req.toCmd(name, pl.DomainAccount()); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		ok = false
+	}
+
+	return
+}
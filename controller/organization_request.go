@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	orgdomain "github.com/opensourceways/xihe-server/organization/domain"
+)
+
+type orgCreateRequest struct {
+	Name string `json:"name"`
+	Desc string `json:"desc"`
+}
+
+func (req *orgCreateRequest) toCmd(creator domain.Account) (
+	cmd orgapp.OrgCreateCmd, err error,
+) {
+	if cmd.Name, err = domain.NewAccount(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = orgdomain.NewOrgDesc(req.Desc); err != nil {
+		return
+	}
+
+	cmd.Creator = creator
+
+	err = cmd.Validate()
+
+	return
+}
+
+type orgMemberRequest struct {
+	Account string `json:"account"`
+	Role    string `json:"role"`
+}
+
+func (req *orgMemberRequest) toCmd(org, operator domain.Account) (
+	cmd orgapp.OrgMemberCmd, err error,
+) {
+	if cmd.Member, err = domain.NewAccount(req.Account); err != nil {
+		return
+	}
+
+	if cmd.Role, err = orgdomain.NewOrgRole(req.Role); err != nil {
+		return
+	}
+
+	cmd.Org = org
+	cmd.Operator = operator
+
+	err = cmd.Validate()
+
+	return
+}
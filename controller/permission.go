@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
+	"github.com/opensourceways/xihe-server/domain"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+)
+
+// resourcePermission checks the permission of user on the resources
+// owned by an account which may be the user or an organization.
+// The collaborators of a resource have the permission of it too.
+type resourcePermission struct {
+	org          orgapp.OrgService
+	collaborator collaboratorapp.CollaboratorService
+}
+
+func (p resourcePermission) canRead(pl *oldUserTokenPayload, owner domain.Account) bool {
+	if pl.isMyself(owner) {
+		return true
+	}
+
+	b, err := p.org.CanRead(owner, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check org permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
+
+func (p resourcePermission) canWrite(pl *oldUserTokenPayload, owner domain.Account) bool {
+	if pl.isMyself(owner) {
+		return true
+	}
+
+	b, err := p.org.CanWrite(owner, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check org permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
+
+func (p resourcePermission) canManage(pl *oldUserTokenPayload, owner domain.Account) bool {
+	if pl.isMyself(owner) {
+		return true
+	}
+
+	b, err := p.org.CanManage(owner, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check org permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
+
+// platformNamespace returns the namespace on the code platform
+// in which the repo of owner will be created.
+func (p resourcePermission) platformNamespace(
+	pl *oldUserTokenPayload, owner domain.Account,
+) (string, error) {
+	if pl.isMyself(owner) {
+		return pl.PlatformUserNamespaceId, nil
+	}
+
+	v, err := p.org.Get(owner)
+	if err != nil {
+		return "", err
+	}
+
+	return v.NamespaceId, nil
+}
+
+// parseOwner returns the owner specified by the query parameter of "owner"
+// which is the user itself by default.
+func (p resourcePermission) parseOwner(
+	ctx *gin.Context, pl *oldUserTokenPayload,
+) (owner domain.Account, ok bool) {
+	v := ctx.Query("owner")
+	if v == "" {
+		return pl.DomainAccount(), true
+	}
+
+	owner, err := domain.NewAccount(v)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		return
+	}
+
+	ok = true
+
+	return
+}
+
+// checkResource responds with not allowed if the user can't
+// read or write the resource.
+func (p resourcePermission) checkResource(
+	ctx *gin.Context, pl *oldUserTokenPayload,
+	obj *domain.ResourceObject, write bool,
+) bool {
+	b := false
+	if write {
+		b = p.canWriteResource(pl, obj)
+	} else {
+		b = p.canReadResource(pl, obj)
+	}
+
+	if !b {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
+			errorNotAllowed, "not allowed",
+		))
+	}
+
+	return b
+}
+
+func (p resourcePermission) canReadResource(
+	pl *oldUserTokenPayload, obj *domain.ResourceObject,
+) bool {
+	if p.canRead(pl, obj.Owner) {
+		return true
+	}
+
+	b, err := p.collaborator.CanRead(obj, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check collaborator permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
+
+func (p resourcePermission) canWriteResource(
+	pl *oldUserTokenPayload, obj *domain.ResourceObject,
+) bool {
+	if p.canWrite(pl, obj.Owner) {
+		return true
+	}
+
+	b, err := p.collaborator.CanWrite(obj, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check collaborator permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
+
+// canReadByName checks whether the user can read the private resource
+// which is specified by the owner and name.
+func (p resourcePermission) canReadByName(
+	pl *oldUserTokenPayload, t domain.ResourceType,
+	owner domain.Account, name domain.ResourceName,
+	get func(domain.Account, domain.ResourceName) (domain.ResourceSummary, error),
+) bool {
+	if p.canRead(pl, owner) {
+		return true
+	}
+
+	s, err := get(owner, name)
+	if err != nil {
+		// the caller will handle the case that the resource is not exist.
+		return false
+	}
+
+	obj := domain.ResourceObject{
+		Type: t,
+		ResourceIndex: domain.ResourceIndex{
+			Owner: owner,
+			Id:    s.Id,
+		},
+	}
+
+	b, err := p.collaborator.CanRead(&obj, pl.DomainAccount())
+	if err != nil {
+		log.Errorf("check collaborator permission failed, err:%s", err.Error())
+	}
+
+	return b
+}
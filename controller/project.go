@@ -7,10 +7,12 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -22,12 +24,20 @@ func AddRouterForProjectController(
 	model repository.Model,
 	dataset repository.Dataset,
 	activity repository.Activity,
+	fork repository.ProjectFork,
 	tags repository.Tags,
 	like repository.Like,
 	sender message.Sender,
 	newPlatformRepository func(token, namespace string) platform.Repository,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
 ) {
 	ctl := ProjectController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		user:    user,
 		repo:    repo,
 		model:   model,
@@ -35,7 +45,7 @@ func AddRouterForProjectController(
 		tags:    tags,
 		like:    like,
 		s: app.NewProjectService(
-			user, repo, model, dataset, activity, nil, sender,
+			user, repo, model, dataset, activity, fork, nil, sender,
 		),
 
 		newPlatformRepository: newPlatformRepository,
@@ -63,6 +73,8 @@ func AddRouterForProjectController(
 type ProjectController struct {
 	baseController
 
+	resourcePermission
+
 	user userrepo.User
 	repo repository.Project
 	s    app.ProjectService
@@ -108,7 +120,7 @@ func (ctl *ProjectController) Check(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
@@ -160,7 +172,7 @@ func (ctl *ProjectController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(cmd.Owner) {
+	if !ctl.canWrite(&pl, cmd.Owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't create project for other user",
@@ -169,9 +181,14 @@ func (ctl *ProjectController) Create(ctx *gin.Context) {
 		return
 	}
 
-	pr := ctl.newPlatformRepository(
-		pl.PlatformToken, pl.PlatformUserNamespaceId,
-	)
+	namespace, err := ctl.platformNamespace(&pl, cmd.Owner)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	pr := ctl.newPlatformRepository(pl.PlatformToken, namespace)
 
 	d, err := ctl.s.Create(&cmd, pr)
 	if err != nil {
@@ -219,7 +236,7 @@ func (ctl *ProjectController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canManage(&pl, owner) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access other's project",
@@ -293,7 +310,7 @@ func (ctl *ProjectController) Update(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed,
 			"can't update project for other user",
@@ -365,7 +382,11 @@ func (ctl *ProjectController) Get(ctx *gin.Context) {
 		return
 	}
 
-	proj, err := ctl.s.GetByName(owner, name, !visitor && pl.isMyself(owner))
+	allowPrivacy := !visitor && ctl.canReadByName(
+		&pl, domain.ResourceTypeProject, owner, name, ctl.repo.GetSummaryByName,
+	)
+
+	proj, err := ctl.s.GetByName(owner, name, allowPrivacy)
 	if err != nil {
 		if isErrorOfAccessingPrivateRepo(err) {
 			ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
@@ -442,7 +463,7 @@ func (ctl *ProjectController) List(ctx *gin.Context) {
 		return
 	}
 
-	if visitor || pl.isNotMe(owner) {
+	if visitor || !ctl.canRead(&pl, owner) {
 		if cmd.RepoType == nil {
 			type1, _ := domain.NewRepoType(domain.RepoTypePublic)
 			type2, _ := domain.NewRepoType(domain.RepoTypeOnline)
@@ -662,7 +683,11 @@ func (ctl *ProjectController) AddRelatedModel(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) && data.IsPrivate() {
+	obj := &domain.ResourceObject{Type: domain.ResourceTypeModel}
+	obj.Owner = owner
+	obj.Id = data.Id
+
+	if data.IsPrivate() && !ctl.canReadResource(&pl, obj) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access private project",
@@ -776,7 +801,11 @@ func (ctl *ProjectController) AddRelatedDataset(ctx *gin.Context) {
 		return
 	}
 
-	if pl.isNotMe(owner) && data.IsPrivate() {
+	obj := &domain.ResourceObject{Type: domain.ResourceTypeDataset}
+	obj.Owner = owner
+	obj.Id = data.Id
+
+	if data.IsPrivate() && !ctl.canReadResource(&pl, obj) {
 		ctx.JSON(http.StatusNotFound, newResponseCodeMsg(
 			errorResourceNotExists,
 			"can't access private project",
@@ -914,7 +943,7 @@ func (ctl *ProjectController) checkPermission(ctx *gin.Context) (
 		return
 	}
 
-	if pl.isNotMe(owner) {
+	if !ctl.canWrite(&pl, owner) {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
 			errorNotAllowed, "not allowed",
 		))
@@ -8,12 +8,16 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	uapp "github.com/opensourceways/xihe-server/user/app"
+	userdomain "github.com/opensourceways/xihe-server/user/domain"
 	urepo "github.com/opensourceways/xihe-server/user/domain/repository"
+	userorgcli "github.com/opensourceways/xihe-server/user/infrastructure/orgcli"
 )
 
 func AddRouterForRepoFileController(
@@ -25,10 +29,18 @@ func AddRouterForRepoFileController(
 	sender message.Sender,
 	ru urepo.User,
 	pu platform.User,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
+	checker *AccessTokenChecker,
 ) {
 	ctl := RepoFileController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		s:       app.NewRepoFileService(p, sender),
-		us:      uapp.NewUserService(ru, pu, sender, encryptHelperToken),
+		us:      uapp.NewUserService(ru, pu, sender, encryptHelperToken, userorgcli.NewOrgCli(org)),
 		model:   model,
 		project: project,
 		dataset: dataset,
@@ -40,8 +52,16 @@ func AddRouterForRepoFileController(
 	rg.GET("/v1/repo/:type/:user/:name/file/:path/preview", ctl.Preview)
 	rg.GET("/v1/repo/:type/:user/:name/readme", ctl.ContainReadme)
 	rg.GET("/v1/repo/:type/:user/:name/app", ctl.ContainApp)
-	rg.PUT("/v1/repo/:type/:name/file/:path", checkUserEmailMiddleware(&ctl.baseController), ctl.Update)
-	rg.POST("/v1/repo/:type/:name/file/:path", checkUserEmailMiddleware(&ctl.baseController), ctl.Create)
+	rg.PUT(
+		"/v1/repo/:type/:name/file/:path",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeRepo),
+		checkUserEmailMiddleware(&ctl.baseController), ctl.Update,
+	)
+	rg.POST(
+		"/v1/repo/:type/:name/file/:path",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeRepo),
+		checkUserEmailMiddleware(&ctl.baseController), ctl.Create,
+	)
 	rg.DELETE("/v1/repo/:type/:name/file/:path", checkUserEmailMiddleware(&ctl.baseController), ctl.Delete)
 	rg.DELETE("/v1/repo/:type/:name/dir/:path", checkUserEmailMiddleware(&ctl.baseController), ctl.DeleteDir)
 }
@@ -49,6 +69,8 @@ func AddRouterForRepoFileController(
 type RepoFileController struct {
 	baseController
 
+	resourcePermission
+
 	s       app.RepoFileService
 	us      uapp.UserService
 	model   repository.Model
@@ -61,6 +83,7 @@ type RepoFileController struct {
 //	@Tags			RepoFile
 //	@Param			name	path	string					true	"repo name"
 //	@Param			path	path	string					true	"repo file path"
+//	@Param			owner	query	string					false	"owner of repo, it is the user by default"
 //	@Param			body	body	RepoFileCreateRequest	true	"body of creating repo file"
 //	@Accept			json
 //	@Success		201
@@ -81,7 +104,12 @@ func (ctl *RepoFileController) Create(ctx *gin.Context) {
 		return
 	}
 
-	info, err := ctl.getRepoFileInfo(ctx, pl.DomainAccount())
+	repo, ok := ctl.getRepoForWriting(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	info, err := ctl.getRepoFileInfo(ctx, &repo)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
 			errorBadRequestParam, err,
@@ -118,6 +146,7 @@ func (ctl *RepoFileController) Create(ctx *gin.Context) {
 //	@Tags			RepoFile
 //	@Param			name	path	string					true	"repo name"
 //	@Param			path	path	string					true	"repo file path"
+//	@Param			owner	query	string					false	"owner of repo, it is the user by default"
 //	@Param			body	body	RepoFileUpdateRequest	true	"body of updating repo file"
 //	@Accept			json
 //	@Success		202
@@ -138,7 +167,12 @@ func (ctl *RepoFileController) Update(ctx *gin.Context) {
 		return
 	}
 
-	info, err := ctl.getRepoFileInfo(ctx, pl.DomainAccount())
+	repo, ok := ctl.getRepoForWriting(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	info, err := ctl.getRepoFileInfo(ctx, &repo)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
 			errorBadRequestParam, err,
@@ -174,6 +208,7 @@ func (ctl *RepoFileController) Update(ctx *gin.Context) {
 //	@Tags			RepoFile
 //	@Param			name	path	string	true	"repo name"
 //	@Param			path	path	string	true	"repo file path"
+//	@Param			owner	query	string	false	"owner of repo, it is the user by default"
 //	@Accept			json
 //	@Success		204
 //	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
@@ -185,7 +220,12 @@ func (ctl *RepoFileController) Delete(ctx *gin.Context) {
 		return
 	}
 
-	info, err := ctl.getRepoFileInfo(ctx, pl.DomainAccount())
+	repo, ok := ctl.getRepoForWriting(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	info, err := ctl.getRepoFileInfo(ctx, &repo)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
 			errorBadRequestParam, err,
@@ -210,6 +250,7 @@ func (ctl *RepoFileController) Delete(ctx *gin.Context) {
 //	@Tags			RepoFile
 //	@Param			name	path	string	true	"repo name"
 //	@Param			path	path	string	true	"repo dir"
+//	@Param			owner	query	string	false	"owner of repo, it is the user by default"
 //	@Accept			json
 //	@Success		204
 //	@Failure		400	bad_request_param	some	parameter	of	body	is	invalid
@@ -221,7 +262,12 @@ func (ctl *RepoFileController) DeleteDir(ctx *gin.Context) {
 		return
 	}
 
-	info, err := ctl.getRepoDirInfo(ctx, pl.DomainAccount())
+	repo, ok := ctl.getRepoForWriting(ctx, &pl)
+	if !ok {
+		return
+	}
+
+	info, err := ctl.getRepoDirInfo(ctx, &repo)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
 			errorBadRequestParam, err,
@@ -523,7 +569,7 @@ func (ctl *RepoFileController) checkForView(ctx *gin.Context) (
 		return
 	}
 
-	viewOther := visitor || pl.isNotMe(user)
+	viewOther := visitor || !ctl.canReadResource(&pl, repoInfo.resourceObject())
 
 	var viewReadme bool
 	if ctx.Param("path") == "" {
@@ -552,14 +598,33 @@ func (ctl *RepoFileController) checkForView(ctx *gin.Context) (
 	return
 }
 
-func (ctl *RepoFileController) getRepoDirInfo(ctx *gin.Context, user domain.Account) (
-	info app.RepoDirInfo, err error,
+func (ctl *RepoFileController) getRepoForWriting(ctx *gin.Context, pl *oldUserTokenPayload) (
+	repoInfo resourceSummary, ok bool,
 ) {
-	v, err := ctl.getRepoInfo(ctx, user)
+	owner, ok := ctl.parseOwner(ctx, pl)
+	if !ok {
+		return
+	}
+
+	repoInfo, err := ctl.getRepoInfo(ctx, owner)
 	if err != nil {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeError(
+			errorBadRequestParam, err,
+		))
+
+		ok = false
+
 		return
 	}
 
+	ok = ctl.checkResource(ctx, pl, repoInfo.resourceObject(), true)
+
+	return
+}
+
+func (ctl *RepoFileController) getRepoDirInfo(ctx *gin.Context, v *resourceSummary) (
+	info app.RepoDirInfo, err error,
+) {
 	info.RepoId = v.RepoId
 	info.RepoName = v.Name
 
@@ -568,14 +633,9 @@ func (ctl *RepoFileController) getRepoDirInfo(ctx *gin.Context, user domain.Acco
 	return
 }
 
-func (ctl *RepoFileController) getRepoFileInfo(ctx *gin.Context, user domain.Account) (
+func (ctl *RepoFileController) getRepoFileInfo(ctx *gin.Context, v *resourceSummary) (
 	info app.RepoFileInfo, err error,
 ) {
-	v, err := ctl.getRepoInfo(ctx, user)
-	if err != nil {
-		return
-	}
-
 	info.RepoId = v.RepoId
 
 	info.Path, err = domain.NewFilePath(ctx.Param("path"))
@@ -630,3 +690,13 @@ type resourceSummary struct {
 	rt domain.ResourceType
 	domain.ResourceSummary
 }
+
+func (s *resourceSummary) resourceObject() *domain.ResourceObject {
+	return &domain.ResourceObject{
+		Type: s.rt,
+		ResourceIndex: domain.ResourceIndex{
+			Owner: s.Owner,
+			Id:    s.Id,
+		},
+	}
+}
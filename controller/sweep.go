@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		CreateSweep
+//	@Description	create hyperparameter sweep of training.
+//	@Description	Every trial is a training of project, so the number of trials plus the existing trainings
+//	@Description	can't exceed the max training num of project, otherwise the error code is train_excced_max_num.
+//	@Description	The trials run regardless of the other trainings of project, at most concurrency ones at a time.
+//	@Tags			Training
+//	@Param			pid		path	string				true	"project id"
+//	@Param			owner	query	string				false	"owner of project, it is the user by default"
+//	@Param			body	body	SweepCreateRequest	true	"body of creating sweep"
+//	@Accept			json
+//	@Success		201	{object}			sweepCreateResp
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/sweep [post]
+func (ctl *TrainingController) CreateSweep(ctx *gin.Context) {
+	req := SweepCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := new(app.SweepCreateCmd)
+
+	if err := req.toCmd(cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, true)
+	if !ok {
+		return
+	}
+
+	if !ctl.setProjectInfo(ctx, &cmd.TrainingCreateCmd, owner, ctx.Param("pid")) {
+		return
+	}
+
+	if !ctl.setModelsInput(ctx, &cmd.TrainingCreateCmd, req.Models) {
+		return
+	}
+
+	if !ctl.setDatasetsInput(ctx, &cmd.TrainingCreateCmd, req.Datasets) {
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.sweep.Create(cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "create sweep",
+		fmt.Sprintf("projectid: %s, sweepid: %s", ctx.Param("pid"), v), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(sweepCreateResp{v}))
+}
+
+//	@Summary		ListSweeps
+//	@Description	list hyperparameter sweeps of project
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Accept			json
+//	@Success		200	{object}		app.SweepSummaryDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/sweep [get]
+func (ctl *TrainingController) ListSweeps(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.sweep.List(owner, ctx.Param("pid"))
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		GetSweep
+//	@Description	get the summary of sweep which ranks the trials by the metric
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			id	path	string	true	"sweep id"
+//	@Accept			json
+//	@Success		200	{object}		app.SweepDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/sweep/{id} [get]
+func (ctl *TrainingController) GetSweep(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.sweep.Get(&domain.SweepIndex{
+		Project: domain.ResourceIndex{
+			Owner: owner,
+			Id:    ctx.Param("pid"),
+		},
+		SweepId: ctx.Param("id"),
+	})
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
@@ -0,0 +1,79 @@
+package controller
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type sweepCreateResp struct {
+	Id string `json:"id"`
+}
+
+type SweepCreateRequest struct {
+	TrainingCreateRequest
+
+	Strategy    string           `json:"strategy"`
+	Parameters  []SweepParameter `json:"parameters"`
+	Metric      string           `json:"metric"`
+	Goal        string           `json:"goal"`
+	MaxTrials   int              `json:"max_trials"`
+	Concurrency int              `json:"concurrency"`
+}
+
+func (req *SweepCreateRequest) toCmd(cmd *app.SweepCreateCmd) (err error) {
+	if err = req.TrainingCreateRequest.toCmd(&cmd.TrainingCreateCmd); err != nil {
+		return
+	}
+
+	if cmd.Strategy, err = domain.NewSweepStrategy(req.Strategy); err != nil {
+		return
+	}
+
+	if cmd.Metric.Name, err = domain.NewCustomizedKey(req.Metric); err != nil {
+		return
+	}
+
+	if cmd.Metric.Goal, err = domain.NewSweepGoal(req.Goal); err != nil {
+		return
+	}
+
+	cmd.Parameters = make([]domain.SweepParameter, len(req.Parameters))
+	for i := range req.Parameters {
+		if cmd.Parameters[i], err = req.Parameters[i].toSweepParameter(); err != nil {
+			return
+		}
+	}
+
+	cmd.MaxTrials = req.MaxTrials
+	cmd.Concurrency = req.Concurrency
+
+	return
+}
+
+type SweepParameter struct {
+	Key    string   `json:"key"`
+	Values []string `json:"values"`
+}
+
+func (p *SweepParameter) toSweepParameter() (r domain.SweepParameter, err error) {
+	if p.Key == "" || len(p.Values) == 0 {
+		err = errors.New("invalid sweep parameter")
+
+		return
+	}
+
+	if r.Key, err = domain.NewCustomizedKey(p.Key); err != nil {
+		return
+	}
+
+	r.Values = make([]domain.CustomizedValue, len(p.Values))
+	for i := range p.Values {
+		if r.Values[i], err = domain.NewCustomizedValue(p.Values[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
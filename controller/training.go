@@ -9,10 +9,15 @@ import (
 	"github.com/gorilla/websocket"
 
 	"github.com/opensourceways/xihe-server/app"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/domain/message"
+	"github.com/opensourceways/xihe-server/domain/platform"
 	"github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/opensourceways/xihe-server/domain/training"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	userdomain "github.com/opensourceways/xihe-server/user/domain"
+	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
 
@@ -20,47 +25,132 @@ func AddRouterForTrainingController(
 	rg *gin.RouterGroup,
 	ts training.Training,
 	repo repository.Training,
+	sweep repository.Sweep,
+	metric repository.TrainingMetric,
+	lineage repository.ModelLineage,
+	user userrepo.User,
 	model repository.Model,
 	project repository.Project,
 	dataset repository.Dataset,
+	activity repository.Activity,
+	rf platform.RepoFile,
 	sender message.Sender,
+	newPlatformRepository func(token, namespace string) platform.Repository,
+	org orgapp.OrgService,
+	collaborator collaboratorapp.CollaboratorService,
+	schedule repository.TrainingSchedule,
+	evaluate repository.Evaluate,
+	report repository.EvaluateReport,
+	checker *AccessTokenChecker,
 ) {
 	ctl := TrainingController{
+		resourcePermission: resourcePermission{
+			org:          org,
+			collaborator: collaborator,
+		},
+
 		ts: app.NewTrainingService(
-			log, ts, repo, sender, apiConfig.MaxTrainingRecordNum,
+			log, ts, repo, metric, sender, apiConfig.MaxTrainingRecordNum,
+		),
+		sweep: app.NewSweepService(
+			log, ts, repo, sweep, metric, sender, apiConfig.MaxTrainingRecordNum,
+		),
+		metric:  app.NewTrainingMetricService(metric, repo),
+		compare: app.NewTrainingCompareService(ts, repo, metric),
+		publish: app.NewTrainingPublishService(
+			log, ts, repo, lineage, rf,
+			app.NewModelService(user, model, project, dataset, activity, nil, sender), model,
+			app.NewProjectService(user, project, model, dataset, activity, nil, nil, sender), project,
+			dataset,
+		),
+		schedule: app.NewTrainingScheduleService(
+			log, ts, repo, schedule, sender, apiConfig.MaxTrainingRecordNum,
 		),
+		report:  app.NewEvaluateReportService(ts, repo, evaluate, report),
 		model:   model,
 		project: project,
 		dataset: dataset,
+
+		newPlatformRepository: newPlatformRepository,
 	}
 
-	rg.POST("/v1/train/project/:pid/training", checkUserEmailMiddleware(&ctl.baseController), ctl.Create)
+	rg.POST(
+		"/v1/train/project/:pid/training",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeTraining),
+		checkUserEmailMiddleware(&ctl.baseController), ctl.Create,
+	)
 	rg.POST("/v1/train/project/:pid/training/:id", ctl.Recreate)
 	rg.PUT("/v1/train/project/:pid/training/:id", ctl.Terminate)
+	rg.POST("/v1/train/project/:pid/training/:id/cancel", ctl.Cancel)
 	rg.GET("/v1/train/project/:pid/training", checkUserEmailMiddleware(&ctl.baseController), ctl.List)
 	rg.GET("/v1/train/project/:pid/training/ws", ctl.ListByWS)
+	rg.GET("/v1/train/project/:pid/training/compare", ctl.Compare)
 	rg.GET(
 		"/v1/train/project/:pid/training/:id/result/:type", checkUserEmailMiddleware(&ctl.baseController),
 		ctl.GetResultDownloadURL,
 	)
 	rg.GET("/v1/train/project/:pid/training/:id", ctl.Get)
+	rg.GET("/v1/train/project/:pid/training/:id/metrics", ctl.GetMetrics)
+	rg.POST(
+		"/v1/train/project/:pid/training/:id/publish",
+		checkUserEmailMiddleware(&ctl.baseController), ctl.Publish,
+	)
 	rg.DELETE("v1/train/project/:pid/training/:id", ctl.Delete)
+
+	rg.POST(
+		"/v1/train/project/:pid/sweep",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeTraining),
+		checkUserEmailMiddleware(&ctl.baseController), ctl.CreateSweep,
+	)
+	rg.GET("/v1/train/project/:pid/sweep", ctl.ListSweeps)
+	rg.GET("/v1/train/project/:pid/sweep/:id", ctl.GetSweep)
+
+	rg.POST(
+		"/v1/train/project/:pid/training/:id/schedule",
+		checkUserEmailMiddleware(&ctl.baseController), ctl.CreateSchedule,
+	)
+	rg.GET("/v1/train/project/:pid/schedule", ctl.ListSchedules)
+	rg.PUT("/v1/train/project/:pid/schedule/:id", ctl.UpdateSchedule)
+	rg.DELETE("/v1/train/project/:pid/schedule/:id", ctl.DeleteSchedule)
+	rg.GET("/v1/train/project/:pid/schedule/:id/runs", ctl.ListScheduleRuns)
+
+	rg.POST(
+		"/v1/train/project/:pid/training/:id/evaluate/:eid/report",
+		allowAccessTokenMiddleware(&ctl.baseController, checker, userdomain.AccessTokenScopeTraining),
+		ctl.AddEvaluateReport,
+	)
+	rg.GET("/v1/train/project/:pid/training/:id/evaluate/:eid/report", ctl.GetEvaluateReport)
+	rg.GET(
+		"/v1/train/project/:pid/training/:id/evaluate/:eid/report/export",
+		ctl.ExportEvaluateReport,
+	)
 }
 
 type TrainingController struct {
 	baseController
 
-	ts app.TrainingService
+	resourcePermission
+
+	ts       app.TrainingService
+	sweep    app.SweepService
+	metric   app.TrainingMetricService
+	compare  app.TrainingCompareService
+	publish  app.TrainingPublishService
+	schedule app.TrainingScheduleService
+	report   app.EvaluateReportService
 
 	model   repository.Model
 	project repository.Project
 	dataset repository.Dataset
+
+	newPlatformRepository func(string, string) platform.Repository
 }
 
 //	@Summary		Create
 //	@Description	create training
 //	@Tags			Training
 //	@Param			pid		path	string					true	"project id"
+//	@Param			owner	query	string					false	"owner of project, it is the user by default"
 //	@Param			body	body	TrainingCreateRequest	true	"body of creating training"
 //	@Accept			json
 //	@Success		201	{object}			trainingCreateResp
@@ -91,7 +181,12 @@ func (ctl *TrainingController) Create(ctx *gin.Context) {
 		return
 	}
 
-	if !ctl.setProjectInfo(ctx, cmd, pl.DomainAccount(), ctx.Param("pid")) {
+	owner, ok := ctl.getProjectOwner(ctx, &pl, true)
+	if !ok {
+		return
+	}
+
+	if !ctl.setProjectInfo(ctx, cmd, owner, ctx.Param("pid")) {
 		return
 	}
 
@@ -208,6 +303,34 @@ func (ctl *TrainingController) Terminate(ctx *gin.Context) {
 	ctx.JSON(http.StatusAccepted, newResponseData("success"))
 }
 
+//	@Summary		Cancel
+//	@Description	cancel the training which is waiting in the queue
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			id	path	string	true	"training id"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	train_not_queued	the		training	is	not	queued
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/cancel [post]
+func (ctl *TrainingController) Cancel(ctx *gin.Context) {
+	info, ok := ctl.getTrainingInfo(ctx)
+	if !ok {
+		return
+	}
+
+	if code, err := ctl.ts.Cancel(&info); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", info.Project.Owner.Account(), "cancel training",
+		fmt.Sprintf("projectid: %s, trainingid: %s", info.Project.Id, info.TrainingId), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
 //	@Summary		Get
 //	@Description	get training info
 //	@Tags			Training
@@ -223,9 +346,14 @@ func (ctl *TrainingController) Get(ctx *gin.Context) {
 		return
 	}
 
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
 	index := domain.TrainingIndex{
 		Project: domain.ResourceIndex{
-			Owner: pl.DomainAccount(),
+			Owner: owner,
 			Id:    ctx.Param("pid"),
 		},
 		TrainingId: ctx.Param("id"),
@@ -329,7 +457,12 @@ func (ctl *TrainingController) List(ctx *gin.Context) {
 		return
 	}
 
-	v, err := ctl.ts.List(pl.DomainAccount(), ctx.Param("pid"))
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.ts.List(owner, ctx.Param("pid"))
 	if err != nil {
 		ctl.sendRespWithInternalError(ctx, newResponseError(err))
 
@@ -353,6 +486,11 @@ func (ctl *TrainingController) ListByWS(ctx *gin.Context) {
 		return
 	}
 
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
 	pid := ctx.Param("pid")
 
 	// setup websocket
@@ -372,7 +510,7 @@ func (ctl *TrainingController) ListByWS(ctx *gin.Context) {
 
 	defer ws.Close()
 
-	ctl.watchTrainings(ws, pl.DomainAccount(), pid)
+	ctl.watchTrainings(ws, owner, pid)
 }
 
 func (ctl *TrainingController) watchTrainings(ws *websocket.Conn, user domain.Account, pid string) {
@@ -468,9 +606,14 @@ func (ctl *TrainingController) GetResultDownloadURL(ctx *gin.Context) {
 		return
 	}
 
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
 	info := domain.TrainingIndex{
 		Project: domain.ResourceIndex{
-			Owner: pl.DomainAccount(),
+			Owner: owner,
 			Id:    ctx.Param("pid"),
 		},
 		TrainingId: ctx.Param("id"),
@@ -507,11 +650,38 @@ func (ctl *TrainingController) getTrainingInfo(ctx *gin.Context) (domain.Trainin
 		return domain.TrainingIndex{}, ok
 	}
 
+	owner, ok := ctl.getProjectOwner(ctx, &pl, true)
+	if !ok {
+		return domain.TrainingIndex{}, ok
+	}
+
 	return domain.TrainingIndex{
 		Project: domain.ResourceIndex{
-			Owner: pl.DomainAccount(),
+			Owner: owner,
 			Id:    ctx.Param("pid"),
 		},
 		TrainingId: ctx.Param("id"),
 	}, true
 }
+
+// getProjectOwner returns the owner of project which may be an organization
+// or other user who has added the current user as a collaborator.
+func (ctl *TrainingController) getProjectOwner(
+	ctx *gin.Context, pl *oldUserTokenPayload, write bool,
+) (owner domain.Account, ok bool) {
+	if owner, ok = ctl.parseOwner(ctx, pl); !ok {
+		return
+	}
+
+	obj := domain.ResourceObject{
+		Type: domain.ResourceTypeProject,
+		ResourceIndex: domain.ResourceIndex{
+			Owner: owner,
+			Id:    ctx.Param("pid"),
+		},
+	}
+
+	ok = ctl.checkResource(ctx, pl, &obj, write)
+
+	return
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+//	@Summary		Compare
+//	@Description	compare the config, result and last metrics of trainings
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			ids	query	string	true	"ids of trainings separated by comma"
+//	@Accept			json
+//	@Success		200	{object}			app.TrainingCompareDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/compare [get]
+func (ctl *TrainingController) Compare(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingCompareCmd{
+		Project: domain.ResourceIndex{
+			Owner: owner,
+			Id:    ctx.Param("pid"),
+		},
+	}
+
+	if v := ctx.Query("ids"); v != "" {
+		cmd.TrainingIds = strings.Split(v, ",")
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.compare.Compare(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+//	@Summary		GetMetrics
+//	@Description	get the downsampled metric series of training
+//	@Tags			Training
+//	@Param			pid			path	string	true	"project id"
+//	@Param			id			path	string	true	"training id"
+//	@Param			names		query	string	false	"names of metrics separated by comma, all by default"
+//	@Param			max_points	query	int		false	"max num of points of each metric, 500 by default"
+//	@Accept			json
+//	@Success		200	{object}			app.TrainingMetricSeriesDTO
+//	@Failure		400	bad_request_param	some	parameter	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/metrics [get]
+func (ctl *TrainingController) GetMetrics(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingMetricGetCmd{
+		Index: domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: owner,
+				Id:    ctx.Param("pid"),
+			},
+			TrainingId: ctx.Param("id"),
+		},
+	}
+
+	if v := ctx.Query("names"); v != "" {
+		cmd.Names = strings.Split(v, ",")
+	}
+
+	if v := ctx.Query("max_points"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			ctl.sendBadRequestParamWithMsg(ctx, "invalid max_points")
+
+			return
+		}
+
+		cmd.MaxPoints = n
+	}
+
+	v, err := ctl.metric.Get(&cmd)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		Publish
+//	@Description	publish the output of training as a model
+//	@Tags			Training
+//	@Param			pid		path	string					true	"project id"
+//	@Param			id		path	string					true	"training id"
+//	@Param			body	body	TrainingPublishRequest	true	"body of publishing training"
+//	@Accept			json
+//	@Success		201	{object}			app.TrainingPublishDTO
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/publish [post]
+func (ctl *TrainingController) Publish(ctx *gin.Context) {
+	req := TrainingPublishRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingPublishCmd{
+		User: pl.PlatformUserInfo(),
+	}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, true)
+	if !ok {
+		return
+	}
+
+	// the model is owned by the owner of project, so the collaborators
+	// of project can't publish unless they can write the models of owner.
+	if !ctl.canWrite(&pl, owner) {
+		ctx.JSON(http.StatusBadRequest, newResponseCodeMsg(
+			errorNotAllowed, "not allowed",
+		))
+
+		return
+	}
+
+	cmd.Index = domain.TrainingIndex{
+		Project: domain.ResourceIndex{
+			Owner: owner,
+			Id:    ctx.Param("pid"),
+		},
+		TrainingId: ctx.Param("id"),
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	namespace, err := ctl.platformNamespace(&pl, owner)
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	v, code, err := ctl.publish.Publish(
+		&cmd, ctl.newPlatformRepository(pl.PlatformToken, namespace),
+	)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", pl.Account, "publish training",
+		fmt.Sprintf(
+			"projectid: %s, trainingid: %s, model: %s/%s, version: %d",
+			cmd.Index.Project.Id, cmd.Index.TrainingId, v.Owner, v.Name, v.Version,
+		), "success",
+	)
+
+	ctx.JSON(http.StatusCreated, newResponseData(v))
+}
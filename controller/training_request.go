@@ -391,3 +391,82 @@ func (ctl *TrainingController) setDatasetsInput(
 
 	return
 }
+
+type TrainingPublishRequest struct {
+	Name     string `json:"name"`
+	Desc     string `json:"desc"`
+	Title    string `json:"title"`
+	Protocol string `json:"protocol"`
+	RepoType string `json:"repo_type"`
+}
+
+func (req *TrainingPublishRequest) toCmd(cmd *app.TrainingPublishCmd) (err error) {
+	if cmd.Name, err = domain.NewResourceName(req.Name); err != nil {
+		return
+	}
+
+	if cmd.Desc, err = domain.NewResourceDesc(req.Desc); err != nil {
+		return
+	}
+
+	if req.Title == "" {
+		req.Title = req.Name
+	}
+
+	if cmd.Title, err = domain.NewResourceTitle(req.Title); err != nil {
+		return
+	}
+
+	if cmd.Protocol, err = domain.NewProtocolName(req.Protocol); err != nil {
+		return
+	}
+
+	cmd.RepoType, err = domain.NewRepoType(req.RepoType)
+
+	return
+}
+
+type trainingScheduleCreateResp struct {
+	Id string `json:"id"`
+}
+
+type TrainingScheduleCreateRequest struct {
+	// Cron is the standard cron expression of 5 fields in UTC.
+	Cron   string `json:"cron"`
+	Policy string `json:"policy"`
+}
+
+func (req *TrainingScheduleCreateRequest) toCmd(cmd *app.TrainingScheduleCreateCmd) (err error) {
+	if cmd.Cron, err = domain.NewCronExpr(req.Cron); err != nil {
+		return
+	}
+
+	cmd.Policy, err = domain.NewTrainingSchedulePolicy(req.Policy)
+
+	return
+}
+
+type TrainingScheduleUpdateRequest struct {
+	// Cron is the standard cron expression of 5 fields in UTC.
+	Cron    *string `json:"cron"`
+	Policy  *string `json:"policy"`
+	Enabled *bool   `json:"enabled"`
+}
+
+func (req *TrainingScheduleUpdateRequest) toCmd(cmd *app.TrainingScheduleUpdateCmd) (err error) {
+	if req.Cron != nil {
+		if cmd.Cron, err = domain.NewCronExpr(*req.Cron); err != nil {
+			return
+		}
+	}
+
+	if req.Policy != nil {
+		if cmd.Policy, err = domain.NewTrainingSchedulePolicy(*req.Policy); err != nil {
+			return
+		}
+	}
+
+	cmd.Enabled = req.Enabled
+
+	return
+}
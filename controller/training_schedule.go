@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/opensourceways/xihe-server/app"
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+//	@Summary		CreateSchedule
+//	@Description	create a cron schedule which recreates the training periodically
+//	@Description	with its config at this moment. The run will be skipped if the
+//	@Description	trainings of project reach the max num. The cron is evaluated in UTC.
+//	@Tags			Training
+//	@Param			pid		path	string							true	"project id"
+//	@Param			id		path	string							true	"training id"
+//	@Param			body	body	TrainingScheduleCreateRequest	true	"body of creating schedule"
+//	@Accept			json
+//	@Success		201	{object}			trainingScheduleCreateResp
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/training/{id}/schedule [post]
+func (ctl *TrainingController) CreateSchedule(ctx *gin.Context) {
+	req := TrainingScheduleCreateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	info, ok := ctl.getTrainingInfo(ctx)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingScheduleCreateCmd{Training: info}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	v, code, err := ctl.schedule.Create(&cmd)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", info.Project.Owner.Account(), "create training schedule",
+		fmt.Sprintf("projectid: %s, trainingid: %s, scheduleid: %s",
+			info.Project.Id, info.TrainingId, v), "success")
+
+	ctx.JSON(http.StatusCreated, newResponseData(trainingScheduleCreateResp{v}))
+}
+
+//	@Summary		UpdateSchedule
+//	@Description	update the cron, policy or status of training schedule. The cron is evaluated in UTC.
+//	@Tags			Training
+//	@Param			pid		path	string							true	"project id"
+//	@Param			id		path	string							true	"schedule id"
+//	@Param			body	body	TrainingScheduleUpdateRequest	true	"body of updating schedule"
+//	@Accept			json
+//	@Success		202
+//	@Failure		400	bad_request_body	can't	parse		request	body
+//	@Failure		401	bad_request_param	some	parameter	of		body	is	invalid
+//	@Failure		500	system_error		system	error
+//	@Router			/v1/train/project/{pid}/schedule/{id} [put]
+func (ctl *TrainingController) UpdateSchedule(ctx *gin.Context) {
+	req := TrainingScheduleUpdateRequest{}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctl.sendBadRequestBody(ctx)
+
+		return
+	}
+
+	index, ok := ctl.getTrainingScheduleIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	cmd := app.TrainingScheduleUpdateCmd{Index: index}
+
+	if err := req.toCmd(&cmd); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if err := cmd.Validate(); err != nil {
+		ctl.sendBadRequestParam(ctx, err)
+
+		return
+	}
+
+	if code, err := ctl.schedule.Update(&cmd); err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+
+		return
+	}
+
+	utils.DoLog("", index.Project.Owner.Account(), "update training schedule",
+		fmt.Sprintf("projectid: %s, scheduleid: %s", index.Project.Id, index.ScheduleId), "success")
+
+	ctx.JSON(http.StatusAccepted, newResponseData("success"))
+}
+
+//	@Summary		DeleteSchedule
+//	@Description	delete training schedule
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			id	path	string	true	"schedule id"
+//	@Accept			json
+//	@Success		204
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/schedule/{id} [delete]
+func (ctl *TrainingController) DeleteSchedule(ctx *gin.Context) {
+	index, ok := ctl.getTrainingScheduleIndex(ctx, true)
+	if !ok {
+		return
+	}
+
+	if err := ctl.schedule.Delete(&index); err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+
+		return
+	}
+
+	utils.DoLog("", index.Project.Owner.Account(), "delete training schedule",
+		fmt.Sprintf("projectid: %s, scheduleid: %s", index.Project.Id, index.ScheduleId), "success")
+
+	ctx.JSON(http.StatusNoContent, newResponseData("success"))
+}
+
+//	@Summary		ListSchedules
+//	@Description	list the training schedules of project
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Accept			json
+//	@Success		200	{object}		app.TrainingScheduleDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/schedule [get]
+func (ctl *TrainingController) ListSchedules(ctx *gin.Context) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, false)
+	if !ok {
+		return
+	}
+
+	v, err := ctl.schedule.List(&domain.ResourceIndex{
+		Owner: owner,
+		Id:    ctx.Param("pid"),
+	})
+	if err != nil {
+		ctl.sendRespWithInternalError(ctx, newResponseError(err))
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+//	@Summary		ListScheduleRuns
+//	@Description	list the history of training schedule being triggered
+//	@Tags			Training
+//	@Param			pid	path	string	true	"project id"
+//	@Param			id	path	string	true	"schedule id"
+//	@Accept			json
+//	@Success		200	{object}		app.TrainingScheduleRunDTO
+//	@Failure		500	system_error	system	error
+//	@Router			/v1/train/project/{pid}/schedule/{id}/runs [get]
+func (ctl *TrainingController) ListScheduleRuns(ctx *gin.Context) {
+	index, ok := ctl.getTrainingScheduleIndex(ctx, false)
+	if !ok {
+		return
+	}
+
+	v, code, err := ctl.schedule.ListRuns(&index)
+	if err != nil {
+		ctl.sendCodeMessage(ctx, code, err)
+	} else {
+		ctl.sendRespOfGet(ctx, v)
+	}
+}
+
+func (ctl *TrainingController) getTrainingScheduleIndex(ctx *gin.Context, write bool) (
+	domain.TrainingScheduleIndex, bool,
+) {
+	pl, _, ok := ctl.checkUserApiToken(ctx, false)
+	if !ok {
+		return domain.TrainingScheduleIndex{}, ok
+	}
+
+	owner, ok := ctl.getProjectOwner(ctx, &pl, write)
+	if !ok {
+		return domain.TrainingScheduleIndex{}, ok
+	}
+
+	return domain.TrainingScheduleIndex{
+		Project: domain.ResourceIndex{
+			Owner: owner,
+			Id:    ctx.Param("pid"),
+		},
+		ScheduleId: ctx.Param("id"),
+	}, true
+}
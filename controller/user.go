@@ -10,9 +10,11 @@ import (
 	"github.com/opensourceways/xihe-server/domain/authing"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/platform"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
 	userapp "github.com/opensourceways/xihe-server/user/app"
 	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
 	userlogincli "github.com/opensourceways/xihe-server/user/infrastructure/logincli"
+	userorgcli "github.com/opensourceways/xihe-server/user/infrastructure/orgcli"
 )
 
 func AddRouterForUserController(
@@ -22,14 +24,19 @@ func AddRouterForUserController(
 	auth authing.User,
 	login app.LoginService,
 	sender message.Sender,
+	token userrepo.AccessToken,
+	org orgapp.OrgService,
 ) {
 
-	us := userapp.NewUserService(repo, ps, sender, encryptHelperToken)
+	us := userapp.NewUserService(
+		repo, ps, sender, encryptHelperToken, userorgcli.NewOrgCli(org),
+	)
 
 	ctl := UserController{
-		auth: auth,
-		repo: repo,
-		s:    us,
+		auth:  auth,
+		repo:  repo,
+		s:     us,
+		token: userapp.NewAccessTokenService(token),
 		email: userapp.NewEmailService(
 			auth, userlogincli.NewLoginCli(login),
 			us,
@@ -53,6 +60,11 @@ func AddRouterForUserController(
 	rg.GET("/v1/user/check_email", checkUserEmailMiddleware(&ctl.baseController))
 	rg.POST("/v1/user/email/sendbind", ctl.SendBindEmail)
 	rg.POST("/v1/user/email/bind", ctl.BindEmail)
+
+	// personal access token
+	rg.POST("/v1/user/tokens", ctl.CreateAccessToken)
+	rg.GET("/v1/user/tokens", ctl.ListAccessTokens)
+	rg.DELETE("/v1/user/tokens/:id", ctl.DeleteAccessToken)
 }
 
 type UserController struct {
@@ -62,6 +74,7 @@ type UserController struct {
 	auth  authing.User
 	s     userapp.UserService
 	email userapp.EmailService
+	token userapp.AccessTokenService
 }
 
 // @Summary		Create
@@ -105,3 +105,31 @@ func (req *EmailSend) toCmd(user domain.Account) (cmd app.SendBindEmailCmd, err
 
 	return
 }
+
+type accessTokenCreateRequest struct {
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiryDays int      `json:"expiry_days"`
+}
+
+func (req *accessTokenCreateRequest) toCmd(owner domain.Account) (
+	cmd app.AccessTokenCreateCmd, err error,
+) {
+	if cmd.Name, err = domain.NewAccessTokenName(req.Name); err != nil {
+		return
+	}
+
+	cmd.Scopes = make([]domain.AccessTokenScope, len(req.Scopes))
+	for i := range req.Scopes {
+		if cmd.Scopes[i], err = domain.NewAccessTokenScope(req.Scopes[i]); err != nil {
+			return
+		}
+	}
+
+	cmd.Owner = owner
+	cmd.ExpiryDays = req.ExpiryDays
+
+	err = cmd.Validate()
+
+	return
+}
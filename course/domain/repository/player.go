@@ -16,4 +16,6 @@ type Player interface {
 	PlayerCount(cid string) (int, error)
 	SaveRepo(courseId string, a *domain.CourseProject, version int) error
 	FindCoursesUserApplied(types.Account) ([]string, error)
+	FindCoursesOfRepo(repo string) ([]string, error)
+	FindReposOfCourse(cid string) ([]string, error)
 }
@@ -169,3 +169,48 @@ func (impl *playerRepoImpl) FindCoursesUserApplied(u types.Account) (
 
 	return
 }
+
+func (impl *playerRepoImpl) FindCoursesOfRepo(repo string) (
+	cs []string, err error) {
+	var v []DCoursePlayer
+
+	f := func(ctx context.Context) error {
+		filter := bson.M{fieldRepo: repo}
+		return impl.cli.GetDocs(ctx, filter, bson.M{fieldCourseId: 1}, &v)
+	}
+
+	if err = withContext(f); err != nil || len(v) == 0 {
+		return
+	}
+
+	cs = make([]string, len(v))
+	for i := range v {
+		cs[i] = v[i].CourseId
+	}
+
+	return
+}
+
+func (impl *playerRepoImpl) FindReposOfCourse(cid string) (
+	rs []string, err error) {
+	var v []DCoursePlayer
+
+	f := func(ctx context.Context) error {
+		filter := bson.M{
+			fieldCourseId: cid,
+			fieldRepo:     bson.M{"$nin": bson.A{nil, ""}},
+		}
+		return impl.cli.GetDocs(ctx, filter, bson.M{fieldRepo: 1}, &v)
+	}
+
+	if err = withContext(f); err != nil || len(v) == 0 {
+		return
+	}
+
+	rs = make([]string, len(v))
+	for i := range v {
+		rs[i] = v[i].Repo
+	}
+
+	return
+}
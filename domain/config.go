@@ -27,6 +27,8 @@ type Config struct {
 	MaxDescLength         int `json:"max_desc_length"`
 	MaxNicknameLength     int `json:"max_nickname_length"`
 	MaxRelatedResourceNum int `json:"max_related_resource_num"`
+	MaxOrgMemberNum       int `json:"max_org_member_num"`
+	MaxCollaboratorNum    int `json:"max_collaborator_num"`
 
 	Covers           []string `json:"covers"            required:"true"`
 	Protocols        []string `json:"protocols"         required:"true"`
@@ -37,6 +39,16 @@ type Config struct {
 	MaxTrainingNameLength int `json:"max_training_name_length"`
 	MinTrainingNameLength int `json:"min_training_name_length"`
 	MaxTrainingDescLength int `json:"max_training_desc_length"`
+	MaxSweepTrialNum      int `json:"max_sweep_trial_num"`
+	MaxSweepConcurrency   int `json:"max_sweep_concurrency"`
+
+	MaxTrainingMetricPointNum int `json:"max_training_metric_point_num"`
+
+	MaxTrainingScheduleNum int `json:"max_training_schedule_num"`
+	// MaxTrainingScheduleRunNum is the max num of runs kept for each schedule.
+	MaxTrainingScheduleRunNum int `json:"max_training_schedule_run_num"`
+	// MinTrainingScheduleInterval is the min seconds between two runs of a schedule.
+	MinTrainingScheduleInterval int `json:"min_training_schedule_interval"`
 
 	WuKongPictureMaxDescLength int `json:"wukong_picture_max_desc_length"`
 
@@ -65,6 +77,14 @@ func (cfg *Config) SetDefault() {
 		cfg.MaxRelatedResourceNum = 5
 	}
 
+	if cfg.MaxOrgMemberNum <= 0 {
+		cfg.MaxOrgMemberNum = 100
+	}
+
+	if cfg.MaxCollaboratorNum <= 0 {
+		cfg.MaxCollaboratorNum = 50
+	}
+
 	if cfg.MaxNicknameLength == 0 {
 		cfg.MaxNicknameLength = 20
 	}
@@ -85,6 +105,30 @@ func (cfg *Config) SetDefault() {
 		cfg.MaxTrainingDescLength = 100
 	}
 
+	if cfg.MaxSweepTrialNum <= 0 {
+		cfg.MaxSweepTrialNum = 20
+	}
+
+	if cfg.MaxSweepConcurrency <= 0 {
+		cfg.MaxSweepConcurrency = 3
+	}
+
+	if cfg.MaxTrainingMetricPointNum <= 0 {
+		cfg.MaxTrainingMetricPointNum = 10000
+	}
+
+	if cfg.MaxTrainingScheduleNum <= 0 {
+		cfg.MaxTrainingScheduleNum = 5
+	}
+
+	if cfg.MaxTrainingScheduleRunNum <= 0 {
+		cfg.MaxTrainingScheduleRunNum = 100
+	}
+
+	if cfg.MinTrainingScheduleInterval <= 0 {
+		cfg.MinTrainingScheduleInterval = 3600
+	}
+
 	if cfg.WuKongPictureMaxDescLength <= 0 {
 		cfg.WuKongPictureMaxDescLength = 75
 	}
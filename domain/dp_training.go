@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -218,3 +219,122 @@ type customizedValue string
 func (r customizedValue) CustomizedValue() string {
 	return string(r)
 }
+
+// SweepStrategy
+type SweepStrategy interface {
+	SweepStrategy() string
+	IsGrid() bool
+}
+
+func NewSweepStrategy(v string) (SweepStrategy, error) {
+	if v != SweepStrategyGrid && v != SweepStrategyRandom {
+		return nil, errors.New("invalid sweep strategy")
+	}
+
+	return sweepStrategy(v), nil
+}
+
+type sweepStrategy string
+
+func (r sweepStrategy) SweepStrategy() string {
+	return string(r)
+}
+
+func (r sweepStrategy) IsGrid() bool {
+	return string(r) == SweepStrategyGrid
+}
+
+// SweepGoal
+type SweepGoal interface {
+	SweepGoal() string
+	IsMaximize() bool
+}
+
+func NewSweepGoal(v string) (SweepGoal, error) {
+	if v == "" {
+		v = SweepGoalMinimize
+	}
+
+	if v != SweepGoalMinimize && v != SweepGoalMaximize {
+		return nil, errors.New("invalid sweep goal")
+	}
+
+	return sweepGoal(v), nil
+}
+
+type sweepGoal string
+
+func (r sweepGoal) SweepGoal() string {
+	return string(r)
+}
+
+func (r sweepGoal) IsMaximize() bool {
+	return string(r) == SweepGoalMaximize
+}
+
+// CronExpr
+type CronExpr interface {
+	CronExpr() string
+	// Next returns the first time in UTC after t, or 0 if there is not one.
+	Next(t int64) int64
+}
+
+func NewCronExpr(v string) (CronExpr, error) {
+	v = strings.Join(strings.Fields(v), " ")
+
+	fields, err := parseCron(v)
+	if err != nil {
+		return nil, err
+	}
+
+	r := cronExpr{expr: v, fields: fields}
+
+	if r.Next(utils.Now()) == 0 {
+		return nil, errors.New("cron expression will never be satisfied")
+	}
+
+	return r, nil
+}
+
+type cronExpr struct {
+	expr   string
+	fields [5]cronField
+}
+
+func (r cronExpr) CronExpr() string {
+	return r.expr
+}
+
+func (r cronExpr) Next(t int64) int64 {
+	return nextCronTime(&r.fields, t)
+}
+
+// TrainingSchedulePolicy
+type TrainingSchedulePolicy interface {
+	TrainingSchedulePolicy() string
+	// IsSkip is true if the triggered training should be skipped
+	// when there is another training not done, otherwise it is queued.
+	IsSkip() bool
+}
+
+func NewTrainingSchedulePolicy(v string) (TrainingSchedulePolicy, error) {
+	if v == "" {
+		v = TrainingSchedulePolicySkip
+	}
+
+	if v != TrainingSchedulePolicySkip && v != TrainingSchedulePolicyQueue {
+		return nil, errors.New("invalid training schedule policy")
+	}
+
+	return trainingSchedulePolicy(v), nil
+}
+
+type trainingSchedulePolicy string
+
+func (r trainingSchedulePolicy) TrainingSchedulePolicy() string {
+	return string(r)
+}
+
+func (r trainingSchedulePolicy) IsSkip() bool {
+	return string(r) == TrainingSchedulePolicySkip
+}
@@ -1,5 +1,11 @@
 package domain
 
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
 const (
 	EvaluateTypeCustom   = "custom"
 	EvaluateTypeStandard = "standard"
@@ -30,3 +36,154 @@ type EvaluateIndex struct {
 
 	Id string
 }
+
+// EvaluateParms is a combination of the hyperparameters of standard evaluation.
+type EvaluateParms struct {
+	Momentum     string
+	BatchSize    string
+	LearningRate string
+}
+
+func (p *EvaluateParms) Key() string {
+	return p.Momentum + "/" + p.BatchSize + "/" + p.LearningRate
+}
+
+// Combinations returns all the combinations of the hyperparameters.
+// It returns a combination of empty values if all the scopes are empty,
+// which is the case of custom evaluation.
+func (p *StandardEvaluateParms) Combinations() []EvaluateParms {
+	scope := func(v EvaluateScope) EvaluateScope {
+		if len(v) == 0 {
+			return EvaluateScope{""}
+		}
+
+		return v
+	}
+
+	r := []EvaluateParms{}
+
+	for _, m := range scope(p.MomentumScope) {
+		for _, b := range scope(p.BatchSizeScope) {
+			for _, l := range scope(p.LearningRateScope) {
+				r = append(r, EvaluateParms{
+					Momentum:     m,
+					BatchSize:    b,
+					LearningRate: l,
+				})
+			}
+		}
+	}
+
+	return r
+}
+
+func (p *StandardEvaluateParms) Contains(v *EvaluateParms) bool {
+	key := v.Key()
+
+	for _, item := range p.Combinations() {
+		if item.Key() == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+type EvaluateMetric struct {
+	Name  CustomizedKey
+	Value float64
+}
+
+// EvaluateResult is the metrics evaluated with a combination of hyperparameters.
+type EvaluateResult struct {
+	Parms   EvaluateParms
+	Metrics []EvaluateMetric
+}
+
+// EvaluateArtifact is a file generated by the evaluation, such as a chart.
+type EvaluateArtifact struct {
+	Name string
+	// Path is the path of file in the object storage of training.
+	Path string
+}
+
+// IsUnder checks whether the artifact is in one of the directories.
+func (a *EvaluateArtifact) IsUnder(dirs ...string) bool {
+	p := path.Clean(a.Path)
+
+	for _, dir := range dirs {
+		if dir == "" {
+			continue
+		}
+
+		if dir = path.Clean(dir); dir != "." && strings.HasPrefix(p, dir+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EvaluateReport stores the results of evaluation which will be
+// available after the evaluate instance exits.
+type EvaluateReport struct {
+	EvaluateIndex
+
+	Results   []EvaluateResult
+	Artifacts []EvaluateArtifact
+	UpdatedAt int64
+}
+
+// AddResults adds the results, and the result of same combination
+// of hyperparameters will be replaced.
+func (r *EvaluateReport) AddResults(v []EvaluateResult) {
+	index := make(map[string]int, len(r.Results))
+	for i := range r.Results {
+		index[r.Results[i].Parms.Key()] = i
+	}
+
+	for i := range v {
+		if j, ok := index[v[i].Parms.Key()]; ok {
+			r.Results[j] = v[i]
+		} else {
+			index[v[i].Parms.Key()] = len(r.Results)
+			r.Results = append(r.Results, v[i])
+		}
+	}
+}
+
+// AddArtifacts adds the artifacts, and the one of same name will be replaced.
+func (r *EvaluateReport) AddArtifacts(v []EvaluateArtifact) {
+	index := make(map[string]int, len(r.Artifacts))
+	for i := range r.Artifacts {
+		index[r.Artifacts[i].Name] = i
+	}
+
+	for i := range v {
+		if j, ok := index[v[i].Name]; ok {
+			r.Artifacts[j] = v[i]
+		} else {
+			index[v[i].Name] = len(r.Artifacts)
+			r.Artifacts = append(r.Artifacts, v[i])
+		}
+	}
+}
+
+// MetricNames returns the sorted names of all the metrics in the results.
+func (r *EvaluateReport) MetricNames() []string {
+	m := map[string]bool{}
+	for i := range r.Results {
+		for _, item := range r.Results[i].Metrics {
+			m[item.Name.CustomizedKey()] = true
+		}
+	}
+
+	v := make([]string, 0, len(m))
+	for k := range m {
+		v = append(v, k)
+	}
+
+	sort.Strings(v)
+
+	return v
+}
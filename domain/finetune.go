@@ -117,6 +117,93 @@ type FinetuneSummary struct {
 
 type FinetuneUserInfo struct {
 	Expiry int64
+	Quota  FinetuneQuota
+
+	// Consumed is the total duration of the deleted finetunes, in seconds.
+	Consumed int
+}
+
+// UsedDuration returns the total duration of finetunes, in seconds.
+// The duration of the running finetune is not final until it is done,
+// so the time elapsed since it was created is counted instead.
+func (info *FinetuneUserInfo) UsedDuration(
+	v []FinetuneSummary, isJobDone func(string) bool, now int64,
+) int {
+	n := info.Consumed
+	for i := range v {
+		item := &v[i]
+
+		d := item.Duration
+		if !isJobDone(item.Status) {
+			if elapsed := int(now - item.CreatedAt); elapsed > d {
+				d = elapsed
+			}
+		}
+
+		n += d
+	}
+
+	return n
+}
+
+// FinetuneQuota is the entitlement of user to finetune.
+// The zero value of each field means no extra limit.
+type FinetuneQuota struct {
+	// MaxConcurrentJobs is 1 if it is not set.
+	MaxConcurrentJobs int
+
+	// MaxGPUHours is the limit of total duration of finetunes.
+	MaxGPUHours int
+
+	// Models is the base models which can be finetuned,
+	// and all the models are allowed if it is empty.
+	Models []string
+}
+
+func NewFinetuneQuota(maxConcurrentJobs, maxGPUHours int, models []string) (
+	FinetuneQuota, error,
+) {
+	if maxConcurrentJobs < 0 || maxGPUHours < 0 {
+		return FinetuneQuota{}, errors.New("invalid quota")
+	}
+
+	for _, m := range models {
+		if _, ok := DomainConfig.Finetunes[m]; !ok {
+			return FinetuneQuota{}, errors.New("invalid model")
+		}
+	}
+
+	return FinetuneQuota{
+		MaxConcurrentJobs: maxConcurrentJobs,
+		MaxGPUHours:       maxGPUHours,
+		Models:            models,
+	}, nil
+}
+
+func (q *FinetuneQuota) ConcurrentJobs() int {
+	if q.MaxConcurrentJobs <= 0 {
+		return 1
+	}
+
+	return q.MaxConcurrentJobs
+}
+
+func (q *FinetuneQuota) IsModelAllowed(model string) bool {
+	if len(q.Models) == 0 {
+		return true
+	}
+
+	for _, m := range q.Models {
+		if m == model {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (q *FinetuneQuota) IsGPUHoursExhausted(usedDuration int) bool {
+	return q.MaxGPUHours > 0 && usedDuration >= q.MaxGPUHours*3600
 }
 
 type FinetuneIndex struct {
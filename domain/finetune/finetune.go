@@ -4,11 +4,32 @@ import (
 	"github.com/opensourceways/xihe-server/domain"
 )
 
+// ErrorUnsupported means the finetune service can't do the operation.
+type ErrorUnsupported struct {
+	error
+}
+
+func NewErrorUnsupported(err error) ErrorUnsupported {
+	return ErrorUnsupported{err}
+}
+
+func IsErrorUnsupported(err error) bool {
+	_, ok := err.(ErrorUnsupported)
+
+	return ok
+}
+
 type Finetune interface {
 	CreateJob(info *domain.FinetuneIndex, t *domain.FinetuneConfig) (domain.FinetuneJobInfo, error)
 	DeleteJob(jobId string) error
 	TerminateJob(jobId string) error
 	GetLogPreviewURL(jobId string) (string, error)
+
+	// GetOutputDownloadURL and DownloadOutput return ErrorUnsupported
+	// if the finetune service can't provide the output.
+	GetOutputDownloadURL(jobId string) (string, error)
+	DownloadOutput(jobId string) (name string, data []byte, err error)
 	IsJobDone(status string) bool
+	IsJobSuccess(status string) bool
 	CanTerminate(status string) bool
 }
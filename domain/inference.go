@@ -30,3 +30,56 @@ type InferenceIndex struct {
 	Id         string
 	LastCommit string
 }
+
+type InferenceDeploymentIndex struct {
+	Project ResourceIndex
+	Id      string
+}
+
+// InferenceDeployment serves the inference of a project pinned to a commit.
+// It keeps the same address when the instance is recreated or
+// the deployment is rolled to a new commit.
+type InferenceDeployment struct {
+	Id            string
+	Project       ResourceIndex
+	ProjectName   ResourceName
+	ResourceLevel string
+
+	// Commit is the commit being served by the instance of InstanceId.
+	Commit     string
+	InstanceId string
+
+	// PendingCommit is the commit being rolled to. It will replace
+	// the Commit when the instance of PendingInstanceId is ready.
+	PendingCommit     string
+	PendingInstanceId string
+
+	// Error stores the message when it fails to roll to the new commit.
+	Error string
+
+	CreatedAt int64
+	UpdatedAt int64
+	Version   int
+}
+
+func (d *InferenceDeployment) Index() InferenceDeploymentIndex {
+	return InferenceDeploymentIndex{
+		Project: d.Project,
+		Id:      d.Id,
+	}
+}
+
+func (d *InferenceDeployment) InferenceInfo(commit string) InferenceInfo {
+	return InferenceInfo{
+		InferenceIndex: InferenceIndex{
+			Project:    d.Project,
+			LastCommit: commit,
+		},
+		ProjectName:   d.ProjectName,
+		ResourceLevel: d.ResourceLevel,
+	}
+}
+
+func (d *InferenceDeployment) IsRolling() bool {
+	return d.PendingInstanceId != ""
+}
@@ -4,6 +4,21 @@ import (
 	"github.com/opensourceways/xihe-server/domain"
 )
 
+// ErrorUnsupported means the inference manager can't do the operation.
+type ErrorUnsupported struct {
+	error
+}
+
+func NewErrorUnsupported(err error) ErrorUnsupported {
+	return ErrorUnsupported{err}
+}
+
+func IsErrorUnsupported(err error) bool {
+	_, ok := err.(ErrorUnsupported)
+
+	return ok
+}
+
 type InferenceInfo struct {
 	*domain.InferenceInfo
 	UserToken string
@@ -13,4 +28,13 @@ type Inference interface {
 	Create(*InferenceInfo) (int, error)
 	GetSurvivalTime(*domain.InferenceInfo) int
 	ExtendSurvivalTime(index *domain.InferenceIndex, timeToExtend int) error
+
+	// Stop returns ErrorUnsupported if the manager can't stop the instance.
+	Stop(*domain.InferenceIndex) error
+}
+
+// Predictor calls the prediction api of a running inference instance.
+type Predictor interface {
+	// Predict returns the status code and body of the response.
+	Predict(accessURL string, body []byte) (int, []byte, error)
 }
@@ -0,0 +1,25 @@
+package domain
+
+const (
+	InferenceInvocationSuccess = "success"
+	InferenceInvocationFailed  = "failed"
+)
+
+// InferenceInvocation records a call which is proxied to
+// the inference instance of project.
+type InferenceInvocation struct {
+	Project    ResourceIndex
+	InstanceId string
+
+	// Caller is nil if the inference is called by a visitor.
+	Caller   Account
+	CalledAt int64
+
+	// Latency is the time the instance takes to respond, in millisecond.
+	Latency int64
+	Status  string
+}
+
+func (i *InferenceInvocation) IsSuccess() bool {
+	return i.Status == InferenceInvocationSuccess
+}
@@ -34,6 +34,7 @@ type Sender interface {
 	AddOperateLogForAccessBigModel(domain.Account, bmdomain.BigmodelType) error
 	AddOperateLogForCreateResource(domain.ResourceObject, domain.ResourceName) error
 	AddOperateLogForDownloadFile(domain.Account, RepoFile) error
+	AddOperateLogForInference(*domain.InferenceInvocation) error
 
 	AddFollowing(*userdomain.FollowerInfo) error
 	RemoveFollowing(*userdomain.FollowerInfo) error
@@ -49,10 +50,12 @@ type Sender interface {
 	RemoveRelatedResources(*RelatedResources) error
 
 	CreateTraining(*MsgTraining) error
+	NotifyTrainingDone(*MsgTraining) error
 	CreateFinetune(*domain.FinetuneIndex) error
 
 	CreateInference(*domain.InferenceInfo) error
 	ExtendInferenceSurvivalTime(*InferenceExtendInfo) error
+	StopInference(*domain.InferenceIndex) error
 
 	CreateEvaluate(*EvaluateInfo) error
 
@@ -106,6 +109,7 @@ type RelatedResources struct {
 
 type TrainingHandler interface {
 	HandleEventCreateTraining(*domain.TrainingIndex) error
+	HandleEventTrainingDone(*domain.TrainingIndex) error
 }
 
 type FinetuneHandler interface {
@@ -115,6 +119,7 @@ type FinetuneHandler interface {
 type InferenceHandler interface {
 	HandleEventCreateInference(*domain.InferenceInfo) error
 	HandleEventExtendInferenceSurvivalTime(*InferenceExtendInfo) error
+	HandleEventStopInference(*domain.InferenceIndex) error
 }
 
 type EvaluateHandler interface {
@@ -10,6 +10,7 @@ import (
 
 const (
 	MsgTypeTraningCreate = "msg_type_training_create"
+	MsgTypeTraningDone   = "msg_type_training_done"
 )
 
 type MsgTraining comsg.MsgNormal
@@ -39,3 +40,16 @@ func (msg *MsgTraining) TrainingCreate(
 		CreatedAt: utils.Now(),
 	}
 }
+
+func (msg *MsgTraining) TrainingDone(index domain.TrainingIndex) {
+	*msg = MsgTraining{
+		Type: MsgTypeTraningDone,
+		User: index.Project.Owner.Account(),
+		Details: map[string]string{
+			"project_owner": index.Project.Owner.Account(),
+			"project_id":    index.Project.Id,
+			"training_id":   index.TrainingId,
+		},
+		CreatedAt: utils.Now(),
+	}
+}
@@ -0,0 +1,13 @@
+package domain
+
+// ModelLineage records where a version of model is published from.
+type ModelLineage struct {
+	Version    int
+	Project    ResourceIndex
+	TrainingId string
+	Datasets   []ResourceIndex
+
+	// File is the path of the training output in the repo of model.
+	File      string
+	CreatedAt int64
+}
@@ -15,6 +15,21 @@ const (
 	fileSuffixCom = ".com"
 	fileSuffixSo  = ".so"
 	fileSuffixDll = ".dll"
+
+	// MaxRepoFileContentSize is the max size of the decoded content
+	// which can be committed in one request.
+	MaxRepoFileContentSize = 200 * 1024 // TODO to config
+)
+
+// the roles of group member and the permissions of repo member on the platform,
+// which the roles of organization and the permissions of collaborator map to.
+const (
+	GroupRoleOwner  = "owner"
+	GroupRoleWriter = "writer"
+	GroupRoleReader = "reader"
+
+	RepoPermissionRead  = "read"
+	RepoPermissionWrite = "write"
 )
 
 type UserOption struct {
@@ -29,6 +44,30 @@ type User interface {
 	RefreshToken(string) (string, error)
 }
 
+type GroupOption struct {
+	Name domain.Account
+	Desc string
+}
+
+type GroupInfo struct {
+	Id          string
+	NamespaceId string
+}
+
+type GroupMemberOption struct {
+	GroupId string
+	UserId  string
+	Role    string
+}
+
+type Group interface {
+	New(*GroupOption) (GroupInfo, error)
+	Delete(groupId string) error
+	AddMember(*GroupMemberOption) error
+	UpdateMember(*GroupMemberOption) error
+	RemoveMember(groupId, userId string) error
+}
+
 type RepoOption struct {
 	Name     domain.ResourceName
 	RepoType domain.RepoType
@@ -45,6 +84,18 @@ type Repository interface {
 	Update(repoId string, repo *RepoOption) error
 }
 
+type RepoMemberOption struct {
+	RepoId     string
+	UserId     string
+	Permission string
+}
+
+type RepoMember interface {
+	AddMember(*RepoMemberOption) error
+	UpdateMember(*RepoMemberOption) error
+	RemoveMember(repoId, userId string) error
+}
+
 type UserInfo struct {
 	User  domain.Account
 	Email domain.Email
@@ -72,10 +123,19 @@ type RepoDirInfo struct {
 	RepoId string
 }
 
+// RepoCommitFile is the file to be created or updated.
+type RepoCommitFile struct {
+	Path    domain.FilePath
+	Content RepoFileContent
+}
+
 type RepoDirFile struct {
 	RepoName domain.ResourceName
 	Dir      domain.Directory
 	File     domain.FilePath
+
+	// Ref is the branch or commit to look up, it is the default branch if empty.
+	Ref string
 }
 
 type RepoPathItem struct {
@@ -91,6 +151,7 @@ type RepoFile interface {
 	Update(u *UserInfo, f *RepoFileInfo, content *RepoFileContent) error
 	Delete(u *UserInfo, f *RepoFileInfo) error
 	DeleteDir(u *UserInfo, f *RepoDirInfo) error
+	CommitFiles(u *UserInfo, d *RepoDirInfo, files []RepoCommitFile) error
 	Download(token string, f *RepoFileInfo) (data []byte, notFound bool, err error)
 	IsLFSFile(data []byte) (is bool, sha string)
 	GenLFSDownloadURL(sha string) (string, error)
@@ -106,7 +167,7 @@ func (r *RepoFileContent) IsOverSize() bool {
 		decodeSize = len(*r.Content)
 	}
 
-	return decodeSize > 200*1024 // TODO 200KB to config
+	return decodeSize > MaxRepoFileContentSize
 }
 
 func (r *RepoFileInfo) BlacklistFilter() bool {
@@ -97,3 +97,9 @@ type ProjectSummary struct {
 	ForkCount     int
 	DownloadCount int
 }
+
+// ProjectFork records the project which a project is forked from.
+type ProjectFork struct {
+	From ResourceIndex
+	To   ResourceIndex
+}
@@ -21,6 +21,7 @@ type Dataset interface {
 	Get(domain.Account, string) (domain.Dataset, error)
 	GetByName(domain.Account, domain.ResourceName) (domain.Dataset, error)
 	GetSummaryByName(domain.Account, domain.ResourceName) (domain.ResourceSummary, error)
+	GetSummaryByRepoId(domain.Account, string) (domain.ResourceSummary, error)
 
 	FindUserDatasets([]UserResourceListOption) ([]domain.DatasetSummary, error)
 	ListSummary([]ResourceSummaryListOption) ([]domain.ResourceSummary, error)
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type EvaluateReport interface {
+	Save(*domain.EvaluateReport) error
+	Get(*domain.EvaluateIndex) (domain.EvaluateReport, error)
+}
@@ -17,6 +17,11 @@ type Finetune interface {
 	Delete(*domain.FinetuneIndex) error
 	List(user domain.Account) (UserFinetunes, error)
 
+	// AddUser grants the user to finetune.
+	AddUser(domain.Account, *domain.FinetuneUserInfo) error
+	// UpdateUser updates the expiry and quota of user.
+	UpdateUser(domain.Account, *domain.FinetuneUserInfo, int) error
+
 	GetJob(*domain.FinetuneIndex) (domain.FinetuneJob, error)
 	SaveJob(*domain.FinetuneIndex, *domain.FinetuneJobInfo) error
 
@@ -10,9 +10,22 @@ type InferenceSummary struct {
 	domain.InferenceDetail
 }
 
+// InferenceInstance is the instance of inference with its project.
+type InferenceInstance struct {
+	domain.InferenceIndex
+
+	ProjectName domain.ResourceName
+
+	domain.InferenceDetail
+}
+
 type Inference interface {
 	Save(*domain.Inference, int) (string, error)
 	UpdateDetail(*domain.InferenceIndex, *domain.InferenceDetail) error
 	FindInstance(*domain.InferenceIndex) (InferenceSummary, error)
 	FindInstances(index *domain.ResourceIndex, lastCommit string) ([]InferenceSummary, int, error)
+
+	// FindRunningInstances returns the instances of projects owned by owner
+	// which will exit after t.
+	FindRunningInstances(owner domain.Account, t int64) ([]InferenceInstance, error)
 }
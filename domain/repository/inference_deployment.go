@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type InferenceDeployment interface {
+	Save(*domain.InferenceDeployment, int) (string, error)
+	Get(*domain.InferenceDeploymentIndex) (domain.InferenceDeployment, error)
+	List(*domain.ResourceIndex) ([]domain.InferenceDeployment, int, error)
+	Update(*domain.InferenceDeployment) error
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type InferenceUsage interface {
+	Add(*domain.InferenceInvocation) error
+
+	// List returns the invocations of project which are called since the day of t.
+	List(project *domain.ResourceIndex, t int64) ([]domain.InferenceInvocation, error)
+}
@@ -26,6 +26,7 @@ type Model interface {
 	Get(domain.Account, string) (domain.Model, error)
 	GetByName(domain.Account, domain.ResourceName) (domain.Model, error)
 	GetSummaryByName(domain.Account, domain.ResourceName) (domain.ResourceSummary, error)
+	GetSummaryByRepoId(domain.Account, string) (domain.ResourceSummary, error)
 
 	FindUserModels([]UserResourceListOption) ([]domain.ModelSummary, error)
 	ListSummary([]ResourceSummaryListOption) ([]domain.ResourceSummary, error)
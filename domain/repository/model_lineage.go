@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type ModelLineage interface {
+	Add(model *domain.ResourceIndex, v *domain.ModelLineage, version int) error
+	List(model *domain.ResourceIndex) ([]domain.ModelLineage, int, error)
+
+	// FindModels returns the models which are published from the project.
+	FindModels(project *domain.ResourceIndex) ([]domain.ResourceIndex, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type ProjectFork interface {
+	Save(*domain.ProjectFork) error
+
+	// FindSource returns the project which the project is forked from.
+	FindSource(*domain.ResourceIndex) (domain.ResourceIndex, error)
+
+	// FindForks returns the projects which are forked from the project.
+	FindForks(*domain.ResourceIndex) ([]domain.ResourceIndex, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type Sweep interface {
+	Save(*domain.Sweep, int) (string, error)
+	Get(*domain.SweepIndex) (domain.Sweep, error)
+	List(user domain.Account, projectId string) ([]domain.Sweep, int, error)
+	UpdateTrials(*domain.Sweep) error
+}
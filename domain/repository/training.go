@@ -12,9 +12,14 @@ type Training interface {
 
 	GetTrainingConfig(*domain.TrainingIndex) (domain.TrainingConfig, error)
 
+	// FindProjectsByInput returns the projects which have a training using the repo.
+	FindProjectsByInput(user domain.Account, repoId string) ([]domain.ResourceIndex, error)
+
 	SaveJob(*domain.TrainingIndex, *domain.JobInfo) error
 	GetJob(*domain.TrainingIndex) (domain.JobInfo, error)
 
 	UpdateJobDetail(*domain.TrainingIndex, *domain.JobDetail) error
+	// UpdateJobDetailWithVersion fails if the trainings of project have been changed.
+	UpdateJobDetailWithVersion(*domain.TrainingIndex, *domain.JobDetail, int) error
 	GetJobDetail(*domain.TrainingIndex) (domain.JobDetail, string, error)
 }
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingMetric interface {
+	Add(*domain.TrainingIndex, []domain.TrainingMetric) error
+
+	// Find returns all the series of training if names is empty.
+	Find(index *domain.TrainingIndex, names []string) ([]domain.TrainingMetricSeries, error)
+
+	// FindLast returns the last value of each series.
+	FindLast(*domain.TrainingIndex) ([]domain.TrainingMetric, error)
+
+	Delete(*domain.TrainingIndex) error
+}
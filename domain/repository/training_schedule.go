@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+type TrainingSchedule interface {
+	Save(*domain.TrainingSchedule, int) (string, error)
+	Get(*domain.TrainingScheduleIndex) (domain.TrainingSchedule, error)
+	List(*domain.ResourceIndex) ([]domain.TrainingSchedule, int, error)
+	Update(*domain.TrainingSchedule) error
+	Delete(*domain.TrainingScheduleIndex) error
+
+	// FindDue finds the enabled schedules which should run before or at t.
+	FindDue(t int64) ([]domain.TrainingSchedule, error)
+
+	AddRun(*domain.TrainingScheduleIndex, *domain.TrainingScheduleRun) error
+	ListRuns(*domain.TrainingScheduleIndex) ([]domain.TrainingScheduleRun, error)
+}
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"errors"
+	"math/rand"
+	"strconv"
+)
+
+const (
+	SweepStrategyGrid   = "grid"
+	SweepStrategyRandom = "random"
+
+	SweepGoalMinimize = "minimize"
+	SweepGoalMaximize = "maximize"
+
+	// SweepMetricDuration is the metric which every trial owns.
+	SweepMetricDuration = "duration"
+
+	maxSweepCombinationNum = 1 << 20
+)
+
+type SweepIndex struct {
+	Project ResourceIndex
+	SweepId string
+}
+
+type Sweep struct {
+	Id        string
+	Owner     Account
+	ProjectId string
+
+	// the name of base config is the name of sweep
+	TrainingConfig
+
+	Strategy    SweepStrategy
+	Parameters  []SweepParameter
+	Metric      SweepMetric
+	Concurrency int
+	Trials      []SweepTrial
+
+	CreatedAt int64
+	Version   int
+}
+
+type SweepParameter struct {
+	Key    CustomizedKey
+	Values []CustomizedValue
+}
+
+type SweepMetric struct {
+	Name CustomizedKey
+	Goal SweepGoal
+}
+
+type SweepTrial struct {
+	Hyperparameters []KeyValue
+	TrainingId      string
+	Done            bool
+}
+
+func (t *SweepTrial) IsPending() bool {
+	return t.TrainingId == ""
+}
+
+func (t *SweepTrial) IsRunning() bool {
+	return t.TrainingId != "" && !t.Done
+}
+
+func (s *Sweep) combinationNum() (int, error) {
+	total := 1
+
+	for i := range s.Parameters {
+		n := len(s.Parameters[i].Values)
+		if n == 0 {
+			return 0, errors.New("empty values of sweep parameter")
+		}
+
+		if total *= n; total > maxSweepCombinationNum {
+			total = maxSweepCombinationNum
+		}
+	}
+
+	return total, nil
+}
+
+func (s *Sweep) combination(k int) []KeyValue {
+	r := make([]KeyValue, len(s.Parameters))
+
+	for i := len(s.Parameters) - 1; i >= 0; i-- {
+		p := &s.Parameters[i]
+		n := len(p.Values)
+
+		r[i] = KeyValue{
+			Key:   p.Key,
+			Value: p.Values[k%n],
+		}
+
+		k /= n
+	}
+
+	return r
+}
+
+// GenTrials generates all the combinations for the grid search and
+// num distinct combinations at most for the random search.
+func (s *Sweep) GenTrials(num int) error {
+	if len(s.Parameters) == 0 {
+		return errors.New("no sweep parameters")
+	}
+
+	total, err := s.combinationNum()
+	if err != nil {
+		return err
+	}
+
+	if s.Strategy.IsGrid() {
+		if total > num {
+			return errors.New("too many combinations of sweep parameters")
+		}
+
+		s.Trials = make([]SweepTrial, total)
+		for i := range s.Trials {
+			s.Trials[i].Hyperparameters = s.combination(i)
+		}
+
+		return nil
+	}
+
+	if num > total {
+		num = total
+	}
+
+	picked := make(map[int]bool, num)
+	s.Trials = make([]SweepTrial, 0, num)
+
+	for len(s.Trials) < num {
+		k := rand.Intn(total)
+		if picked[k] {
+			continue
+		}
+
+		picked[k] = true
+
+		s.Trials = append(s.Trials, SweepTrial{
+			Hyperparameters: s.combination(k),
+		})
+	}
+
+	return nil
+}
+
+// TrialConfig returns the training config of the i-th trial
+// which overrides the hyperparameters of the base config.
+func (s *Sweep) TrialConfig(i int) (cfg TrainingConfig, err error) {
+	cfg = s.TrainingConfig
+
+	name := s.Name.TrainingName() + "-" + strconv.Itoa(i+1)
+	if cfg.Name, err = NewTrainingName(name); err != nil {
+		return
+	}
+
+	trial := s.Trials[i].Hyperparameters
+
+	cfg.Hyperparameters = make([]KeyValue, 0, len(s.Hyperparameters)+len(trial))
+
+	for j := range s.Hyperparameters {
+		item := &s.Hyperparameters[j]
+
+		if !s.isSweptKey(item.Key) {
+			cfg.Hyperparameters = append(cfg.Hyperparameters, *item)
+		}
+	}
+
+	cfg.Hyperparameters = append(cfg.Hyperparameters, trial...)
+
+	return
+}
+
+func (s *Sweep) isSweptKey(k CustomizedKey) bool {
+	for i := range s.Parameters {
+		if s.Parameters[i].Key.CustomizedKey() == k.CustomizedKey() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TrialsToLaunch returns the indexes of pending trials which can be
+// launched without exceeding the concurrency.
+func (s *Sweep) TrialsToLaunch() []int {
+	running := 0
+	for i := range s.Trials {
+		if s.Trials[i].IsRunning() {
+			running++
+		}
+	}
+
+	n := s.Concurrency - running
+	if n <= 0 {
+		return nil
+	}
+
+	r := make([]int, 0, n)
+	for i := range s.Trials {
+		if len(r) == n {
+			break
+		}
+
+		if s.Trials[i].IsPending() {
+			r = append(r, i)
+		}
+	}
+
+	return r
+}
+
+// MarkTrialDone returns false if the training is not a trial of the sweep.
+func (s *Sweep) MarkTrialDone(trainingId string) bool {
+	for i := range s.Trials {
+		if s.Trials[i].TrainingId == trainingId {
+			s.Trials[i].Done = true
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *Sweep) IsDone() bool {
+	for i := range s.Trials {
+		if !s.Trials[i].Done {
+			return false
+		}
+	}
+
+	return true
+}
@@ -87,3 +87,47 @@ type TrainingIndex struct {
 	Project    ResourceIndex
 	TrainingId string
 }
+
+// TrainingMetric is a scalar reported by the training at a step.
+type TrainingMetric struct {
+	Name  CustomizedKey
+	Step  int
+	Value float64
+}
+
+type TrainingMetricPoint struct {
+	Step  int
+	Value float64
+}
+
+type TrainingMetricSeries struct {
+	Name   CustomizedKey
+	Points []TrainingMetricPoint
+}
+
+// Downsample averages the points in each bucket to make sure
+// the num of points is not bigger than max.
+func (s *TrainingMetricSeries) Downsample(max int) []TrainingMetricPoint {
+	n := len(s.Points)
+	if max <= 0 || n <= max {
+		return s.Points
+	}
+
+	r := make([]TrainingMetricPoint, max)
+
+	for i := range r {
+		start, end := i*n/max, (i+1)*n/max
+
+		sum := 0.0
+		for j := start; j < end; j++ {
+			sum += s.Points[j].Value
+		}
+
+		r[i] = TrainingMetricPoint{
+			Step:  s.Points[end-1].Step,
+			Value: sum / float64(end-start),
+		}
+	}
+
+	return r
+}
@@ -4,6 +4,21 @@ import (
 	"github.com/opensourceways/xihe-server/domain"
 )
 
+// ErrorFileTooBig
+type ErrorFileTooBig struct {
+	error
+}
+
+func NewErrorFileTooBig(err error) ErrorFileTooBig {
+	return ErrorFileTooBig{err}
+}
+
+func IsErrorFileTooBig(err error) bool {
+	_, ok := err.(ErrorFileTooBig)
+
+	return ok
+}
+
 type Training interface {
 	CreateJob(endpoint string, info *domain.TrainingIndex, t *domain.TrainingConfig) (domain.JobInfo, error)
 	DeleteJob(endpoint, jobId string) error
@@ -11,4 +26,7 @@ type Training interface {
 	GetLogPreviewURL(endpoint, jobId string) (string, error)
 	IsJobDone(status string) bool
 	GetFileDownloadURL(endpoint, file string) (string, error)
+
+	// DownloadFile returns ErrorFileTooBig if the file is bigger than maxSize.
+	DownloadFile(endpoint, file string, maxSize int) ([]byte, error)
 }
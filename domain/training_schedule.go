@@ -0,0 +1,221 @@
+package domain
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	TrainingSchedulePolicySkip  = "skip"
+	TrainingSchedulePolicyQueue = "queue"
+
+	TrainingScheduleRunTriggered = "triggered"
+	TrainingScheduleRunSkipped   = "skipped"
+	TrainingScheduleRunFailed    = "failed"
+
+	// a cron expression which can't be satisfied in the period will be rejected.
+	cronSearchYears = 5
+)
+
+type TrainingScheduleIndex struct {
+	Project    ResourceIndex
+	ScheduleId string
+}
+
+// TrainingSchedule recreates the training of TrainingId periodically
+// according to the Cron.
+type TrainingSchedule struct {
+	Id         string
+	Project    ResourceIndex
+	TrainingId string
+
+	// Config is the config of the training when the schedule is created,
+	// so the schedule still works after the training is deleted.
+	Config TrainingConfig
+
+	Cron      CronExpr
+	Policy    TrainingSchedulePolicy
+	Enabled   bool
+	NextRunAt int64
+	CreatedAt int64
+
+	Version int
+}
+
+func (s *TrainingSchedule) Index() TrainingScheduleIndex {
+	return TrainingScheduleIndex{
+		Project:    s.Project,
+		ScheduleId: s.Id,
+	}
+}
+
+// TrainingScheduleRun is the history of the schedule being triggered.
+type TrainingScheduleRun struct {
+	TriggeredAt int64
+	TrainingId  string
+	Status      string
+	Reason      string
+}
+
+// cronField is the set of values allowed by one field of cron expression.
+type cronField struct {
+	bits uint64
+	// any is true if the field is '*'.
+	any bool
+}
+
+func (f cronField) has(v int) bool {
+	return f.bits&(1<<uint(v)) != 0
+}
+
+type cronBound struct {
+	min int
+	max int
+}
+
+// the bounds of minute, hour, day of month, month and day of week.
+var cronBounds = [5]cronBound{
+	{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7},
+}
+
+// parseCron parses the standard cron expression of 5 fields which are
+// minute, hour, day of month, month and day of week.
+// Each field supports '*', 'a', 'a-b', '*/n', 'a-b/n' and the list of them.
+func parseCron(expr string) (r [5]cronField, err error) {
+	items := strings.Fields(expr)
+	if len(items) != len(cronBounds) {
+		err = errors.New("cron expression must have 5 fields")
+
+		return
+	}
+
+	for i, item := range items {
+		if r[i], err = parseCronField(item, cronBounds[i]); err != nil {
+			return
+		}
+	}
+
+	// both 0 and 7 mean Sunday
+	if dow := &r[4]; dow.has(7) {
+		dow.bits |= 1
+	}
+
+	return
+}
+
+func parseCronField(v string, b cronBound) (r cronField, err error) {
+	r.any = v == "*"
+
+	for _, item := range strings.Split(v, ",") {
+		var bits uint64
+		if bits, err = parseCronRange(item, b); err != nil {
+			return
+		}
+
+		r.bits |= bits
+	}
+
+	return
+}
+
+func parseCronRange(v string, b cronBound) (uint64, error) {
+	invalid := errors.New("invalid cron field: " + v)
+
+	step := 1
+	if i := strings.Index(v, "/"); i >= 0 {
+		n, err := strconv.Atoi(v[i+1:])
+		if err != nil || n <= 0 {
+			return 0, invalid
+		}
+
+		step = n
+		v = v[:i]
+	}
+
+	start, end := b.min, b.max
+
+	if v != "*" {
+		items := strings.Split(v, "-")
+		if len(items) > 2 {
+			return 0, invalid
+		}
+
+		n, err := strconv.Atoi(items[0])
+		if err != nil {
+			return 0, invalid
+		}
+
+		start, end = n, n
+
+		if len(items) == 2 {
+			if end, err = strconv.Atoi(items[1]); err != nil {
+				return 0, invalid
+			}
+		} else if step > 1 {
+			end = b.max
+		}
+	}
+
+	if start < b.min || end > b.max || start > end {
+		return 0, invalid
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+
+	return bits, nil
+}
+
+// nextCronTime returns the first time after t in UTC matching the fields.
+// It returns 0 if there is not one in the following years.
+func nextCronTime(fields *[5]cronField, t int64) int64 {
+	minute, hour, dom, month, dow := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+	matchDay := func(v time.Time) bool {
+		d, w := dom.has(v.Day()), dow.has(int(v.Weekday()))
+
+		// it matches either of them if both are restricted.
+		if !dom.any && !dow.any {
+			return d || w
+		}
+
+		return d && w
+	}
+
+	v := time.Unix(t, 0).UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := v.AddDate(cronSearchYears, 0, 0)
+
+	for v.Before(limit) {
+		if !month.has(int(v.Month())) {
+			v = time.Date(v.Year(), v.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+
+		if !matchDay(v) {
+			v = time.Date(v.Year(), v.Month(), v.Day()+1, 0, 0, 0, 0, time.UTC)
+
+			continue
+		}
+
+		if !hour.has(v.Hour()) {
+			v = v.Truncate(time.Hour).Add(time.Hour)
+
+			continue
+		}
+
+		if !minute.has(v.Minute()) {
+			v = v.Add(time.Minute)
+
+			continue
+		}
+
+		return v.Unix()
+	}
+
+	return 0
+}
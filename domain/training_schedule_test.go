@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func cronBits(v ...int) (r uint64) {
+	for _, i := range v {
+		r |= 1 << uint(i)
+	}
+
+	return
+}
+
+func TestParseCron(t *testing.T) {
+	cases := []struct {
+		name    string
+		expr    string
+		wantErr bool
+	}{
+		{name: "every minute", expr: "* * * * *"},
+		{name: "fixed time", expr: "30 2 * * *"},
+		{name: "list, range and step", expr: "*/5 1-3 1,15 */2 0-6"},
+		{name: "sunday as 7", expr: "0 0 * * 7"},
+		{name: "too few fields", expr: "* * * *", wantErr: true},
+		{name: "too many fields", expr: "* * * * * *", wantErr: true},
+		{name: "minute out of range", expr: "60 * * * *", wantErr: true},
+		{name: "hour out of range", expr: "* 24 * * *", wantErr: true},
+		{name: "day of month out of range", expr: "0 0 0 * *", wantErr: true},
+		{name: "month out of range", expr: "* * * 13 *", wantErr: true},
+		{name: "day of week out of range", expr: "* * * * 8", wantErr: true},
+		{name: "zero step", expr: "*/0 * * * *", wantErr: true},
+		{name: "reversed range", expr: "5-1 * * * *", wantErr: true},
+		{name: "malformed range", expr: "1-2-3 * * * *", wantErr: true},
+		{name: "not a number", expr: "a * * * *", wantErr: true},
+		{name: "invalid item of list", expr: "1,x * * * *", wantErr: true},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			_, err := parseCron(c.expr)
+			if c.wantErr {
+				if err == nil {
+					t.Fatal("expect an error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestParseCronField(t *testing.T) {
+	cases := []struct {
+		name    string
+		field   string
+		bound   cronBound
+		want    uint64
+		wantAny bool
+	}{
+		{
+			name:    "any",
+			field:   "*",
+			bound:   cronBounds[1],
+			want:    1<<24 - 1,
+			wantAny: true,
+		},
+		{
+			name:  "single value",
+			field: "7",
+			bound: cronBounds[0],
+			want:  cronBits(7),
+		},
+		{
+			name:  "step of any",
+			field: "*/20",
+			bound: cronBounds[0],
+			want:  cronBits(0, 20, 40),
+		},
+		{
+			name:  "step from value",
+			field: "10/25",
+			bound: cronBounds[0],
+			want:  cronBits(10, 35),
+		},
+		{
+			name:  "list of value and range with step",
+			field: "1,5-10/2",
+			bound: cronBounds[0],
+			want:  cronBits(1, 5, 7, 9),
+		},
+		{
+			name:  "range of month",
+			field: "11-12",
+			bound: cronBounds[3],
+			want:  cronBits(11, 12),
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			v, err := parseCronField(c.field, c.bound)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v.bits != c.want {
+				t.Fatalf("expect bits %b, got %b", c.want, v.bits)
+			}
+
+			if v.any != c.wantAny {
+				t.Fatalf("expect any %v, got %v", c.wantAny, v.any)
+			}
+		})
+	}
+}
+
+func TestNextCronTime(t *testing.T) {
+	date := func(year int, month time.Month, day, hour, min, sec int) int64 {
+		return time.Date(year, month, day, hour, min, sec, 0, time.UTC).Unix()
+	}
+
+	cases := []struct {
+		name string
+		expr string
+		t    int64
+		want int64
+	}{
+		{
+			name: "every 15 minutes",
+			expr: "*/15 * * * *",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: date(2024, 1, 1, 0, 15, 0),
+		},
+		{
+			name: "later on the same day",
+			expr: "30 2 * * *",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: date(2024, 1, 1, 2, 30, 0),
+		},
+		{
+			name: "strictly after the time",
+			expr: "0 0 * * *",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: date(2024, 1, 2, 0, 0, 0),
+		},
+		{
+			name: "seconds are ignored",
+			expr: "0 12 * * *",
+			t:    date(2024, 1, 1, 11, 59, 30),
+			want: date(2024, 1, 1, 12, 0, 0),
+		},
+		{
+			name: "weekdays from saturday",
+			expr: "0 9 * * 1-5",
+			t:    date(2024, 1, 6, 10, 0, 0),
+			want: date(2024, 1, 8, 9, 0, 0),
+		},
+		{
+			name: "sunday as 7",
+			expr: "0 0 * * 7",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: date(2024, 1, 7, 0, 0, 0),
+		},
+		{
+			name: "first day of next month",
+			expr: "0 0 1 * *",
+			t:    date(2024, 1, 15, 0, 0, 0),
+			want: date(2024, 2, 1, 0, 0, 0),
+		},
+		{
+			name: "either day of month or day of week",
+			expr: "0 0 13 * 5",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: date(2024, 1, 5, 0, 0, 0),
+		},
+		{
+			name: "next leap day",
+			expr: "0 0 29 2 *",
+			t:    date(2024, 3, 1, 0, 0, 0),
+			want: date(2028, 2, 29, 0, 0, 0),
+		},
+		{
+			name: "never satisfied",
+			expr: "0 0 31 2 *",
+			t:    date(2024, 1, 1, 0, 0, 0),
+			want: 0,
+		},
+	}
+
+	for i := range cases {
+		c := &cases[i]
+
+		t.Run(c.name, func(t *testing.T) {
+			fields, err := parseCron(c.expr)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if v := nextCronTime(&fields, c.t); v != c.want {
+				t.Fatalf("expect %v, got %v", c.want, v)
+			}
+		})
+	}
+}
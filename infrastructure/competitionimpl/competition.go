@@ -32,3 +32,7 @@ func (s *service) UploadSubmissionFile(data io.Reader, path string) error {
 func (s *service) Upload(data io.Reader, path string) error {
 	return s.obs.createObject(data, path)
 }
+
+func (s *service) Download(path string) ([]byte, error) {
+	return s.obs.getObject(path)
+}
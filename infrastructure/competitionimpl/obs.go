@@ -43,3 +43,18 @@ func (s *obsService) createObject(f io.Reader, path string) error {
 
 	return err
 }
+
+func (s *obsService) getObject(path string) ([]byte, error) {
+	input := &obs.GetObjectInput{}
+	input.Bucket = s.bucket
+	input.Key = s.genPath(path)
+
+	output, err := s.cli.GetObject(input)
+	if err != nil {
+		return nil, err
+	}
+
+	defer output.Body.Close()
+
+	return io.ReadAll(output.Body)
+}
@@ -3,5 +3,12 @@ package finetuneimpl
 type Config struct {
 	Endpoint           string   `json:"endpoint"              required:"true"`
 	JobDoneStatus      []string `json:"job_done_status"       required:"true"`
+	JobSuccessStatus   []string `json:"job_success_status"`
 	CanTerminateStatus []string `json:"can_terminate_status"  required:"true"`
 }
+
+func (cfg *Config) SetDefault() {
+	if len(cfg.JobSuccessStatus) == 0 {
+		cfg.JobSuccessStatus = []string{"Completed"}
+	}
+}
@@ -1,6 +1,8 @@
 package finetuneimpl
 
 import (
+	"errors"
+
 	"github.com/opensourceways/xihe-finetune/sdk"
 	"k8s.io/apimachinery/pkg/util/sets"
 
@@ -12,13 +14,16 @@ func NewFinetune(cfg *Config) finetune.Finetune {
 	return &finetuneImpl{
 		cli:                sdk.New(cfg.Endpoint),
 		doneStatus:         sets.NewString(cfg.JobDoneStatus...),
+		successStatus:      sets.NewString(cfg.JobSuccessStatus...),
 		canTerminateStatus: sets.NewString(cfg.CanTerminateStatus...),
 	}
 }
 
 type finetuneImpl struct {
-	cli                sdk.Finetune
+	cli sdk.Finetune
+
 	doneStatus         sets.String
+	successStatus      sets.String
 	canTerminateStatus sets.String
 }
 
@@ -26,6 +31,10 @@ func (impl *finetuneImpl) IsJobDone(status string) bool {
 	return impl.doneStatus.Has(status)
 }
 
+func (impl *finetuneImpl) IsJobSuccess(status string) bool {
+	return impl.successStatus.Has(status)
+}
+
 func (impl *finetuneImpl) CanTerminate(status string) bool {
 	return impl.canTerminateStatus.Has(status)
 }
@@ -67,3 +76,15 @@ func (impl *finetuneImpl) GetLogPreviewURL(jobId string) (r string, err error) {
 
 	return
 }
+
+func (impl *finetuneImpl) GetOutputDownloadURL(jobId string) (string, error) {
+	return "", finetune.NewErrorUnsupported(
+		errors.New("the finetune service can't provide the output"),
+	)
+}
+
+func (impl *finetuneImpl) DownloadOutput(jobId string) (string, []byte, error) {
+	_, err := impl.GetOutputDownloadURL(jobId)
+
+	return "", nil, err
+}
@@ -0,0 +1,114 @@
+package gitlab
+
+import (
+	"strconv"
+
+	sdk "github.com/xanzy/go-gitlab"
+
+	"github.com/opensourceways/xihe-server/domain/platform"
+)
+
+func NewGroupService() platform.Group {
+	return &group{admin}
+}
+
+type group struct {
+	*administrator
+}
+
+func (g *group) New(opt *platform.GroupOption) (r platform.GroupInfo, err error) {
+	name := opt.Name.Account()
+	visibility := sdk.PublicVisibility
+
+	o := &sdk.CreateGroupOptions{
+		Name:       &name,
+		Path:       &name,
+		Visibility: &visibility,
+	}
+
+	if opt.Desc != "" {
+		o.Description = &opt.Desc
+	}
+
+	v, _, err := g.cli.Groups.CreateGroup(o)
+	if err != nil {
+		return
+	}
+
+	r.Id = strconv.Itoa(v.ID)
+	// the namespace of a group has the same id as the group
+	r.NamespaceId = r.Id
+
+	return
+}
+
+func (g *group) Delete(groupId string) error {
+	v, err := g.cli.Groups.DeleteGroup(groupId)
+	if err != nil && v != nil && v.StatusCode == 404 {
+		err = nil
+	}
+
+	return err
+}
+
+func (g *group) AddMember(opt *platform.GroupMemberOption) error {
+	uid, err := strconv.Atoi(opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	level := toAccessLevel(opt.Role)
+
+	_, _, err = g.cli.GroupMembers.AddGroupMember(
+		opt.GroupId, &sdk.AddGroupMemberOptions{
+			UserID:      &uid,
+			AccessLevel: &level,
+		},
+	)
+
+	return err
+}
+
+func (g *group) UpdateMember(opt *platform.GroupMemberOption) error {
+	uid, err := strconv.Atoi(opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	level := toAccessLevel(opt.Role)
+
+	_, _, err = g.cli.GroupMembers.EditGroupMember(
+		opt.GroupId, uid, &sdk.EditGroupMemberOptions{
+			AccessLevel: &level,
+		},
+	)
+
+	return err
+}
+
+func (g *group) RemoveMember(groupId, userId string) error {
+	uid, err := strconv.Atoi(userId)
+	if err != nil {
+		return err
+	}
+
+	v, err := g.cli.GroupMembers.RemoveGroupMember(groupId, uid, nil)
+	if err != nil && v != nil && v.StatusCode == 404 {
+		err = nil
+	}
+
+	return err
+}
+
+func toAccessLevel(role string) sdk.AccessLevelValue {
+	switch role {
+	case platform.GroupRoleOwner:
+		return sdk.OwnerPermissions
+
+	case platform.GroupRoleWriter:
+		return sdk.MaintainerPermissions
+
+	default:
+		return sdk.ReporterPermissions
+	}
+}
@@ -0,0 +1,128 @@
+package gitlab
+
+import (
+	"strconv"
+
+	sdk "github.com/xanzy/go-gitlab"
+
+	"github.com/opensourceways/xihe-server/domain/platform"
+)
+
+func NewRepoMemberService() platform.RepoMember {
+	return &repoMember{admin}
+}
+
+type repoMember struct {
+	*administrator
+}
+
+func (r *repoMember) AddMember(opt *platform.RepoMemberOption) error {
+	uid, err := strconv.Atoi(opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	if err = r.allowDevelopersToPush(opt); err != nil {
+		return err
+	}
+
+	level := toRepoAccessLevel(opt.Permission)
+
+	_, _, err = r.cli.ProjectMembers.AddProjectMember(
+		opt.RepoId, &sdk.AddProjectMemberOptions{
+			UserID:      &uid,
+			AccessLevel: &level,
+		},
+	)
+
+	return err
+}
+
+func (r *repoMember) UpdateMember(opt *platform.RepoMemberOption) error {
+	uid, err := strconv.Atoi(opt.UserId)
+	if err != nil {
+		return err
+	}
+
+	if err = r.allowDevelopersToPush(opt); err != nil {
+		return err
+	}
+
+	level := toRepoAccessLevel(opt.Permission)
+
+	_, _, err = r.cli.ProjectMembers.EditProjectMember(
+		opt.RepoId, uid, &sdk.EditProjectMemberOptions{
+			AccessLevel: &level,
+		},
+	)
+
+	return err
+}
+
+func (r *repoMember) RemoveMember(repoId, userId string) error {
+	uid, err := strconv.Atoi(userId)
+	if err != nil {
+		return err
+	}
+
+	v, err := r.cli.ProjectMembers.DeleteProjectMember(repoId, uid)
+	if err != nil && v != nil && v.StatusCode == 404 {
+		err = nil
+	}
+
+	return err
+}
+
+// allowDevelopersToPush re-protects the default branch to allow the developers
+// to push, because the default protection only allows the maintainers.
+// The collaborators are not maintainers, so that they can't change the settings of repo.
+func (r *repoMember) allowDevelopersToPush(opt *platform.RepoMemberOption) error {
+	if opt.Permission != platform.RepoPermissionWrite {
+		return nil
+	}
+
+	b, v, err := r.cli.ProtectedBranches.GetProtectedBranch(opt.RepoId, defaultBranch)
+	if err != nil {
+		// the default branch is not protected
+		if v != nil && v.StatusCode == 404 {
+			return nil
+		}
+
+		return err
+	}
+
+	for _, item := range b.PushAccessLevels {
+		if item.AccessLevel == sdk.DeveloperPermissions {
+			return nil
+		}
+	}
+
+	if _, err = r.cli.ProtectedBranches.UnprotectRepositoryBranches(
+		opt.RepoId, defaultBranch,
+	); err != nil {
+		return err
+	}
+
+	name := defaultBranch
+	level := sdk.DeveloperPermissions
+	maintainer := sdk.MaintainerPermissions
+
+	_, _, err = r.cli.ProtectedBranches.ProtectRepositoryBranches(
+		opt.RepoId, &sdk.ProtectRepositoryBranchesOptions{
+			Name:                 &name,
+			PushAccessLevel:      &level,
+			MergeAccessLevel:     &level,
+			UnprotectAccessLevel: &maintainer,
+		},
+	)
+
+	return err
+}
+
+func toRepoAccessLevel(p string) sdk.AccessLevelValue {
+	if p == platform.RepoPermissionWrite {
+		return sdk.DeveloperPermissions
+	}
+
+	return sdk.ReporterPermissions
+}
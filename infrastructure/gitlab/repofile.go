@@ -229,6 +229,83 @@ func (impl *repoFile) List(u *platform.UserInfo, info *platform.RepoDir) (
 }
 
 func (impl *repoFile) DeleteDir(u *platform.UserInfo, info *platform.RepoDirInfo) (err error) {
+	v, err := impl.listAllFiles(u, &info.RepoDir)
+	if err != nil {
+		return
+	}
+
+	if v.allFilesCount() >= maxFileCount {
+		err = platform.NewErrorTooManyFilesToDelete(
+			errors.New("too many files to delete"),
+		)
+
+		return
+	}
+
+	files := v.allFiles()
+	if len(files) == 0 {
+		return
+	}
+
+	return impl.deleteMultiFiles(u, info, files)
+}
+
+// CommitFiles creates or updates the files by one commit.
+func (impl *repoFile) CommitFiles(
+	u *platform.UserInfo, info *platform.RepoDirInfo, files []platform.RepoCommitFile,
+) error {
+	v, err := impl.listAllFiles(u, &info.RepoDir)
+	if err != nil {
+		return err
+	}
+
+	exists := make(map[string]bool, v.allFilesCount())
+	for _, f := range v.allFiles() {
+		exists[f] = true
+	}
+
+	actions := make([]Action, len(files))
+	for i := range files {
+		item := &files[i]
+
+		a := Action{
+			Action:   "create",
+			FilePath: item.Path.FilePath(),
+			Content:  *item.Content.Content,
+		}
+
+		if exists[a.FilePath] {
+			a.Action = "update"
+		}
+
+		if item.Content.IsEncoded {
+			a.Encoding = "base64"
+		}
+
+		actions[i] = a
+	}
+
+	commit := Commits{
+		CommitInfo: impl.toCommitInfo(u, "save files to dir: "+info.Path.Directory()),
+		Actions:    actions,
+	}
+
+	url := endpoint + fmt.Sprintf("/projects/%s/repository/commits", info.RepoId)
+
+	req, err := impl.newRequest(u.Token, url, http.MethodPost, &commit)
+	if err != nil {
+		return err
+	}
+
+	_, err = impl.cli.ForwardTo(req, nil)
+
+	return err
+}
+
+// listAllFiles lists the files in the directory recursively.
+func (impl *repoFile) listAllFiles(u *platform.UserInfo, info *platform.RepoDir) (
+	v graphqlResult, err error,
+) {
 	body := `
 {
 	"query":"query {
@@ -266,25 +343,9 @@ func (impl *repoFile) DeleteDir(u *platform.UserInfo, info *platform.RepoDirInfo
 	}
 	h.Add("Content-Type", "application/json")
 
-	v := graphqlResult{}
-	if _, err = impl.cli.ForwardTo(req, &v); err != nil {
-		return
-	}
-
-	if v.allFilesCount() >= maxFileCount {
-		err = platform.NewErrorTooManyFilesToDelete(
-			errors.New("too many files to delete"),
-		)
-
-		return
-	}
+	_, err = impl.cli.ForwardTo(req, &v)
 
-	files := v.allFiles()
-	if len(files) == 0 {
-		return
-	}
-
-	return impl.deleteMultiFiles(u, info, files)
+	return
 }
 
 func (impl *repoFile) deleteMultiFiles(
@@ -341,11 +402,16 @@ func (impl *repoFile) GetDirFileInfo(u *platform.UserInfo, info *platform.RepoDi
 	}"
 }
 `
+	ref := info.Ref
+	if ref == "" {
+		ref = defaultBranch
+	}
+
 	data := fmt.Sprintf(
 		body,
 		u.User.Account()+"/"+info.RepoName.ResourceName(),
-		defaultBranch, info.Dir.Directory(),
-		defaultBranch, info.File.FilePath(),
+		ref, info.Dir.Directory(),
+		ref, info.File.FilePath(),
 	)
 
 	data = strings.ReplaceAll(data, "\n", "")
@@ -25,6 +25,8 @@ type Commits struct {
 type Action struct {
 	Action   string `json:"action"     required:"true"`
 	FilePath string `json:"file_path"  required:"true"`
+	Content  string `json:"content,omitempty"`
+	Encoding string `json:"encoding,omitempty"`
 }
 
 type graphqlResult struct {
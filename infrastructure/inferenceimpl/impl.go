@@ -1,6 +1,8 @@
 package inferenceimpl
 
 import (
+	"errors"
+
 	"github.com/opensourceways/xihe-inference-evaluate/sdk"
 
 	"github.com/opensourceways/xihe-server/domain"
@@ -65,3 +67,11 @@ func (impl *inferenceImpl) ExtendSurvivalTime(index *domain.InferenceIndex, time
 
 	return impl.cli.ExtendExpiryOfInference(&opt)
 }
+
+// Stop can't release the instance until the container manager supports
+// stopping it, so the instance will run until it expires.
+func (impl *inferenceImpl) Stop(index *domain.InferenceIndex) error {
+	return inference.NewErrorUnsupported(
+		errors.New("the container manager can't stop the inference instance"),
+	)
+}
@@ -0,0 +1,77 @@
+package inferenceimpl
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/opensourceways/xihe-server/domain/inference"
+)
+
+type PredictConfig struct {
+	// Path is the path of prediction api of the inference app.
+	Path string `json:"path"`
+
+	// unit is second
+	Timeout int `json:"timeout"`
+
+	// unit is byte
+	MaxResponseSize int64 `json:"max_response_size"`
+}
+
+func (cfg *PredictConfig) SetDefault() {
+	if cfg.Path == "" {
+		cfg.Path = "/api/predict"
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 60
+	}
+
+	if cfg.MaxResponseSize <= 0 {
+		cfg.MaxResponseSize = 10 << 20
+	}
+}
+
+func NewPredictor(cfg *PredictConfig) inference.Predictor {
+	return &predictor{
+		cli: http.Client{
+			Timeout: time.Duration(cfg.Timeout) * time.Second,
+		},
+		path:            cfg.Path,
+		maxResponseSize: cfg.MaxResponseSize,
+	}
+}
+
+type predictor struct {
+	cli             http.Client
+	path            string
+	maxResponseSize int64
+}
+
+func (impl *predictor) Predict(accessURL string, body []byte) (int, []byte, error) {
+	url := strings.TrimSuffix(accessURL, "/") + impl.path
+
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := impl.cli.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	v, err := io.ReadAll(io.LimitReader(resp.Body, impl.maxResponseSize))
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, v, nil
+}
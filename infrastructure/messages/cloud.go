@@ -11,3 +11,11 @@ func (s sender) SubscribeCloud(v *message.MsgCloudConf) error {
 func (s sender) ReleasePod(v *message.MsgPod) error {
 	return s.send(topics.Cloud, v)
 }
+
+func (s sender) ExtendPod(v *message.MsgPod) error {
+	return s.send(topics.Cloud, v)
+}
+
+func (s sender) NotifyWaiting(v *message.MsgNotice) error {
+	return s.send(topics.CloudNotice, v)
+}
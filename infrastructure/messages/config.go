@@ -2,6 +2,9 @@ package messages
 
 var topics Topics
 
+// Topics are the topics of the messages. Some of them are consumed outside of
+// this server, such as CloudNotice which is consumed by the message center to
+// notify the users of the result of waiting for the cloud.
 type Topics struct {
 	Like            string `json:"like"             required:"true"`
 	Fork            string `json:"fork"             required:"true"`
@@ -15,6 +18,7 @@ type Topics struct {
 	OperateLog      string `json:"operate_log"      required:"true"`
 	RelatedResource string `json:"related_resource" required:"true"`
 	Cloud           string `json:"cloud"            required:"true"`
+	CloudNotice     string `json:"cloud_notice"     required:"true"`
 	Async           string `json:"async"            required:"true"`
 	BigModel        string `json:"bigmodel"         required:"true"`
 }
@@ -10,6 +10,7 @@ const (
 	actionRemove = "remove"
 	actionCreate = "create"
 	actionExtend = "extend"
+	actionStop   = "stop"
 )
 
 type msgOperateLog struct {
@@ -189,9 +190,14 @@ type msgSubmission struct {
 	Path  string `json:"path"`
 }
 
-type msgPodCreate struct {
-	User      string `json:"user"`
-	PodId     string `json:"pod_id"`
-	CloudId   string `json:"cloud_id"`
-	CloudName string `json:"cloud_name"`
+// msgPod is the message of creating pod if the type is empty.
+type msgPod struct {
+	Type         string `json:"type"`
+	User         string `json:"user"`
+	Owner        string `json:"owner"`
+	PodId        string `json:"pod_id"`
+	CloudId      string `json:"cloud_id"`
+	CloudName    string `json:"cloud_name"`
+	Expiry       int64  `json:"expiry"`
+	SurvivalTime int64  `json:"survival_time"`
 }
@@ -2,6 +2,7 @@ package messages
 
 import (
 	"encoding/json"
+	"strconv"
 
 	"github.com/opensourceways/community-robot-lib/kafka"
 	"github.com/opensourceways/community-robot-lib/mq"
@@ -104,6 +105,13 @@ func (s sender) ExtendInferenceSurvivalTime(info *message.InferenceExtendInfo) e
 	return s.send(topics.Inference, &v)
 }
 
+func (s sender) StopInference(index *domain.InferenceIndex) error {
+	v := s.toInferenceMsg(index)
+	v.Action = actionStop
+
+	return s.send(topics.Inference, &v)
+}
+
 func (s sender) toInferenceMsg(index *domain.InferenceIndex) msgInference {
 	return msgInference{
 		ProjectId:    index.Project.Id,
@@ -210,6 +218,16 @@ func (s sender) AddOperateLogForDownloadFile(u domain.Account, repo message.Repo
 	})
 }
 
+func (s sender) AddOperateLogForInference(v *domain.InferenceInvocation) error {
+	return s.sendOperateLog(v.Caller, "inference", map[string]string{
+		"owner":    v.Project.Owner.Account(),
+		"pid":      v.Project.Id,
+		"instance": v.InstanceId,
+		"status":   v.Status,
+		"latency":  strconv.FormatInt(v.Latency, 10),
+	})
+}
+
 func (s sender) AddOperateLogForCloudSubscribe(u domain.Account, cloudId string) error {
 	return s.sendOperateLog(u, "cloud", map[string]string{
 		"cloud_id": cloudId,
@@ -324,6 +324,10 @@ func registerHandlerForTraining(handler interface{}) (mq.Subscriber, error) {
 		v.Project.Id = body.Details["project_id"]
 		v.TrainingId = body.Details["training_id"]
 
+		if body.Type == message.MsgTypeTraningDone {
+			return h.HandleEventTrainingDone(&v)
+		}
+
 		return h.HandleEventCreateTraining(&v)
 	})
 }
@@ -387,6 +391,11 @@ func registerHandlerForInference(handler interface{}) (mq.Subscriber, error) {
 		v.Project.Id = body.ProjectId
 		v.LastCommit = body.LastCommit
 
+		// the message of stopping has no project name.
+		if body.Action == actionStop {
+			return h.HandleEventStopInference(&v)
+		}
+
 		info := domain.InferenceInfo{
 			InferenceIndex: v,
 		}
@@ -460,12 +469,17 @@ func registerHandlerForCloud(handler interface{}) (mq.Subscriber, error) {
 			return
 		}
 
-		body := msgPodCreate{}
+		body := msgPod{}
 		if err = json.Unmarshal(msg.Body, &body); err != nil {
 			return
 		}
 
-		user, err := domain.NewAccount(body.User)
+		owner := body.User
+		if body.Type != "" {
+			owner = body.Owner
+		}
+
+		user, err := domain.NewAccount(owner)
 		if err != nil {
 			return
 		}
@@ -477,9 +491,25 @@ func registerHandlerForCloud(handler interface{}) (mq.Subscriber, error) {
 				Owner:   user,
 			},
 		}
-		v.SetDefaultExpiry()
 
-		return h.HandleEventPodSubscribe(&v)
+		switch body.Type {
+		case cloudmsg.MsgTypePodRelease:
+			return h.HandleEventPodRelease(&v)
+
+		case cloudmsg.MsgTypePodExtend:
+			if v.Expiry, err = cloudtypes.NewPodExpiry(body.Expiry); err != nil {
+				return
+			}
+
+			return h.HandleEventPodExtend(&v)
+
+		default:
+			if err = v.SetExpiry(body.SurvivalTime); err != nil {
+				return
+			}
+
+			return h.HandleEventPodSubscribe(&v)
+		}
 	})
 }
 
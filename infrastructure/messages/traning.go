@@ -5,3 +5,7 @@ import "github.com/opensourceways/xihe-server/domain/message"
 func (s sender) CreateTraining(msg *message.MsgTraining) error {
 	return s.send(topics.Training, &msg)
 }
+
+func (s sender) NotifyTrainingDone(msg *message.MsgTraining) error {
+	return s.send(topics.Training, &msg)
+}
@@ -156,6 +156,32 @@ func (col dataset) GetSummaryByName(owner, name string) (
 	return
 }
 
+func (col dataset) GetSummaryByRepoId(owner, repoId string) (
+	do repositories.ResourceSummaryDO, err error,
+) {
+	var v []dDataset
+
+	err = getResourceSummaryByRepoId(col.collectionName, owner, repoId, &v)
+	if err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		err = repositories.NewErrorDataNotExists(errDocNotExists)
+
+		return
+	}
+
+	item := &v[0].Items[0]
+	do.Id = item.Id
+	do.Name = item.Name
+	do.Owner = owner
+	do.RepoId = item.RepoId
+	do.RepoType = item.RepoType
+
+	return
+}
+
 func (col dataset) ListUsersDatasets(opts map[string][]string) (
 	r []repositories.DatasetSummaryDO, err error,
 ) {
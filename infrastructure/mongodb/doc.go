@@ -61,6 +61,17 @@ const (
 	fieldPictures       = "pictures"
 	fieldChoices        = "choices"
 	fieldCompletions    = "completions"
+	fieldTrials         = "trials"
+	fieldPoints         = "points"
+	fieldInputs         = "inputs"
+	fieldUser           = "user"
+	fieldProject        = "project"
+	fieldRuns           = "runs"
+	fieldPolicy         = "policy"
+	fieldNextRun        = "next_run"
+	fieldCron           = "cron"
+	fieldQuota          = "quota"
+	fieldConsumed       = "consumed"
 )
 
 type dProject struct {
@@ -351,6 +362,127 @@ type dJobDetail struct {
 	OutputPath string `bson:"output"     json:"output,omitempty"`
 }
 
+type dSweep struct {
+	Owner         string `bson:"owner"   json:"owner"`
+	ProjectId     string `bson:"pid"     json:"pid"`
+	ProjectName   string `bson:"name"    json:"name"`
+	ProjectRepoId string `bson:"rid"     json:"rid"`
+	Version       int    `bson:"version" json:"-"`
+
+	Items []sweepItem `bson:"items"   json:"-"`
+}
+
+type sweepItem struct {
+	Id          string            `bson:"id"            json:"id"`
+	Config      trainingItem      `bson:"config"        json:"config"`
+	Strategy    string            `bson:"strategy"      json:"strategy"`
+	Parameters  []dSweepParameter `bson:"parameters"    json:"parameters"`
+	MetricName  string            `bson:"metric"        json:"metric"`
+	MetricGoal  string            `bson:"goal"          json:"goal"`
+	Concurrency int               `bson:"concurrency"   json:"concurrency"`
+	Trials      []dSweepTrial     `bson:"trials"        json:"trials"`
+	CreatedAt   int64             `bson:"created_at"    json:"created_at"`
+
+	// Version will be increased by 1 automatically.
+	// So, don't marshal it to avoid setting it occasionally.
+	Version int `bson:"version"    json:"-"`
+}
+
+type dSweepParameter struct {
+	Key    string   `bson:"key"             json:"key"`
+	Values []string `bson:"values"          json:"values"`
+}
+
+type dSweepTrial struct {
+	Hyperparameters []dKeyValue `bson:"parameters"  json:"parameters"`
+	TrainingId      string      `bson:"tid"         json:"tid"`
+	Done            bool        `bson:"done"        json:"done"`
+}
+
+type dTrainingMetric struct {
+	Owner      string         `bson:"owner"   json:"owner"`
+	ProjectId  string         `bson:"pid"     json:"pid"`
+	TrainingId string         `bson:"tid"     json:"tid"`
+	Name       string         `bson:"name"    json:"name"`
+	Points     []dMetricPoint `bson:"points"  json:"points"`
+}
+
+type dMetricPoint struct {
+	Step  int     `bson:"step"    json:"step"`
+	Value float64 `bson:"value"   json:"value"`
+}
+
+type dProjectFork struct {
+	Owner  string `bson:"owner"   json:"owner"`
+	Id     string `bson:"id"      json:"id"`
+	ROwner string `bson:"rowner"  json:"rowner"`
+	RId    string `bson:"rid"     json:"rid"`
+}
+
+type dModelLineage struct {
+	Owner   string             `bson:"owner"    json:"owner"`
+	ModelId string             `bson:"id"       json:"id"`
+	Items   []modelLineageItem `bson:"items"    json:"-"`
+	Version int                `bson:"version"  json:"-"`
+}
+
+type modelLineageItem struct {
+	Version    int             `bson:"version"     json:"version"`
+	Project    ResourceIndex   `bson:"project"     json:"project"`
+	TrainingId string          `bson:"tid"         json:"tid"`
+	Datasets   []ResourceIndex `bson:"datasets"    json:"datasets"`
+	File       string          `bson:"file"        json:"file"`
+	CreatedAt  int64           `bson:"created_at"  json:"created_at"`
+}
+
+type dTrainingSchedule struct {
+	Owner     string `bson:"owner"   json:"owner"`
+	ProjectId string `bson:"pid"     json:"pid"`
+	Version   int    `bson:"version" json:"-"`
+
+	Items []trainingScheduleItem `bson:"items" json:"-"`
+}
+
+type trainingScheduleItem struct {
+	Id         string          `bson:"id"          json:"id"`
+	TrainingId string          `bson:"tid"         json:"tid"`
+	Config     dTrainingConfig `bson:"config"      json:"config"`
+	Cron       string          `bson:"cron"        json:"cron"`
+	Policy     string          `bson:"policy"      json:"policy"`
+	Enabled    bool            `bson:"enabled"     json:"enabled"`
+	NextRunAt  int64           `bson:"next_run"    json:"next_run"`
+	CreatedAt  int64           `bson:"created_at"  json:"created_at"`
+
+	// the latest runs of the schedule, the latest is the last one.
+	Runs []trainingScheduleRun `bson:"runs"        json:"-"`
+
+	// Version will be increased by 1 automatically.
+	// So, don't marshal it to avoid setting it occasionally.
+	Version int `bson:"version"    json:"-"`
+}
+
+type dTrainingConfig struct {
+	ProjectName     string      `bson:"project_name"  json:"project_name"`
+	ProjectRepoId   string      `bson:"rid"           json:"rid"`
+	Name            string      `bson:"name"          json:"name"`
+	Desc            string      `bson:"desc"          json:"desc"`
+	CodeDir         string      `bson:"code_dir"      json:"code_dir"`
+	BootFile        string      `bson:"boot_file"     json:"boot_file"`
+	Compute         dCompute    `bson:"compute"       json:"compute"`
+	Inputs          []dInput    `bson:"inputs"        json:"inputs"`
+	EnableAim       bool        `bson:"aim"           json:"aim"`
+	EnableOutput    bool        `bson:"output"        json:"output"`
+	Env             []dKeyValue `bson:"env"           json:"env"`
+	Hyperparameters []dKeyValue `bson:"parameters"    json:"parameters"`
+}
+
+type trainingScheduleRun struct {
+	TriggeredAt int64  `bson:"triggered_at"  json:"triggered_at"`
+	TrainingId  string `bson:"tid"           json:"tid,omitempty"`
+	Status      string `bson:"status"        json:"status"`
+	Reason      string `bson:"reason"        json:"reason,omitempty"`
+}
+
 type dInference struct {
 	Owner       string `bson:"owner"   json:"owner"`
 	ProjectId   string `bson:"pid"     json:"pid"`
@@ -368,6 +500,31 @@ type inferenceItem struct {
 	AccessURL string `bson:"url"         json:"url,omitempty"`
 }
 
+type dInferenceDeployment struct {
+	Owner     string `bson:"owner"   json:"owner"`
+	ProjectId string `bson:"pid"     json:"pid"`
+	Version   int    `bson:"version" json:"-"`
+
+	Items []inferenceDeploymentItem `bson:"items" json:"-"`
+}
+
+type inferenceDeploymentItem struct {
+	Id                string `bson:"id"               json:"id"`
+	ProjectName       string `bson:"name"             json:"name"`
+	ResourceLevel     string `bson:"level"            json:"level"`
+	Commit            string `bson:"commit"           json:"commit"`
+	InstanceId        string `bson:"instance"         json:"instance"`
+	PendingCommit     string `bson:"pending_commit"   json:"pending_commit"`
+	PendingInstanceId string `bson:"pending_instance" json:"pending_instance"`
+	Error             string `bson:"error"            json:"error"`
+	CreatedAt         int64  `bson:"created_at"       json:"created_at"`
+	UpdatedAt         int64  `bson:"updated_at"       json:"updated_at"`
+
+	// Version will be increased by 1 automatically.
+	// So, don't marshal it to avoid setting it occasionally.
+	Version int `bson:"version"    json:"-"`
+}
+
 type dEvaluate struct {
 	Owner      string `bson:"owner"       json:"owner"`
 	ProjectId  string `bson:"pid"         json:"pid"`
@@ -389,6 +546,33 @@ type evaluateItem struct {
 	AccessURL         string   `bson:"url"         json:"url,omitempty"`
 }
 
+type dEvaluateReport struct {
+	Owner      string                 `bson:"owner"       json:"owner"`
+	ProjectId  string                 `bson:"pid"         json:"pid"`
+	TrainingId string                 `bson:"tid"         json:"tid"`
+	Id         string                 `bson:"id"          json:"id"`
+	Results    []evaluateResultItem   `bson:"results"     json:"results"`
+	Artifacts  []evaluateArtifactItem `bson:"artifacts"   json:"artifacts"`
+	UpdatedAt  int64                  `bson:"updated_at"  json:"updated_at"`
+}
+
+type evaluateResultItem struct {
+	Momentum     string               `bson:"momentum"    json:"momentum"`
+	BatchSize    string               `bson:"bsize"       json:"bsize"`
+	LearningRate string               `bson:"rate"        json:"rate"`
+	Metrics      []evaluateMetricItem `bson:"metrics"     json:"metrics"`
+}
+
+type evaluateMetricItem struct {
+	Name  string  `bson:"name"   json:"name"`
+	Value float64 `bson:"value"  json:"value"`
+}
+
+type evaluateArtifactItem struct {
+	Name string `bson:"name"  json:"name"`
+	Path string `bson:"path"  json:"path"`
+}
+
 type DCompetition struct {
 	Id         string `bson:"id"              json:"id"`
 	Name       string `bson:"name"            json:"name"`
@@ -531,9 +715,11 @@ type pictureItem struct {
 }
 
 type dFinetune struct {
-	Owner   string `bson:"owner"         json:"owner"`
-	Expiry  int64  `bson:"expiry"        json:"expiry"`
-	Version int    `bson:"version"       json:"-"`
+	Owner    string         `bson:"owner"         json:"owner"`
+	Expiry   int64          `bson:"expiry"        json:"expiry"`
+	Quota    dFinetuneQuota `bson:"quota"         json:"quota"`
+	Consumed int            `bson:"consumed"      json:"consumed"`
+	Version  int            `bson:"version"       json:"-"`
 
 	Items []finetuneItem `bson:"items"   json:"-"`
 }
@@ -549,6 +735,12 @@ type finetuneItem struct {
 	JobDetail       dFinetuneJobDetail `bson:"detail"        json:"-"`
 }
 
+type dFinetuneQuota struct {
+	MaxConcurrentJobs int      `bson:"max_concurrent_jobs"  json:"max_concurrent_jobs"`
+	MaxGPUHours       int      `bson:"max_gpu_hours"        json:"max_gpu_hours"`
+	Models            []string `bson:"models"               json:"models"`
+}
+
 type dFinetuneJobInfo struct {
 	Endpoint string `bson:"endpoint"    json:"endpoint"`
 	JobId    string `bson:"job_id"      json:"job_id"`
@@ -559,3 +751,19 @@ type dFinetuneJobDetail struct {
 	Error    string `bson:"error"      json:"error,omitempty"`
 	Status   string `bson:"status"     json:"status,omitempty"`
 }
+
+type dInferenceUsage struct {
+	Owner     string `bson:"owner" json:"owner"`
+	ProjectId string `bson:"pid"   json:"pid"`
+	Date      string `bson:"date"  json:"date"`
+
+	Items []inferenceInvocationItem `bson:"items" json:"-"`
+}
+
+type inferenceInvocationItem struct {
+	InstanceId string `bson:"iid"     json:"iid"`
+	Caller     string `bson:"user"    json:"user,omitempty"`
+	CalledAt   int64  `bson:"at"      json:"at"`
+	Latency    int64  `bson:"latency" json:"latency"`
+	Status     string `bson:"status"  json:"status"`
+}
@@ -0,0 +1,136 @@
+package mongodb
+
+import (
+	"context"
+	"sort"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewEvaluateReportMapper(name string) repositories.EvaluateReportMapper {
+	return evaluateReport{name}
+}
+
+// evaluateReport stores the report of an evaluation to a doc.
+type evaluateReport struct {
+	collectionName string
+}
+
+func (col evaluateReport) docFilter(index *repositories.EvaluateIndexDO) bson.M {
+	filter := evaluateDocFilter(index.Project.Owner, index.Project.Id, index.TrainingId)
+	filter[fieldId] = index.Id
+
+	return filter
+}
+
+func (col evaluateReport) Replace(do *repositories.EvaluateReportDO) error {
+	doc, err := genDoc(col.toEvaluateReportDoc(do))
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.replaceDoc(
+			ctx, col.collectionName, col.docFilter(&do.Index), doc,
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (col evaluateReport) Get(index *repositories.EvaluateIndexDO) (
+	do repositories.EvaluateReportDO, err error,
+) {
+	var v dEvaluateReport
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName, col.docFilter(index), nil, &v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		if isDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(err)
+		}
+
+		return
+	}
+
+	do.Index = *index
+	do.UpdatedAt = v.UpdatedAt
+
+	do.Results = make([]repositories.EvaluateResultDO, len(v.Results))
+	for i := range v.Results {
+		item := &v.Results[i]
+
+		metrics := make(map[string]float64, len(item.Metrics))
+		for _, m := range item.Metrics {
+			metrics[m.Name] = m.Value
+		}
+
+		do.Results[i] = repositories.EvaluateResultDO{
+			Parms: repositories.EvaluateParmsDO{
+				Momentum:     item.Momentum,
+				BatchSize:    item.BatchSize,
+				LearningRate: item.LearningRate,
+			},
+			Metrics: metrics,
+		}
+	}
+
+	do.Artifacts = make([]repositories.EvaluateArtifactDO, len(v.Artifacts))
+	for i := range v.Artifacts {
+		do.Artifacts[i] = repositories.EvaluateArtifactDO{
+			Name: v.Artifacts[i].Name,
+			Path: v.Artifacts[i].Path,
+		}
+	}
+
+	return
+}
+
+func (col evaluateReport) toEvaluateReportDoc(do *repositories.EvaluateReportDO) dEvaluateReport {
+	doc := dEvaluateReport{
+		Owner:      do.Index.Project.Owner,
+		ProjectId:  do.Index.Project.Id,
+		TrainingId: do.Index.TrainingId,
+		Id:         do.Index.Id,
+		Results:    make([]evaluateResultItem, len(do.Results)),
+		Artifacts:  make([]evaluateArtifactItem, len(do.Artifacts)),
+		UpdatedAt:  do.UpdatedAt,
+	}
+
+	for i := range do.Results {
+		item := &do.Results[i]
+
+		metrics := make([]evaluateMetricItem, 0, len(item.Metrics))
+		for k, v := range item.Metrics {
+			metrics = append(metrics, evaluateMetricItem{Name: k, Value: v})
+		}
+
+		sort.Slice(metrics, func(i, j int) bool {
+			return metrics[i].Name < metrics[j].Name
+		})
+
+		doc.Results[i] = evaluateResultItem{
+			Momentum:     item.Parms.Momentum,
+			BatchSize:    item.Parms.BatchSize,
+			LearningRate: item.Parms.LearningRate,
+			Metrics:      metrics,
+		}
+	}
+
+	for i := range do.Artifacts {
+		doc.Artifacts[i] = evaluateArtifactItem{
+			Name: do.Artifacts[i].Name,
+			Path: do.Artifacts[i].Path,
+		}
+	}
+
+	return doc
+}
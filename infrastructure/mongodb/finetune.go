@@ -49,18 +49,98 @@ func (col finetuneCol) insert(do *repositories.UserFinetuneDO, version int) (ide
 	return
 }
 
+// Delete removes the finetune and adds its duration to the consumed.
 func (col finetuneCol) Delete(index *repositories.FinetuneIndexDO) error {
+	var v []dFinetune
+
 	f := func(ctx context.Context) error {
-		return cli.pullArrayElem(
+		return cli.getArrayElem(
 			ctx, col.collectionName, fieldItems,
 			resourceOwnerFilter(index.Owner),
 			resourceIdFilter(index.Id),
+			bson.M{subfieldOfItems(fieldDetail): 1},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		return nil
+	}
+
+	filter := resourceOwnerFilter(index.Owner)
+	filter[subfieldOfItems(fieldId)] = index.Id
+
+	f = func(ctx context.Context) error {
+		return cli.pullArrayElemAndInc(
+			ctx, col.collectionName, fieldItems,
+			filter, resourceIdFilter(index.Id),
+			bson.M{fieldConsumed: v[0].Items[0].JobDetail.Duration},
 		)
 	}
 
 	return withContext(f)
 }
 
+func (col finetuneCol) InsertUser(user string, do *repositories.FinetuneUserInfoDO) error {
+	doc := bson.M{
+		fieldOwner:    user,
+		fieldExpiry:   do.Expiry,
+		fieldQuota:    col.toFinetuneQuotaDoc(&do.Quota),
+		fieldConsumed: 0,
+		fieldItems:    bson.A{},
+		fieldVersion:  0,
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, resourceOwnerFilter(user), doc,
+		)
+
+		return err
+	}
+
+	err := withContext(f)
+	if err != nil && isDocExists(err) {
+		err = repositories.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (col finetuneCol) UpdateUser(
+	user string, do *repositories.FinetuneUserInfoDO, version int,
+) error {
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName, resourceOwnerFilter(user),
+			bson.M{
+				fieldExpiry: do.Expiry,
+				fieldQuota:  col.toFinetuneQuotaDoc(&do.Quota),
+			},
+			mongoCmdSet, version,
+		)
+	}
+
+	err := withContext(f)
+	if err != nil && isDocNotExists(err) {
+		err = repositories.NewErrorConcurrentUpdating(err)
+	}
+
+	return err
+}
+
+func (col finetuneCol) toFinetuneQuotaDoc(do *repositories.FinetuneQuotaDO) dFinetuneQuota {
+	return dFinetuneQuota{
+		MaxConcurrentJobs: do.MaxConcurrentJobs,
+		MaxGPUHours:       do.MaxGPUHours,
+		Models:            do.Models,
+	}
+}
+
 func (col finetuneCol) Get(index *repositories.FinetuneIndexDO) (
 	do repositories.FinetuneDetailDO, err error,
 ) {
@@ -97,6 +177,8 @@ func (col finetuneCol) List(user string) (do repositories.UserFinetunesDO, versi
 			resourceOwnerFilter(user),
 			bson.M{
 				fieldExpiry:                     1,
+				fieldQuota:                      1,
+				fieldConsumed:                   1,
 				fieldVersion:                    1,
 				subfieldOfItems(fieldId):        1,
 				subfieldOfItems(fieldName):      1,
@@ -115,6 +197,12 @@ func (col finetuneCol) List(user string) (do repositories.UserFinetunesDO, versi
 
 	version = v.Version
 	do.Expiry = v.Expiry
+	do.Consumed = v.Consumed
+	do.Quota = repositories.FinetuneQuotaDO{
+		MaxConcurrentJobs: v.Quota.MaxConcurrentJobs,
+		MaxGPUHours:       v.Quota.MaxGPUHours,
+		Models:            v.Quota.Models,
+	}
 
 	t := v.Items
 	if len(t) == 0 {
@@ -197,3 +197,54 @@ func (col inference) toInferenceSummaryDO(doc *inferenceItem, r *repositories.In
 	r.Expiry = doc.Expiry
 	r.AccessURL = doc.AccessURL
 }
+
+func (col inference) ListRunning(owner string, t int64) ([]repositories.InferenceInstanceDO, error) {
+	var v []dInference
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				fieldOwner: owner,
+				fieldItems: bson.M{"$elemMatch": bson.M{
+					fieldExpiry: bson.M{"$gt": t},
+				}},
+			},
+			bson.M{fieldVersion: 0},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := []repositories.InferenceInstanceDO{}
+
+	for i := range v {
+		doc := &v[i]
+
+		for j := range doc.Items {
+			item := &doc.Items[j]
+
+			if item.Expiry <= t {
+				continue
+			}
+
+			do := repositories.InferenceInstanceDO{
+				ProjectName: doc.ProjectName,
+			}
+			do.Id = item.Id
+			do.Project.Owner = doc.Owner
+			do.Project.Id = doc.ProjectId
+			do.LastCommit = doc.LastCommit
+			do.Expiry = item.Expiry
+			do.Error = item.Error
+			do.AccessURL = item.AccessURL
+
+			r = append(r, do)
+		}
+	}
+
+	return r, nil
+}
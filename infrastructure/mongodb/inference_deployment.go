@@ -0,0 +1,228 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewInferenceDeploymentMapper(name string) repositories.InferenceDeploymentMapper {
+	return inferenceDeployment{name}
+}
+
+type inferenceDeployment struct {
+	collectionName string
+}
+
+func (col inferenceDeployment) newDoc(owner, projectId string) error {
+	docFilter := trainingDocFilter(owner, projectId)
+
+	doc := bson.M{
+		fieldOwner:   owner,
+		fieldPId:     projectId,
+		fieldItems:   bson.A{},
+		fieldVersion: 0,
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col inferenceDeployment) Insert(do *repositories.InferenceDeploymentDO, version int) (
+	identity string, err error,
+) {
+	identity, err = col.insert(do, version)
+	if err == nil || !isDocNotExists(err) {
+		return
+	}
+
+	// doc is not exist or duplicate insert
+
+	if err = col.newDoc(do.Owner, do.ProjectId); err == nil {
+		identity, err = col.insert(do, version)
+		if err != nil && isDocNotExists(err) {
+			err = repositories.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return
+}
+
+func (col inferenceDeployment) insert(do *repositories.InferenceDeploymentDO, version int) (
+	identity string, err error,
+) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := genDoc(col.toInferenceDeploymentItem(do))
+	if err != nil {
+		return
+	}
+
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(do.Owner, do.ProjectId),
+			bson.M{fieldItems: doc}, mongoCmdPush, version,
+		)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (col inferenceDeployment) Get(project *repositories.ResourceIndexDO, id string) (
+	do repositories.InferenceDeploymentDO, err error,
+) {
+	var v []dInferenceDeployment
+
+	f := func(ctx context.Context) error {
+		return cli.getArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(project.Owner, project.Id),
+			resourceIdFilter(id),
+			bson.M{
+				fieldOwner: 1,
+				fieldPId:   1,
+				fieldItems: 1,
+			},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		err = repositories.NewErrorDataNotExists(errDocNotExists)
+	} else {
+		col.toInferenceDeploymentDO(&v[0], &v[0].Items[0], &do)
+	}
+
+	return
+}
+
+func (col inferenceDeployment) List(project *repositories.ResourceIndexDO) (
+	[]repositories.InferenceDeploymentDO, int, error,
+) {
+	var v dInferenceDeployment
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(project.Owner, project.Id),
+			nil, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if isDocNotExists(err) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+
+	items := v.Items
+	r := make([]repositories.InferenceDeploymentDO, len(items))
+
+	for i := range items {
+		col.toInferenceDeploymentDO(&v, &items[i], &r[i])
+	}
+
+	return r, v.Version, nil
+}
+
+func (col inferenceDeployment) Update(do *repositories.InferenceDeploymentDO) error {
+	item := col.toInferenceDeploymentItem(do)
+
+	doc, err := genDoc(item)
+	if err != nil {
+		return err
+	}
+
+	// id and created_at will not be changed.
+	delete(doc, fieldId)
+	delete(doc, fieldCreatedAt)
+
+	updated := false
+
+	f := func(ctx context.Context) (err error) {
+		updated, err = cli.updateArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(do.Owner, do.ProjectId),
+			resourceIdFilter(do.Id),
+			doc, do.Version, do.UpdatedAt,
+		)
+
+		return
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if !updated {
+		return repositories.NewErrorConcurrentUpdating(
+			errors.New("no update"),
+		)
+	}
+
+	return nil
+}
+
+func (col inferenceDeployment) toInferenceDeploymentItem(
+	do *repositories.InferenceDeploymentDO,
+) inferenceDeploymentItem {
+	return inferenceDeploymentItem{
+		Id:                do.Id,
+		ProjectName:       do.ProjectName,
+		ResourceLevel:     do.ResourceLevel,
+		Commit:            do.Commit,
+		InstanceId:        do.InstanceId,
+		PendingCommit:     do.PendingCommit,
+		PendingInstanceId: do.PendingInstanceId,
+		Error:             do.Error,
+		CreatedAt:         do.CreatedAt,
+		UpdatedAt:         do.UpdatedAt,
+	}
+}
+
+func (col inferenceDeployment) toInferenceDeploymentDO(
+	doc *dInferenceDeployment, item *inferenceDeploymentItem,
+	do *repositories.InferenceDeploymentDO,
+) {
+	*do = repositories.InferenceDeploymentDO{
+		Id:                item.Id,
+		Owner:             doc.Owner,
+		ProjectId:         doc.ProjectId,
+		ProjectName:       item.ProjectName,
+		ResourceLevel:     item.ResourceLevel,
+		Commit:            item.Commit,
+		InstanceId:        item.InstanceId,
+		PendingCommit:     item.PendingCommit,
+		PendingInstanceId: item.PendingInstanceId,
+		Error:             item.Error,
+		CreatedAt:         item.CreatedAt,
+		UpdatedAt:         item.UpdatedAt,
+		Version:           item.Version,
+	}
+}
@@ -0,0 +1,135 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewInferenceUsageMapper(name string) repositories.InferenceUsageMapper {
+	return inferenceUsage{name}
+}
+
+func inferenceUsageDocFilter(index *repositories.ResourceIndexDO, date string) bson.M {
+	return bson.M{
+		fieldOwner: index.Owner,
+		fieldPId:   index.Id,
+		fieldDate:  date,
+	}
+}
+
+// inferenceUsage stores the invocations of a project in a day to a doc.
+type inferenceUsage struct {
+	collectionName string
+}
+
+func (col inferenceUsage) newDoc(index *repositories.ResourceIndexDO, date string) error {
+	docFilter := inferenceUsageDocFilter(index, date)
+
+	doc := bson.M{
+		fieldOwner: index.Owner,
+		fieldPId:   index.Id,
+		fieldDate:  date,
+		fieldItems: bson.A{},
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col inferenceUsage) Add(
+	index *repositories.ResourceIndexDO, date string,
+	do *repositories.InferenceInvocationDO,
+) error {
+	err := col.add(index, date, do)
+	if err == nil || !isDocNotExists(err) {
+		return err
+	}
+
+	if err = col.newDoc(index, date); err != nil {
+		return err
+	}
+
+	return col.add(index, date, do)
+}
+
+func (col inferenceUsage) add(
+	index *repositories.ResourceIndexDO, date string,
+	do *repositories.InferenceInvocationDO,
+) error {
+	doc, err := genDoc(inferenceInvocationItem{
+		InstanceId: do.InstanceId,
+		Caller:     do.Caller,
+		CalledAt:   do.CalledAt,
+		Latency:    do.Latency,
+		Status:     do.Status,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		return cli.pushArrayElem(
+			ctx, col.collectionName, fieldItems,
+			inferenceUsageDocFilter(index, date), doc,
+		)
+	}
+
+	return withContext(f)
+}
+
+func (col inferenceUsage) List(index *repositories.ResourceIndexDO, date string) (
+	[]repositories.InferenceInvocationDO, error,
+) {
+	var v []dInferenceUsage
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				fieldOwner: index.Owner,
+				fieldPId:   index.Id,
+				fieldDate:  bson.M{"$gte": date},
+			},
+			bson.M{fieldItems: 1},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := []repositories.InferenceInvocationDO{}
+
+	for i := range v {
+		items := v[i].Items
+
+		for j := range items {
+			item := &items[j]
+
+			r = append(r, repositories.InferenceInvocationDO{
+				InstanceId: item.InstanceId,
+				Caller:     item.Caller,
+				CalledAt:   item.CalledAt,
+				Latency:    item.Latency,
+				Status:     item.Status,
+			})
+		}
+	}
+
+	return r, nil
+}
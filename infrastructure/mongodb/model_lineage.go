@@ -0,0 +1,187 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewModelLineageMapper(name string) repositories.ModelLineageMapper {
+	return modelLineage{name}
+}
+
+func modelLineageDocFilter(model *repositories.ResourceIndexDO) bson.M {
+	return bson.M{
+		fieldOwner: model.Owner,
+		fieldId:    model.Id,
+	}
+}
+
+type modelLineage struct {
+	collectionName string
+}
+
+func (col modelLineage) newDoc(model *repositories.ResourceIndexDO) error {
+	doc := bson.M{
+		fieldOwner:   model.Owner,
+		fieldId:      model.Id,
+		fieldItems:   bson.A{},
+		fieldVersion: 0,
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, modelLineageDocFilter(model), doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col modelLineage) Insert(
+	model *repositories.ResourceIndexDO, do *repositories.ModelLineageDO, version int,
+) error {
+	err := col.insert(model, do, version)
+	if err == nil || !isDocNotExists(err) {
+		return err
+	}
+
+	// doc is not exist or duplicate insert
+
+	if err = col.newDoc(model); err == nil {
+		err = col.insert(model, do, version)
+		if err != nil && isDocNotExists(err) {
+			err = repositories.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return err
+}
+
+func (col modelLineage) insert(
+	model *repositories.ResourceIndexDO, do *repositories.ModelLineageDO, version int,
+) error {
+	doc, err := genDoc(col.toModelLineageItem(do))
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			modelLineageDocFilter(model),
+			bson.M{fieldItems: doc}, mongoCmdPush, version,
+		)
+	}
+
+	return withContext(f)
+}
+
+func (col modelLineage) List(model *repositories.ResourceIndexDO) (
+	[]repositories.ModelLineageDO, int, error,
+) {
+	var v dModelLineage
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			modelLineageDocFilter(model), nil, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if isDocNotExists(err) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+
+	r := make([]repositories.ModelLineageDO, len(v.Items))
+	for i := range v.Items {
+		item := &v.Items[i]
+
+		r[i] = repositories.ModelLineageDO{
+			Version: item.Version,
+			Project: repositories.ResourceIndexDO{
+				Owner: item.Project.Owner,
+				Id:    item.Project.Id,
+			},
+			TrainingId: item.TrainingId,
+			Datasets:   toResourceIndexDO(item.Datasets),
+			File:       item.File,
+			CreatedAt:  item.CreatedAt,
+		}
+	}
+
+	return r, v.Version, nil
+}
+
+func (col modelLineage) FindModels(project *repositories.ResourceIndexDO) (
+	[]repositories.ResourceIndexDO, error,
+) {
+	var v []dModelLineage
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				fieldItems: bson.M{
+					"$elemMatch": bson.M{
+						fieldProject + "." + fieldRId:    project.Id,
+						fieldProject + "." + fieldROwner: project.Owner,
+					},
+				},
+			},
+			bson.M{
+				fieldOwner: 1,
+				fieldId:    1,
+			},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]repositories.ResourceIndexDO, len(v))
+	for i := range v {
+		r[i] = repositories.ResourceIndexDO{
+			Owner: v[i].Owner,
+			Id:    v[i].ModelId,
+		}
+	}
+
+	return r, nil
+}
+
+func (col modelLineage) toModelLineageItem(do *repositories.ModelLineageDO) modelLineageItem {
+	datasets := make([]ResourceIndex, len(do.Datasets))
+	for i := range do.Datasets {
+		datasets[i] = ResourceIndex{
+			Owner: do.Datasets[i].Owner,
+			Id:    do.Datasets[i].Id,
+		}
+	}
+
+	return modelLineageItem{
+		Version: do.Version,
+		Project: ResourceIndex{
+			Owner: do.Project.Owner,
+			Id:    do.Project.Id,
+		},
+		TrainingId: do.TrainingId,
+		Datasets:   datasets,
+		File:       do.File,
+		CreatedAt:  do.CreatedAt,
+	}
+}
@@ -0,0 +1,109 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewProjectForkMapper(name string) repositories.ProjectForkMapper {
+	return projectFork{name}
+}
+
+type projectFork struct {
+	collectionName string
+}
+
+func (col projectFork) Insert(do *repositories.ProjectForkDO) error {
+	docFilter := bson.M{
+		fieldOwner: do.To.Owner,
+		fieldId:    do.To.Id,
+	}
+
+	doc, err := genDoc(dProjectFork{
+		Owner:  do.To.Owner,
+		Id:     do.To.Id,
+		ROwner: do.From.Owner,
+		RId:    do.From.Id,
+	})
+	if err != nil {
+		return err
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && isDocExists(err) {
+		err = repositories.NewErrorDuplicateCreating(err)
+	}
+
+	return err
+}
+
+func (col projectFork) GetSource(index *repositories.ResourceIndexDO) (
+	do repositories.ResourceIndexDO, err error,
+) {
+	var v dProjectFork
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			bson.M{
+				fieldOwner: index.Owner,
+				fieldId:    index.Id,
+			},
+			nil, &v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		if isDocNotExists(err) {
+			err = repositories.NewErrorDataNotExists(err)
+		}
+
+		return
+	}
+
+	do.Owner = v.ROwner
+	do.Id = v.RId
+
+	return
+}
+
+func (col projectFork) ListForks(index *repositories.ResourceIndexDO) (
+	[]repositories.ResourceIndexDO, error,
+) {
+	var v []dProjectFork
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				fieldROwner: index.Owner,
+				fieldRId:    index.Id,
+			},
+			nil, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]repositories.ResourceIndexDO, len(v))
+	for i := range v {
+		r[i] = repositories.ResourceIndexDO{
+			Owner: v[i].Owner,
+			Id:    v[i].Id,
+		}
+	}
+
+	return r, nil
+}
@@ -165,6 +165,25 @@ func getResourceSummaryByName(collection, owner, name string, result interface{}
 	return withContext(f)
 }
 
+func getResourceSummaryByRepoId(collection, owner, repoId string, result interface{}) error {
+	f := func(ctx context.Context) error {
+		return cli.getArrayElem(
+			ctx, collection, fieldItems,
+			resourceOwnerFilter(owner),
+			bson.M{fieldRepoId: repoId},
+			bson.M{
+				subfieldOfItems(fieldId):       1,
+				subfieldOfItems(fieldName):     1,
+				subfieldOfItems(fieldRepoId):   1,
+				subfieldOfItems(fieldRepoType): 1,
+			},
+			result,
+		)
+	}
+
+	return withContext(f)
+}
+
 func getResourceByName(collection, owner, name string, result interface{}) error {
 	f := func(ctx context.Context) error {
 		return cli.getArrayElem(
@@ -0,0 +1,296 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewSweepMapper(name string) repositories.SweepMapper {
+	return sweep{name}
+}
+
+type sweep struct {
+	collectionName string
+}
+
+func (col sweep) newDoc(do *repositories.SweepDO) error {
+	docFilter := trainingDocFilter(do.Owner, do.ProjectId)
+
+	doc := bson.M{
+		fieldOwner:   do.Owner,
+		fieldPId:     do.ProjectId,
+		fieldName:    do.ProjectName,
+		fieldRId:     do.ProjectRepoId,
+		fieldItems:   bson.A{},
+		fieldVersion: 0,
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col sweep) Insert(do *repositories.SweepDO, version int) (
+	identity string, err error,
+) {
+	identity, err = col.insert(do, version)
+	if err == nil || !isDocNotExists(err) {
+		return
+	}
+
+	// doc is not exist or duplicate insert
+
+	if err = col.newDoc(do); err == nil {
+		identity, err = col.insert(do, version)
+		if err != nil && isDocNotExists(err) {
+			err = repositories.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return
+}
+
+func (col sweep) insert(do *repositories.SweepDO, version int) (identity string, err error) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := col.toSweepDoc(do)
+	if err != nil {
+		return
+	}
+
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(do.Owner, do.ProjectId),
+			bson.M{fieldItems: doc}, mongoCmdPush, version,
+		)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (col sweep) Get(index *repositories.SweepIndexDO) (
+	do repositories.SweepDO, err error,
+) {
+	var v []dSweep
+
+	f := func(ctx context.Context) error {
+		return cli.getArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.SweepId),
+			bson.M{
+				fieldOwner: 1,
+				fieldPId:   1,
+				fieldName:  1,
+				fieldRId:   1,
+				fieldItems: 1,
+			},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		err = repositories.NewErrorDataNotExists(errDocNotExists)
+	} else {
+		col.toSweepDO(&v[0], &v[0].Items[0], &do)
+	}
+
+	return
+}
+
+func (col sweep) List(user, projectId string) ([]repositories.SweepDO, int, error) {
+	var v dSweep
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(user, projectId),
+			nil, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if isDocNotExists(err) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+
+	items := v.Items
+	r := make([]repositories.SweepDO, len(items))
+
+	for i := range items {
+		col.toSweepDO(&v, &items[i], &r[i])
+	}
+
+	return r, v.Version, nil
+}
+
+func (col sweep) UpdateTrials(
+	index *repositories.SweepIndexDO, trials []repositories.SweepTrialDO, version int,
+) error {
+	updated := false
+
+	f := func(ctx context.Context) (err error) {
+		updated, err = cli.updateArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.SweepId),
+			bson.M{fieldTrials: col.toSweepTrialDocs(trials)},
+			version, 0,
+		)
+
+		return
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if !updated {
+		return repositories.NewErrorConcurrentUpdating(
+			errors.New("no update"),
+		)
+	}
+
+	return nil
+}
+
+func (col sweep) toSweepDoc(do *repositories.SweepDO) (bson.M, error) {
+	cfg := &do.TrainingConfigDO
+	c := &cfg.Compute
+	t := training{}
+
+	params := make([]dSweepParameter, len(do.Parameters))
+	for i := range do.Parameters {
+		params[i] = dSweepParameter{
+			Key:    do.Parameters[i].Key,
+			Values: do.Parameters[i].Values,
+		}
+	}
+
+	docObj := sweepItem{
+		Id: do.Id,
+		Config: trainingItem{
+			Name:            cfg.Name,
+			Desc:            cfg.Desc,
+			CodeDir:         cfg.CodeDir,
+			BootFile:        cfg.BootFile,
+			Inputs:          t.toInputDoc(cfg.Inputs),
+			EnableAim:       cfg.EnableAim,
+			EnableOutput:    cfg.EnableOutput,
+			Env:             t.toKeyValueDoc(cfg.Env),
+			Hyperparameters: t.toKeyValueDoc(cfg.Hyperparameters),
+			Compute: dCompute{
+				Type:    c.Type,
+				Flavor:  c.Flavor,
+				Version: c.Version,
+			},
+		},
+		Strategy:    do.Strategy,
+		Parameters:  params,
+		MetricName:  do.MetricName,
+		MetricGoal:  do.MetricGoal,
+		Concurrency: do.Concurrency,
+		Trials:      col.toSweepTrialDocs(do.Trials),
+		CreatedAt:   do.CreatedAt,
+	}
+
+	return genDoc(docObj)
+}
+
+func (col sweep) toSweepTrialDocs(v []repositories.SweepTrialDO) []dSweepTrial {
+	r := make([]dSweepTrial, len(v))
+
+	for i := range v {
+		r[i] = dSweepTrial{
+			Hyperparameters: training{}.toKeyValueDoc(v[i].Hyperparameters),
+			TrainingId:      v[i].TrainingId,
+			Done:            v[i].Done,
+		}
+	}
+
+	return r
+}
+
+func (col sweep) toSweepDO(doc *dSweep, item *sweepItem, do *repositories.SweepDO) {
+	t := training{}
+	cfg := &item.Config
+	c := &cfg.Compute
+
+	*do = repositories.SweepDO{
+		Id:        item.Id,
+		Owner:     doc.Owner,
+		ProjectId: doc.ProjectId,
+
+		TrainingConfigDO: repositories.TrainingConfigDO{
+			ProjectName:     doc.ProjectName,
+			ProjectRepoId:   doc.ProjectRepoId,
+			Name:            cfg.Name,
+			Desc:            cfg.Desc,
+			CodeDir:         cfg.CodeDir,
+			BootFile:        cfg.BootFile,
+			Inputs:          t.toInputs(cfg.Inputs),
+			EnableAim:       cfg.EnableAim,
+			EnableOutput:    cfg.EnableOutput,
+			Env:             t.toKeyValues(cfg.Env),
+			Hyperparameters: t.toKeyValues(cfg.Hyperparameters),
+			Compute: repositories.ComputeDO{
+				Type:    c.Type,
+				Flavor:  c.Flavor,
+				Version: c.Version,
+			},
+		},
+
+		Strategy:    item.Strategy,
+		MetricName:  item.MetricName,
+		MetricGoal:  item.MetricGoal,
+		Concurrency: item.Concurrency,
+		CreatedAt:   item.CreatedAt,
+		Version:     item.Version,
+	}
+
+	do.Parameters = make([]repositories.SweepParameterDO, len(item.Parameters))
+	for i := range item.Parameters {
+		do.Parameters[i] = repositories.SweepParameterDO{
+			Key:    item.Parameters[i].Key,
+			Values: item.Parameters[i].Values,
+		}
+	}
+
+	do.Trials = make([]repositories.SweepTrialDO, len(item.Trials))
+	for i := range item.Trials {
+		v := &item.Trials[i]
+
+		do.Trials[i] = repositories.SweepTrialDO{
+			Hyperparameters: t.toKeyValues(v.Hyperparameters),
+			TrainingId:      v.TrainingId,
+			Done:            v.Done,
+		}
+	}
+}
@@ -2,8 +2,11 @@ package mongodb
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/opensourceways/xihe-server/infrastructure/repositories"
 )
@@ -290,6 +293,67 @@ func (col training) UpdateJobDetail(info *repositories.TrainingIndexDO, detail *
 	return withContext(f)
 }
 
+// UpdateJobDetailWithVersion updates the detail only if the version of doc is not changed,
+// and it increases the version.
+func (col training) UpdateJobDetailWithVersion(
+	info *repositories.TrainingIndexDO, detail *repositories.TrainingJobDetailDO, version int,
+) error {
+	v := dJobDetail{
+		Duration:   detail.Duration,
+		Error:      detail.Error,
+		Status:     detail.Status,
+		LogPath:    detail.LogPath,
+		AimPath:    detail.AimPath,
+		OutputPath: detail.OutputPath,
+	}
+
+	doc, err := genDoc(v)
+	if err != nil {
+		return err
+	}
+
+	filter := trainingDocFilter(info.User, info.ProjectId)
+	filter[fieldVersion] = version
+
+	updated := false
+
+	f := func(ctx context.Context) error {
+		r, err := cli.collection(col.collectionName).UpdateOne(
+			ctx, filter,
+			bson.M{
+				mongoCmdSet: bson.M{
+					fmt.Sprintf("%s.$[i].%s", fieldItems, fieldDetail): doc,
+				},
+				mongoCmdInc: bson.M{fieldVersion: 1},
+			},
+			&options.UpdateOptions{
+				ArrayFilters: &options.ArrayFilters{
+					Filters: bson.A{
+						bson.M{"i." + fieldId: info.TrainingId},
+					},
+				},
+			},
+		)
+		if err == nil {
+			updated = r.MatchedCount > 0
+		}
+
+		return err
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if !updated {
+		return repositories.NewErrorConcurrentUpdating(
+			errors.New("no update"),
+		)
+	}
+
+	return nil
+}
+
 func (col training) GetJobDetail(info *repositories.TrainingIndexDO) (
 	do repositories.TrainingJobDetailDO, endpoint string, err error,
 ) {
@@ -334,3 +398,42 @@ func (col training) toTrainingSummary(t *trainingItem, s *repositories.TrainingS
 		CreatedAt: t.CreatedAt,
 	}
 }
+
+func (col training) FindProjectsByInput(user, repoId string) (
+	[]repositories.ResourceIndexDO, error,
+) {
+	var v []dTraining
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				subfieldOfItems(fieldInputs): bson.M{
+					"$elemMatch": bson.M{
+						fieldUser: user,
+						fieldRId:  repoId,
+					},
+				},
+			},
+			bson.M{
+				fieldOwner: 1,
+				fieldPId:   1,
+			},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]repositories.ResourceIndexDO, len(v))
+	for i := range v {
+		r[i] = repositories.ResourceIndexDO{
+			Owner: v[i].Owner,
+			Id:    v[i].ProjectId,
+		}
+	}
+
+	return r, nil
+}
@@ -28,7 +28,23 @@ func (col training) toTrainingDoc(do *repositories.UserTrainingDO) (bson.M, erro
 			Version: c.Version,
 		},
 	}
-	return genDoc(docObj)
+
+	doc, err := genDoc(docObj)
+	if err != nil {
+		return nil, err
+	}
+
+	// the detail may be set when the training is queued
+	detail := &do.JobDetail
+	if doc[fieldDetail], err = genDoc(dJobDetail{
+		Status:   detail.Status,
+		Error:    detail.Error,
+		Duration: detail.Duration,
+	}); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
 }
 
 func (col training) toKeyValueDoc(kv []repositories.KeyValueDO) []dKeyValue {
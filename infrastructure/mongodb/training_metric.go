@@ -0,0 +1,172 @@
+package mongodb
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingMetricMapper(name string) repositories.TrainingMetricMapper {
+	return trainingMetric{name}
+}
+
+func trainingMetricDocFilter(index *repositories.TrainingIndexDO) bson.M {
+	return bson.M{
+		fieldOwner: index.User,
+		fieldPId:   index.ProjectId,
+		fieldTId:   index.TrainingId,
+	}
+}
+
+type trainingMetric struct {
+	collectionName string
+}
+
+func (col trainingMetric) newDoc(index *repositories.TrainingIndexDO, name string) error {
+	docFilter := trainingMetricDocFilter(index)
+	docFilter[fieldName] = name
+
+	doc := bson.M{
+		fieldOwner:  index.User,
+		fieldPId:    index.ProjectId,
+		fieldTId:    index.TrainingId,
+		fieldName:   name,
+		fieldPoints: bson.A{},
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col trainingMetric) Add(
+	index *repositories.TrainingIndexDO,
+	series []repositories.TrainingMetricSeriesDO, keep int,
+) error {
+	for i := range series {
+		if err := col.add(index, &series[i], keep); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (col trainingMetric) add(
+	index *repositories.TrainingIndexDO,
+	series *repositories.TrainingMetricSeriesDO, keep int,
+) error {
+	points := make(bson.A, len(series.Points))
+	for i := range series.Points {
+		points[i] = dMetricPoint{
+			Step:  series.Points[i].Step,
+			Value: series.Points[i].Value,
+		}
+	}
+
+	docFilter := trainingMetricDocFilter(index)
+	docFilter[fieldName] = series.Name
+
+	push := func() error {
+		return withContext(func(ctx context.Context) error {
+			return cli.pushElemsToLimitedArray(
+				ctx, col.collectionName, fieldPoints, keep,
+				docFilter, points,
+			)
+		})
+	}
+
+	err := push()
+	if err == nil || !isDocNotExists(err) {
+		return err
+	}
+
+	if err = col.newDoc(index, series.Name); err != nil {
+		return err
+	}
+
+	return push()
+}
+
+func (col trainingMetric) Find(index *repositories.TrainingIndexDO, names []string) (
+	[]repositories.TrainingMetricSeriesDO, error,
+) {
+	docFilter := trainingMetricDocFilter(index)
+	if len(names) > 0 {
+		docFilter[fieldName] = bson.M{"$in": names}
+	}
+
+	return col.find(docFilter, nil)
+}
+
+func (col trainingMetric) FindLast(index *repositories.TrainingIndexDO) (
+	[]repositories.TrainingMetricSeriesDO, error,
+) {
+	return col.find(
+		trainingMetricDocFilter(index),
+		bson.M{
+			fieldName:   1,
+			fieldPoints: bson.M{"$slice": -1},
+		},
+	)
+}
+
+func (col trainingMetric) find(docFilter, project bson.M) (
+	[]repositories.TrainingMetricSeriesDO, error,
+) {
+	var v []dTrainingMetric
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName, docFilter, project, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := make([]repositories.TrainingMetricSeriesDO, len(v))
+	for i := range v {
+		item := &v[i]
+
+		points := make([]repositories.TrainingMetricPointDO, len(item.Points))
+		for j := range item.Points {
+			points[j] = repositories.TrainingMetricPointDO{
+				Step:  item.Points[j].Step,
+				Value: item.Points[j].Value,
+			}
+		}
+
+		r[i] = repositories.TrainingMetricSeriesDO{
+			Name:   item.Name,
+			Points: points,
+		}
+	}
+
+	return r, nil
+}
+
+func (col trainingMetric) Delete(index *repositories.TrainingIndexDO) error {
+	f := func(ctx context.Context) error {
+		_, err := cli.collection(col.collectionName).DeleteMany(
+			ctx, trainingMetricDocFilter(index),
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
@@ -0,0 +1,398 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+)
+
+func NewTrainingScheduleMapper(name string) repositories.TrainingScheduleMapper {
+	return trainingSchedule{name}
+}
+
+type trainingSchedule struct {
+	collectionName string
+}
+
+func (col trainingSchedule) newDoc(owner, projectId string) error {
+	docFilter := trainingDocFilter(owner, projectId)
+
+	doc := bson.M{
+		fieldOwner:   owner,
+		fieldPId:     projectId,
+		fieldItems:   bson.A{},
+		fieldVersion: 0,
+	}
+
+	f := func(ctx context.Context) error {
+		_, err := cli.newDocIfNotExist(
+			ctx, col.collectionName, docFilter, doc,
+		)
+
+		return err
+	}
+
+	if err := withContext(f); err != nil && isDBError(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (col trainingSchedule) Insert(do *repositories.TrainingScheduleDO, version int) (
+	identity string, err error,
+) {
+	identity, err = col.insert(do, version)
+	if err == nil || !isDocNotExists(err) {
+		return
+	}
+
+	// doc is not exist or duplicate insert
+
+	if err = col.newDoc(do.Owner, do.ProjectId); err == nil {
+		identity, err = col.insert(do, version)
+		if err != nil && isDocNotExists(err) {
+			err = repositories.NewErrorDuplicateCreating(err)
+		}
+	}
+
+	return
+}
+
+func (col trainingSchedule) insert(do *repositories.TrainingScheduleDO, version int) (
+	identity string, err error,
+) {
+	identity = newId()
+	do.Id = identity
+
+	doc, err := genDoc(col.toTrainingScheduleItem(do))
+	if err != nil {
+		return
+	}
+
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		return cli.updateDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(do.Owner, do.ProjectId),
+			bson.M{fieldItems: doc}, mongoCmdPush, version,
+		)
+	}
+
+	err = withContext(f)
+
+	return
+}
+
+func (col trainingSchedule) Get(index *repositories.TrainingScheduleIndexDO) (
+	do repositories.TrainingScheduleDO, err error,
+) {
+	var v []dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		return cli.getArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.ScheduleId),
+			bson.M{
+				fieldOwner: 1,
+				fieldPId:   1,
+				fieldItems: 1,
+			},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		return
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		err = repositories.NewErrorDataNotExists(errDocNotExists)
+	} else {
+		col.toTrainingScheduleDO(&v[0], &v[0].Items[0], &do)
+	}
+
+	return
+}
+
+func (col trainingSchedule) List(user, projectId string) (
+	[]repositories.TrainingScheduleDO, int, error,
+) {
+	var v dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		return cli.getDoc(
+			ctx, col.collectionName,
+			trainingDocFilter(user, projectId),
+			bson.M{fieldItems + "." + fieldRuns: 0}, &v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		if isDocNotExists(err) {
+			return nil, 0, nil
+		}
+
+		return nil, 0, err
+	}
+
+	items := v.Items
+	r := make([]repositories.TrainingScheduleDO, len(items))
+
+	for i := range items {
+		col.toTrainingScheduleDO(&v, &items[i], &r[i])
+	}
+
+	return r, v.Version, nil
+}
+
+func (col trainingSchedule) Update(do *repositories.TrainingScheduleDO) error {
+	updated := false
+
+	f := func(ctx context.Context) (err error) {
+		updated, err = cli.updateArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(do.Owner, do.ProjectId),
+			resourceIdFilter(do.Id),
+			bson.M{
+				fieldCron:    do.Cron,
+				fieldPolicy:  do.Policy,
+				fieldEnabled: do.Enabled,
+				fieldNextRun: do.NextRunAt,
+			},
+			do.Version, 0,
+		)
+
+		return
+	}
+
+	if err := withContext(f); err != nil {
+		return err
+	}
+
+	if !updated {
+		return repositories.NewErrorConcurrentUpdating(
+			errors.New("no update"),
+		)
+	}
+
+	return nil
+}
+
+func (col trainingSchedule) Delete(index *repositories.TrainingScheduleIndexDO) error {
+	f := func(ctx context.Context) error {
+		return cli.pullArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.ScheduleId),
+		)
+	}
+
+	return withContext(f)
+}
+
+func (col trainingSchedule) FindDue(t int64) ([]repositories.TrainingScheduleDO, error) {
+	var v []dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		return cli.getDocs(
+			ctx, col.collectionName,
+			bson.M{
+				fieldItems: bson.M{"$elemMatch": bson.M{
+					fieldEnabled: true,
+					fieldNextRun: bson.M{"$lte": t},
+				}},
+			},
+			bson.M{
+				fieldOwner: 1,
+				fieldPId:   1,
+				fieldItems: 1,
+			},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	r := []repositories.TrainingScheduleDO{}
+
+	for i := range v {
+		doc := &v[i]
+
+		for j := range doc.Items {
+			item := &doc.Items[j]
+
+			if item.Enabled && item.NextRunAt <= t {
+				do := repositories.TrainingScheduleDO{}
+				col.toTrainingScheduleDO(doc, item, &do)
+
+				r = append(r, do)
+			}
+		}
+	}
+
+	return r, nil
+}
+
+func (col trainingSchedule) AddRun(
+	index *repositories.TrainingScheduleIndexDO,
+	run *repositories.TrainingScheduleRunDO, keep int,
+) error {
+	doc, err := genDoc(trainingScheduleRun{
+		TriggeredAt: run.TriggeredAt,
+		TrainingId:  run.TrainingId,
+		Status:      run.Status,
+		Reason:      run.Reason,
+	})
+	if err != nil {
+		return err
+	}
+
+	// keep the latest runs of each schedule, and the version of schedule
+	// is not changed, so that it will not fail to update the schedule.
+	f := func(ctx context.Context) error {
+		_, err := cli.modifyArrayElemWithoutVersion(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.ScheduleId),
+			bson.M{fieldRuns: bson.M{
+				"$each":  bson.A{doc},
+				"$slice": -keep,
+			}},
+			mongoCmdPush,
+		)
+
+		return err
+	}
+
+	return withContext(f)
+}
+
+func (col trainingSchedule) ListRuns(index *repositories.TrainingScheduleIndexDO) (
+	[]repositories.TrainingScheduleRunDO, error,
+) {
+	var v []dTrainingSchedule
+
+	f := func(ctx context.Context) error {
+		return cli.getArrayElem(
+			ctx, col.collectionName, fieldItems,
+			trainingDocFilter(index.User, index.ProjectId),
+			resourceIdFilter(index.ScheduleId),
+			bson.M{fieldItems + "." + fieldRuns: 1},
+			&v,
+		)
+	}
+
+	if err := withContext(f); err != nil {
+		return nil, err
+	}
+
+	if len(v) == 0 || len(v[0].Items) == 0 {
+		return nil, nil
+	}
+
+	runs := v[0].Items[0].Runs
+	r := make([]repositories.TrainingScheduleRunDO, 0, len(runs))
+
+	// the latest is the first one
+	for i := len(runs) - 1; i >= 0; i-- {
+		item := &runs[i]
+
+		r = append(r, repositories.TrainingScheduleRunDO{
+			ScheduleId:  index.ScheduleId,
+			TriggeredAt: item.TriggeredAt,
+			TrainingId:  item.TrainingId,
+			Status:      item.Status,
+			Reason:      item.Reason,
+		})
+	}
+
+	return r, nil
+}
+
+func (col trainingSchedule) toTrainingScheduleItem(
+	do *repositories.TrainingScheduleDO,
+) trainingScheduleItem {
+	return trainingScheduleItem{
+		Id:         do.Id,
+		TrainingId: do.TrainingId,
+		Config:     col.toTrainingConfigDoc(&do.Config),
+		Cron:       do.Cron,
+		Policy:     do.Policy,
+		Enabled:    do.Enabled,
+		NextRunAt:  do.NextRunAt,
+		CreatedAt:  do.CreatedAt,
+	}
+}
+
+func (col trainingSchedule) toTrainingConfigDoc(do *repositories.TrainingConfigDO) dTrainingConfig {
+	c := &do.Compute
+
+	return dTrainingConfig{
+		ProjectName:     do.ProjectName,
+		ProjectRepoId:   do.ProjectRepoId,
+		Name:            do.Name,
+		Desc:            do.Desc,
+		CodeDir:         do.CodeDir,
+		BootFile:        do.BootFile,
+		Inputs:          training{}.toInputDoc(do.Inputs),
+		EnableAim:       do.EnableAim,
+		EnableOutput:    do.EnableOutput,
+		Env:             training{}.toKeyValueDoc(do.Env),
+		Hyperparameters: training{}.toKeyValueDoc(do.Hyperparameters),
+		Compute: dCompute{
+			Type:    c.Type,
+			Flavor:  c.Flavor,
+			Version: c.Version,
+		},
+	}
+}
+
+func (col trainingSchedule) toTrainingConfigDO(doc *dTrainingConfig) repositories.TrainingConfigDO {
+	c := &doc.Compute
+
+	return repositories.TrainingConfigDO{
+		ProjectName:     doc.ProjectName,
+		ProjectRepoId:   doc.ProjectRepoId,
+		Name:            doc.Name,
+		Desc:            doc.Desc,
+		CodeDir:         doc.CodeDir,
+		BootFile:        doc.BootFile,
+		Inputs:          training{}.toInputs(doc.Inputs),
+		EnableAim:       doc.EnableAim,
+		EnableOutput:    doc.EnableOutput,
+		Env:             training{}.toKeyValues(doc.Env),
+		Hyperparameters: training{}.toKeyValues(doc.Hyperparameters),
+		Compute: repositories.ComputeDO{
+			Type:    c.Type,
+			Flavor:  c.Flavor,
+			Version: c.Version,
+		},
+	}
+}
+
+func (col trainingSchedule) toTrainingScheduleDO(
+	doc *dTrainingSchedule, item *trainingScheduleItem,
+	do *repositories.TrainingScheduleDO,
+) {
+	*do = repositories.TrainingScheduleDO{
+		Id:         item.Id,
+		Owner:      doc.Owner,
+		ProjectId:  doc.ProjectId,
+		TrainingId: item.TrainingId,
+		Config:     col.toTrainingConfigDO(&item.Config),
+		Cron:       item.Cron,
+		Policy:     item.Policy,
+		Enabled:    item.Enabled,
+		NextRunAt:  item.NextRunAt,
+		CreatedAt:  item.CreatedAt,
+		Version:    item.Version,
+	}
+}
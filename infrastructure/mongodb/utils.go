@@ -324,6 +324,29 @@ func (cli *client) pushElemToLimitedArray(
 	return nil
 }
 
+func (cli *client) pushElemsToLimitedArray(
+	ctx context.Context,
+	collection, array string, keep int,
+	filterOfDoc bson.M, values bson.A,
+) error {
+	r, err := cli.collection(collection).UpdateOne(
+		ctx, filterOfDoc,
+		bson.M{mongoCmdPush: bson.M{array: bson.M{
+			"$each":  values,
+			"$slice": -keep,
+		}}},
+	)
+	if err != nil {
+		return dbError{err}
+	}
+
+	if r.MatchedCount == 0 {
+		return errDocNotExists
+	}
+
+	return nil
+}
+
 func (cli *client) pullNestedArrayElem(
 	ctx context.Context, collection, array string,
 	filterOfDoc, filterOfArray, data bson.M,
@@ -542,6 +565,24 @@ func (cli *client) pullArrayElem(
 	return nil
 }
 
+func (cli *client) pullArrayElemAndInc(
+	ctx context.Context, collection, array string,
+	filterOfDoc, filterOfArray, inc bson.M,
+) error {
+	update := bson.M{
+		mongoCmdPull: bson.M{array: filterOfArray},
+		mongoCmdInc:  inc,
+	}
+
+	col := cli.collection(collection)
+
+	if _, err := col.UpdateOne(ctx, filterOfDoc, update); err != nil {
+		return dbError{err}
+	}
+
+	return nil
+}
+
 func (cli *client) getArrayElem(
 	ctx context.Context, collection, array string,
 	filterOfDoc, filterOfArray bson.M,
@@ -15,6 +15,7 @@ type DatasetMapper interface {
 	Get(string, string) (DatasetDO, error)
 	GetByName(string, string) (DatasetDO, error)
 	GetSummaryByName(string, string) (ResourceSummaryDO, error)
+	GetSummaryByRepoId(string, string) (ResourceSummaryDO, error)
 
 	ListUsersDatasets(map[string][]string) ([]DatasetSummaryDO, error)
 	ListSummary(map[string][]string) ([]ResourceSummaryDO, error)
@@ -177,6 +178,17 @@ func (impl dataset) GetSummaryByName(owner domain.Account, name domain.ResourceN
 	return v.toDataset()
 }
 
+func (impl dataset) GetSummaryByRepoId(owner domain.Account, repoId string) (
+	domain.ResourceSummary, error,
+) {
+	v, err := impl.mapper.GetSummaryByRepoId(owner.Account(), repoId)
+	if err != nil {
+		return domain.ResourceSummary{}, convertError(err)
+	}
+
+	return v.toDataset()
+}
+
 func (impl dataset) toDatasetDO(d *domain.Dataset) DatasetDO {
 	do := DatasetDO{
 		Id:        d.Id,
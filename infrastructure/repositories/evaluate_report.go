@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type EvaluateReportMapper interface {
+	Replace(*EvaluateReportDO) error
+	Get(*EvaluateIndexDO) (EvaluateReportDO, error)
+}
+
+func NewEvaluateReportRepository(mapper EvaluateReportMapper) repository.EvaluateReport {
+	return evaluateReport{mapper}
+}
+
+type evaluateReport struct {
+	mapper EvaluateReportMapper
+}
+
+func (impl evaluateReport) Save(v *domain.EvaluateReport) error {
+	do := impl.toEvaluateReportDO(v)
+
+	return convertError(impl.mapper.Replace(&do))
+}
+
+func (impl evaluateReport) Get(index *domain.EvaluateIndex) (
+	r domain.EvaluateReport, err error,
+) {
+	do := impl.toEvaluateIndexDO(index)
+
+	v, err := impl.mapper.Get(&do)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	if err = v.toEvaluateReport(&r); err == nil {
+		r.EvaluateIndex = *index
+	}
+
+	return
+}
+
+func (impl evaluateReport) toEvaluateIndexDO(index *domain.EvaluateIndex) EvaluateIndexDO {
+	return EvaluateIndexDO{
+		Id:         index.Id,
+		TrainingId: index.TrainingId,
+		Project:    toResourceIndexDO(&index.Project),
+	}
+}
+
+func (impl evaluateReport) toEvaluateReportDO(v *domain.EvaluateReport) EvaluateReportDO {
+	do := EvaluateReportDO{
+		Index:     impl.toEvaluateIndexDO(&v.EvaluateIndex),
+		Results:   make([]EvaluateResultDO, len(v.Results)),
+		Artifacts: v.Artifacts,
+		UpdatedAt: v.UpdatedAt,
+	}
+
+	for i := range v.Results {
+		item := &v.Results[i]
+
+		metrics := make(map[string]float64, len(item.Metrics))
+		for _, m := range item.Metrics {
+			metrics[m.Name.CustomizedKey()] = m.Value
+		}
+
+		do.Results[i] = EvaluateResultDO{
+			Parms:   item.Parms,
+			Metrics: metrics,
+		}
+	}
+
+	return do
+}
+
+type EvaluateParmsDO = domain.EvaluateParms
+type EvaluateArtifactDO = domain.EvaluateArtifact
+
+type EvaluateReportDO struct {
+	Index     EvaluateIndexDO
+	Results   []EvaluateResultDO
+	Artifacts []EvaluateArtifactDO
+	UpdatedAt int64
+}
+
+type EvaluateResultDO struct {
+	Parms   EvaluateParmsDO
+	Metrics map[string]float64
+}
+
+func (do *EvaluateReportDO) toEvaluateReport(r *domain.EvaluateReport) error {
+	r.Results = make([]domain.EvaluateResult, len(do.Results))
+
+	for i := range do.Results {
+		item := &do.Results[i]
+
+		metrics := make([]domain.EvaluateMetric, 0, len(item.Metrics))
+		for k, v := range item.Metrics {
+			name, err := domain.NewCustomizedKey(k)
+			if err != nil {
+				return err
+			}
+
+			metrics = append(metrics, domain.EvaluateMetric{
+				Name:  name,
+				Value: v,
+			})
+		}
+
+		r.Results[i] = domain.EvaluateResult{
+			Parms:   item.Parms,
+			Metrics: metrics,
+		}
+	}
+
+	r.Artifacts = do.Artifacts
+	r.UpdatedAt = do.UpdatedAt
+
+	return nil
+}
@@ -13,6 +13,9 @@ type FinetuneMapper interface {
 	Get(*FinetuneIndexDO) (FinetuneDetailDO, error)
 	List(user string) (UserFinetunesDO, int, error)
 
+	InsertUser(user string, do *FinetuneUserInfoDO) error
+	UpdateUser(user string, do *FinetuneUserInfoDO, version int) error
+
 	GetJob(*FinetuneIndexDO) (FinetuneJobDO, error)
 	UpdateJobInfo(*FinetuneIndexDO, *FinetuneJobInfoDO) error
 	UpdateJobDetail(*FinetuneIndexDO, *FinetuneJobDetailDO) error
@@ -80,7 +83,7 @@ func (impl finetuneImpl) List(user domain.Account) (
 	}
 
 	r.Version = version
-	r.Expiry = v.Expiry
+	r.FinetuneUserInfo = v.FinetuneUserInfoDO
 
 	if len(v.Datas) == 0 {
 		return
@@ -97,6 +100,24 @@ func (impl finetuneImpl) List(user domain.Account) (
 	return
 }
 
+func (impl finetuneImpl) AddUser(user domain.Account, info *domain.FinetuneUserInfo) error {
+	if err := impl.mapper.InsertUser(user.Account(), info); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl finetuneImpl) UpdateUser(
+	user domain.Account, info *domain.FinetuneUserInfo, version int,
+) error {
+	if err := impl.mapper.UpdateUser(user.Account(), info, version); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
 func (impl finetuneImpl) GetJob(index *domain.FinetuneIndex) (domain.FinetuneJob, error) {
 	do := impl.toFinetuneIndexDO(index)
 
@@ -109,8 +109,11 @@ func (do *FinetuneSummaryDO) toFinetuneSummary(obj *domain.FinetuneSummary) (err
 	return
 }
 
+type FinetuneQuotaDO = domain.FinetuneQuota
+type FinetuneUserInfoDO = domain.FinetuneUserInfo
+
 type UserFinetunesDO struct {
-	Expiry int64
+	FinetuneUserInfoDO
 
 	Datas []FinetuneSummaryDO
 }
@@ -12,6 +12,7 @@ type InferenceMapper interface {
 	Get(*InferenceIndexDO) (InferenceSummaryDO, error)
 	UpdateDetail(*InferenceIndexDO, *InferenceDetailDO) error
 	List(*ResourceIndexDO, string) ([]InferenceSummaryDO, int, error)
+	ListRunning(owner string, t int64) ([]InferenceInstanceDO, error)
 }
 
 func NewInferenceRepository(mapper InferenceMapper) repository.Inference {
@@ -75,6 +76,24 @@ func (impl inference) FindInstances(info *domain.ResourceIndex, lastCommit strin
 	return
 }
 
+func (impl inference) FindRunningInstances(owner domain.Account, t int64) (
+	[]repository.InferenceInstance, error,
+) {
+	v, err := impl.mapper.ListRunning(owner.Account(), t)
+	if err != nil || len(v) == 0 {
+		return nil, convertError(err)
+	}
+
+	r := make([]repository.InferenceInstance, len(v))
+	for i := range v {
+		if err = v[i].toInferenceInstance(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
 func (impl inference) UpdateDetail(
 	info *domain.InferenceIndex, detail *domain.InferenceDetail,
 ) error {
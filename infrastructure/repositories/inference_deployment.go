@@ -0,0 +1,148 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type InferenceDeploymentMapper interface {
+	Insert(*InferenceDeploymentDO, int) (string, error)
+	Get(*ResourceIndexDO, string) (InferenceDeploymentDO, error)
+	List(*ResourceIndexDO) ([]InferenceDeploymentDO, int, error)
+	Update(*InferenceDeploymentDO) error
+}
+
+func NewInferenceDeploymentRepository(mapper InferenceDeploymentMapper) repository.InferenceDeployment {
+	return inferenceDeployment{mapper}
+}
+
+type inferenceDeployment struct {
+	mapper InferenceDeploymentMapper
+}
+
+func (impl inferenceDeployment) Save(d *domain.InferenceDeployment, version int) (string, error) {
+	if d.Id != "" {
+		return "", errors.New("must be a new inference deployment")
+	}
+
+	do := impl.toInferenceDeploymentDO(d)
+
+	v, err := impl.mapper.Insert(&do, version)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl inferenceDeployment) Get(index *domain.InferenceDeploymentIndex) (
+	r domain.InferenceDeployment, err error,
+) {
+	do := toResourceIndexDO(&index.Project)
+
+	v, err := impl.mapper.Get(&do, index.Id)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toInferenceDeployment(&r)
+	}
+
+	return
+}
+
+func (impl inferenceDeployment) List(project *domain.ResourceIndex) (
+	r []domain.InferenceDeployment, version int, err error,
+) {
+	do := toResourceIndexDO(project)
+
+	v, version, err := impl.mapper.List(&do)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	if len(v) == 0 {
+		return
+	}
+
+	r = make([]domain.InferenceDeployment, len(v))
+	for i := range v {
+		if err = v[i].toInferenceDeployment(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (impl inferenceDeployment) Update(d *domain.InferenceDeployment) error {
+	do := impl.toInferenceDeploymentDO(d)
+
+	if err := impl.mapper.Update(&do); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl inferenceDeployment) toInferenceDeploymentDO(d *domain.InferenceDeployment) InferenceDeploymentDO {
+	return InferenceDeploymentDO{
+		Id:                d.Id,
+		Owner:             d.Project.Owner.Account(),
+		ProjectId:         d.Project.Id,
+		ProjectName:       d.ProjectName.ResourceName(),
+		ResourceLevel:     d.ResourceLevel,
+		Commit:            d.Commit,
+		InstanceId:        d.InstanceId,
+		PendingCommit:     d.PendingCommit,
+		PendingInstanceId: d.PendingInstanceId,
+		Error:             d.Error,
+		CreatedAt:         d.CreatedAt,
+		UpdatedAt:         d.UpdatedAt,
+		Version:           d.Version,
+	}
+}
+
+type InferenceDeploymentDO struct {
+	Id                string
+	Owner             string
+	ProjectId         string
+	ProjectName       string
+	ResourceLevel     string
+	Commit            string
+	InstanceId        string
+	PendingCommit     string
+	PendingInstanceId string
+	Error             string
+	CreatedAt         int64
+	UpdatedAt         int64
+	Version           int
+}
+
+func (do *InferenceDeploymentDO) toInferenceDeployment(r *domain.InferenceDeployment) (err error) {
+	*r = domain.InferenceDeployment{
+		Id:                do.Id,
+		ResourceLevel:     do.ResourceLevel,
+		Commit:            do.Commit,
+		InstanceId:        do.InstanceId,
+		PendingCommit:     do.PendingCommit,
+		PendingInstanceId: do.PendingInstanceId,
+		Error:             do.Error,
+		CreatedAt:         do.CreatedAt,
+		UpdatedAt:         do.UpdatedAt,
+		Version:           do.Version,
+	}
+
+	r.Project.Id = do.ProjectId
+
+	if r.Project.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	r.ProjectName, err = domain.NewResourceName(do.ProjectName)
+
+	return
+}
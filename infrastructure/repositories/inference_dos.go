@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
 )
 
 type InferenceIndexDO struct {
@@ -45,3 +46,28 @@ func (impl inference) toInferenceDO(obj *domain.Inference) InferenceDO {
 		ProjectOwner: obj.Project.Owner.Account(),
 	}
 }
+
+type InferenceInstanceDO struct {
+	InferenceIndexDO
+
+	ProjectName string
+
+	InferenceDetailDO
+}
+
+func (do *InferenceInstanceDO) toInferenceInstance(r *repository.InferenceInstance) (err error) {
+	if r.Project.Owner, err = domain.NewAccount(do.Project.Owner); err != nil {
+		return
+	}
+
+	if r.ProjectName, err = domain.NewResourceName(do.ProjectName); err != nil {
+		return
+	}
+
+	r.Id = do.Id
+	r.Project.Id = do.Project.Id
+	r.LastCommit = do.LastCommit
+	r.InferenceDetail = do.InferenceDetailDO
+
+	return
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type InferenceUsageMapper interface {
+	Add(*ResourceIndexDO, string, *InferenceInvocationDO) error
+	List(*ResourceIndexDO, string) ([]InferenceInvocationDO, error)
+}
+
+func NewInferenceUsageRepository(mapper InferenceUsageMapper) repository.InferenceUsage {
+	return inferenceUsage{mapper}
+}
+
+type inferenceUsage struct {
+	mapper InferenceUsageMapper
+}
+
+func (impl inferenceUsage) Add(v *domain.InferenceInvocation) error {
+	index := toResourceIndexDO(&v.Project)
+
+	do := InferenceInvocationDO{
+		InstanceId: v.InstanceId,
+		CalledAt:   v.CalledAt,
+		Latency:    v.Latency,
+		Status:     v.Status,
+	}
+
+	if v.Caller != nil {
+		do.Caller = v.Caller.Account()
+	}
+
+	return convertError(impl.mapper.Add(&index, utils.ToDate(v.CalledAt), &do))
+}
+
+func (impl inferenceUsage) List(project *domain.ResourceIndex, t int64) (
+	[]domain.InferenceInvocation, error,
+) {
+	index := toResourceIndexDO(project)
+
+	v, err := impl.mapper.List(&index, utils.ToDate(t))
+	if err != nil || len(v) == 0 {
+		return nil, convertError(err)
+	}
+
+	r := make([]domain.InferenceInvocation, len(v))
+	for i := range v {
+		if err = v[i].toInferenceInvocation(&r[i]); err != nil {
+			return nil, err
+		}
+
+		r[i].Project = *project
+	}
+
+	return r, nil
+}
+
+type InferenceInvocationDO struct {
+	InstanceId string
+	Caller     string
+	CalledAt   int64
+	Latency    int64
+	Status     string
+}
+
+func (do *InferenceInvocationDO) toInferenceInvocation(r *domain.InferenceInvocation) (err error) {
+	if do.Caller != "" {
+		if r.Caller, err = domain.NewAccount(do.Caller); err != nil {
+			return
+		}
+	}
+
+	r.InstanceId = do.InstanceId
+	r.CalledAt = do.CalledAt
+	r.Latency = do.Latency
+	r.Status = do.Status
+
+	return
+}
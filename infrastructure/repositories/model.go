@@ -15,6 +15,7 @@ type ModelMapper interface {
 	Get(string, string) (ModelDO, error)
 	GetByName(string, string) (ModelDO, error)
 	GetSummaryByName(string, string) (ResourceSummaryDO, error)
+	GetSummaryByRepoId(string, string) (ResourceSummaryDO, error)
 
 	ListUsersModels(map[string][]string) ([]ModelSummaryDO, error)
 	ListSummary(map[string][]string) ([]ResourceSummaryDO, error)
@@ -177,6 +178,17 @@ func (impl model) GetSummaryByName(owner domain.Account, name domain.ResourceNam
 	return v.toModel()
 }
 
+func (impl model) GetSummaryByRepoId(owner domain.Account, repoId string) (
+	domain.ResourceSummary, error,
+) {
+	v, err := impl.mapper.GetSummaryByRepoId(owner.Account(), repoId)
+	if err != nil {
+		return domain.ResourceSummary{}, convertError(err)
+	}
+
+	return v.toModel()
+}
+
 func (impl model) toModelDO(m *domain.Model) ModelDO {
 	do := ModelDO{
 		Id:        m.Id,
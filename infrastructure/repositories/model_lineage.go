@@ -0,0 +1,108 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type ModelLineageMapper interface {
+	Insert(model *ResourceIndexDO, do *ModelLineageDO, version int) error
+	List(model *ResourceIndexDO) ([]ModelLineageDO, int, error)
+	FindModels(project *ResourceIndexDO) ([]ResourceIndexDO, error)
+}
+
+func NewModelLineageRepository(mapper ModelLineageMapper) repository.ModelLineage {
+	return modelLineage{mapper}
+}
+
+type modelLineage struct {
+	mapper ModelLineageMapper
+}
+
+func (impl modelLineage) Add(
+	model *domain.ResourceIndex, v *domain.ModelLineage, version int,
+) error {
+	index := toResourceIndexDO(model)
+	do := impl.toModelLineageDO(v)
+
+	if err := impl.mapper.Insert(&index, &do, version); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl modelLineage) List(model *domain.ResourceIndex) (
+	[]domain.ModelLineage, int, error,
+) {
+	index := toResourceIndexDO(model)
+
+	v, version, err := impl.mapper.List(&index)
+	if err != nil {
+		return nil, 0, convertError(err)
+	}
+
+	r := make([]domain.ModelLineage, len(v))
+	for i := range v {
+		if err := v[i].toModelLineage(&r[i]); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return r, version, nil
+}
+
+func (impl modelLineage) FindModels(project *domain.ResourceIndex) (
+	[]domain.ResourceIndex, error,
+) {
+	index := toResourceIndexDO(project)
+
+	v, err := impl.mapper.FindModels(&index)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return convertToResourceIndex(v)
+}
+
+func (impl modelLineage) toModelLineageDO(v *domain.ModelLineage) ModelLineageDO {
+	datasets := make([]ResourceIndexDO, len(v.Datasets))
+	for i := range v.Datasets {
+		datasets[i] = toResourceIndexDO(&v.Datasets[i])
+	}
+
+	return ModelLineageDO{
+		Version:    v.Version,
+		Project:    toResourceIndexDO(&v.Project),
+		TrainingId: v.TrainingId,
+		Datasets:   datasets,
+		File:       v.File,
+		CreatedAt:  v.CreatedAt,
+	}
+}
+
+type ModelLineageDO struct {
+	Version    int
+	Project    ResourceIndexDO
+	TrainingId string
+	Datasets   []ResourceIndexDO
+	File       string
+	CreatedAt  int64
+}
+
+func (do *ModelLineageDO) toModelLineage(r *domain.ModelLineage) (err error) {
+	if err = do.Project.toResourceIndex(&r.Project); err != nil {
+		return
+	}
+
+	if r.Datasets, err = convertToResourceIndex(do.Datasets); err != nil {
+		return
+	}
+
+	r.Version = do.Version
+	r.TrainingId = do.TrainingId
+	r.File = do.File
+	r.CreatedAt = do.CreatedAt
+
+	return
+}
@@ -0,0 +1,66 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type ProjectForkMapper interface {
+	Insert(*ProjectForkDO) error
+	GetSource(*ResourceIndexDO) (ResourceIndexDO, error)
+	ListForks(*ResourceIndexDO) ([]ResourceIndexDO, error)
+}
+
+func NewProjectForkRepository(mapper ProjectForkMapper) repository.ProjectFork {
+	return projectFork{mapper}
+}
+
+type projectFork struct {
+	mapper ProjectForkMapper
+}
+
+func (impl projectFork) Save(v *domain.ProjectFork) error {
+	do := ProjectForkDO{
+		From: toResourceIndexDO(&v.From),
+		To:   toResourceIndexDO(&v.To),
+	}
+
+	if err := impl.mapper.Insert(&do); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl projectFork) FindSource(index *domain.ResourceIndex) (
+	r domain.ResourceIndex, err error,
+) {
+	do := toResourceIndexDO(index)
+
+	v, err := impl.mapper.GetSource(&do)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toResourceIndex(&r)
+	}
+
+	return
+}
+
+func (impl projectFork) FindForks(index *domain.ResourceIndex) (
+	[]domain.ResourceIndex, error,
+) {
+	do := toResourceIndexDO(index)
+
+	v, err := impl.mapper.ListForks(&do)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return convertToResourceIndex(v)
+}
+
+type ProjectForkDO struct {
+	From ResourceIndexDO
+	To   ResourceIndexDO
+}
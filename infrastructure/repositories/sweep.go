@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type SweepMapper interface {
+	Insert(*SweepDO, int) (string, error)
+	Get(*SweepIndexDO) (SweepDO, error)
+	List(user, projectId string) ([]SweepDO, int, error)
+	UpdateTrials(*SweepIndexDO, []SweepTrialDO, int) error
+}
+
+func NewSweepRepository(mapper SweepMapper) repository.Sweep {
+	return sweep{mapper}
+}
+
+type sweep struct {
+	mapper SweepMapper
+}
+
+func (impl sweep) Save(s *domain.Sweep, version int) (string, error) {
+	if s.Id != "" {
+		return "", errors.New("must be a new sweep")
+	}
+
+	do := impl.toSweepDO(s)
+
+	v, err := impl.mapper.Insert(&do, version)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl sweep) Get(index *domain.SweepIndex) (r domain.Sweep, err error) {
+	do := SweepIndexDO{
+		User:      index.Project.Owner.Account(),
+		ProjectId: index.Project.Id,
+		SweepId:   index.SweepId,
+	}
+
+	v, err := impl.mapper.Get(&do)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toSweep(&r)
+	}
+
+	return
+}
+
+func (impl sweep) List(user domain.Account, projectId string) (
+	r []domain.Sweep, version int, err error,
+) {
+	v, version, err := impl.mapper.List(user.Account(), projectId)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	if len(v) == 0 {
+		return
+	}
+
+	r = make([]domain.Sweep, len(v))
+	for i := range v {
+		if err = v[i].toSweep(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+func (impl sweep) UpdateTrials(s *domain.Sweep) error {
+	do := SweepIndexDO{
+		User:      s.Owner.Account(),
+		ProjectId: s.ProjectId,
+		SweepId:   s.Id,
+	}
+
+	err := impl.mapper.UpdateTrials(&do, impl.toSweepTrialDOs(s.Trials), s.Version)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
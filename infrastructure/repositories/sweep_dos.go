@@ -0,0 +1,153 @@
+package repositories
+
+import "github.com/opensourceways/xihe-server/domain"
+
+type SweepIndexDO struct {
+	User      string
+	ProjectId string
+	SweepId   string
+}
+
+type SweepDO struct {
+	Id        string
+	Owner     string
+	ProjectId string
+
+	TrainingConfigDO
+
+	Strategy    string
+	Parameters  []SweepParameterDO
+	MetricName  string
+	MetricGoal  string
+	Concurrency int
+	Trials      []SweepTrialDO
+
+	CreatedAt int64
+	Version   int
+}
+
+type SweepParameterDO struct {
+	Key    string
+	Values []string
+}
+
+type SweepTrialDO struct {
+	Hyperparameters []KeyValueDO
+	TrainingId      string
+	Done            bool
+}
+
+func (impl sweep) toSweepDO(s *domain.Sweep) SweepDO {
+	ut := domain.UserTraining{
+		Owner:          s.Owner,
+		ProjectId:      s.ProjectId,
+		TrainingConfig: s.TrainingConfig,
+	}
+
+	do := SweepDO{
+		Owner:            s.Owner.Account(),
+		ProjectId:        s.ProjectId,
+		TrainingConfigDO: training{}.toUserTrainingDO(&ut).TrainingConfigDO,
+		Strategy:         s.Strategy.SweepStrategy(),
+		MetricName:       s.Metric.Name.CustomizedKey(),
+		MetricGoal:       s.Metric.Goal.SweepGoal(),
+		Concurrency:      s.Concurrency,
+		Trials:           impl.toSweepTrialDOs(s.Trials),
+		CreatedAt:        s.CreatedAt,
+	}
+
+	do.Parameters = make([]SweepParameterDO, len(s.Parameters))
+	for i := range s.Parameters {
+		p := &s.Parameters[i]
+
+		values := make([]string, len(p.Values))
+		for j := range p.Values {
+			values[j] = p.Values[j].CustomizedValue()
+		}
+
+		do.Parameters[i] = SweepParameterDO{
+			Key:    p.Key.CustomizedKey(),
+			Values: values,
+		}
+	}
+
+	return do
+}
+
+func (impl sweep) toSweepTrialDOs(v []domain.SweepTrial) []SweepTrialDO {
+	r := make([]SweepTrialDO, len(v))
+
+	for i := range v {
+		r[i] = SweepTrialDO{
+			Hyperparameters: training{}.toKeyValueDOs(v[i].Hyperparameters),
+			TrainingId:      v[i].TrainingId,
+			Done:            v[i].Done,
+		}
+	}
+
+	return r
+}
+
+func (do *SweepDO) toSweep(s *domain.Sweep) (err error) {
+	if s.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	if s.TrainingConfig, err = do.TrainingConfigDO.toTrainingConfig(); err != nil {
+		return
+	}
+
+	if s.Strategy, err = domain.NewSweepStrategy(do.Strategy); err != nil {
+		return
+	}
+
+	if s.Metric.Name, err = domain.NewCustomizedKey(do.MetricName); err != nil {
+		return
+	}
+
+	if s.Metric.Goal, err = domain.NewSweepGoal(do.MetricGoal); err != nil {
+		return
+	}
+
+	s.Parameters = make([]domain.SweepParameter, len(do.Parameters))
+	for i := range do.Parameters {
+		if s.Parameters[i], err = do.Parameters[i].toSweepParameter(); err != nil {
+			return
+		}
+	}
+
+	s.Trials = make([]domain.SweepTrial, len(do.Trials))
+	for i := range do.Trials {
+		item := &do.Trials[i]
+
+		if s.Trials[i].Hyperparameters, err = do.toKeyValues(item.Hyperparameters); err != nil {
+			return
+		}
+
+		s.Trials[i].TrainingId = item.TrainingId
+		s.Trials[i].Done = item.Done
+	}
+
+	s.Id = do.Id
+	s.ProjectId = do.ProjectId
+	s.Concurrency = do.Concurrency
+	s.CreatedAt = do.CreatedAt
+	s.Version = do.Version
+
+	return
+}
+
+func (do *SweepParameterDO) toSweepParameter() (r domain.SweepParameter, err error) {
+	if r.Key, err = domain.NewCustomizedKey(do.Key); err != nil {
+		return
+	}
+
+	r.Values = make([]domain.CustomizedValue, len(do.Values))
+	for i := range do.Values {
+		if r.Values[i], err = domain.NewCustomizedValue(do.Values[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
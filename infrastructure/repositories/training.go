@@ -12,10 +12,12 @@ type TrainingMapper interface {
 	Delete(*TrainingIndexDO) error
 	Get(*TrainingIndexDO) (TrainingDetailDO, error)
 	GetTrainingConfig(*TrainingIndexDO) (TrainingConfigDO, error)
+	FindProjectsByInput(user, repoId string) ([]ResourceIndexDO, error)
 	List(user, projectId string) ([]TrainingSummaryDO, int, error)
 	UpdateJobInfo(*TrainingIndexDO, *TrainingJobInfoDO) error
 	GetJobInfo(*TrainingIndexDO) (TrainingJobInfoDO, error)
 	UpdateJobDetail(*TrainingIndexDO, *TrainingJobDetailDO) error
+	UpdateJobDetailWithVersion(*TrainingIndexDO, *TrainingJobDetailDO, int) error
 	GetJobDetail(*TrainingIndexDO) (TrainingJobDetailDO, string, error)
 }
 
@@ -143,3 +145,26 @@ func (impl training) UpdateJobDetail(info *domain.TrainingIndex, detail *domain.
 
 	return nil
 }
+
+func (impl training) UpdateJobDetailWithVersion(
+	info *domain.TrainingIndex, detail *domain.JobDetail, version int,
+) error {
+	do := impl.toTrainingIndexDO(info)
+
+	if err := impl.mapper.UpdateJobDetailWithVersion(&do, detail, version); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl training) FindProjectsByInput(user domain.Account, repoId string) (
+	[]domain.ResourceIndex, error,
+) {
+	v, err := impl.mapper.FindProjectsByInput(user.Account(), repoId)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return convertToResourceIndex(v)
+}
@@ -9,6 +9,7 @@ type UserTrainingDO struct {
 
 	TrainingConfigDO
 
+	JobDetail TrainingJobDetailDO
 	CreatedAt int64
 }
 
@@ -172,39 +173,43 @@ func (do *InputDO) toInput() (r domain.Input, err error) {
 }
 
 func (impl training) toUserTrainingDO(ut *domain.UserTraining) UserTrainingDO {
-	t := &ut.TrainingConfig
-	c := &t.Compute
-
-	do := UserTrainingDO{
+	return UserTrainingDO{
 		Id:        ut.Id,
 		Owner:     ut.Owner.Account(),
 		ProjectId: ut.ProjectId,
+		JobDetail: ut.JobDetail,
 		CreatedAt: ut.CreatedAt,
 
-		TrainingConfigDO: TrainingConfigDO{
-			Name:          t.Name.TrainingName(),
-			ProjectName:   t.ProjectName.ResourceName(),
-			ProjectRepoId: t.ProjectRepoId,
-
-			CodeDir:  t.CodeDir.Directory(),
-			BootFile: t.BootFile.FilePath(),
-
-			Hyperparameters: impl.toKeyValueDOs(t.Hyperparameters),
-			Env:             impl.toKeyValueDOs(t.Env),
-			Inputs:          impl.toInputDOs(t.Inputs),
-			EnableAim:       t.EnableAim,
-			EnableOutput:    t.EnableOutput,
-
-			Compute: ComputeDO{
-				Type:    c.Type.ComputeType(),
-				Flavor:  c.Flavor.ComputeFlavor(),
-				Version: c.Version.ComputeVersion(),
-			},
+		TrainingConfigDO: impl.toTrainingConfigDO(&ut.TrainingConfig),
+	}
+}
+
+func (impl training) toTrainingConfigDO(t *domain.TrainingConfig) TrainingConfigDO {
+	c := &t.Compute
+
+	do := TrainingConfigDO{
+		Name:          t.Name.TrainingName(),
+		ProjectName:   t.ProjectName.ResourceName(),
+		ProjectRepoId: t.ProjectRepoId,
+
+		CodeDir:  t.CodeDir.Directory(),
+		BootFile: t.BootFile.FilePath(),
+
+		Hyperparameters: impl.toKeyValueDOs(t.Hyperparameters),
+		Env:             impl.toKeyValueDOs(t.Env),
+		Inputs:          impl.toInputDOs(t.Inputs),
+		EnableAim:       t.EnableAim,
+		EnableOutput:    t.EnableOutput,
+
+		Compute: ComputeDO{
+			Type:    c.Type.ComputeType(),
+			Flavor:  c.Flavor.ComputeFlavor(),
+			Version: c.Version.ComputeVersion(),
 		},
 	}
 
 	if t.Desc != nil {
-		do.TrainingConfigDO.Desc = t.Desc.TrainingDesc()
+		do.Desc = t.Desc.TrainingDesc()
 	}
 
 	return do
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingMetricMapper interface {
+	// Add keeps the latest keep points of each series.
+	Add(index *TrainingIndexDO, series []TrainingMetricSeriesDO, keep int) error
+	Find(index *TrainingIndexDO, names []string) ([]TrainingMetricSeriesDO, error)
+	FindLast(*TrainingIndexDO) ([]TrainingMetricSeriesDO, error)
+	Delete(*TrainingIndexDO) error
+}
+
+func NewTrainingMetricRepository(mapper TrainingMetricMapper) repository.TrainingMetric {
+	return trainingMetric{mapper}
+}
+
+type trainingMetric struct {
+	mapper TrainingMetricMapper
+}
+
+func (impl trainingMetric) Add(info *domain.TrainingIndex, v []domain.TrainingMetric) error {
+	index := training{}.toTrainingIndexDO(info)
+
+	series := []TrainingMetricSeriesDO{}
+	m := map[string]int{}
+
+	for i := range v {
+		item := &v[i]
+		name := item.Name.CustomizedKey()
+
+		j, ok := m[name]
+		if !ok {
+			j = len(series)
+			m[name] = j
+			series = append(series, TrainingMetricSeriesDO{Name: name})
+		}
+
+		series[j].Points = append(series[j].Points, TrainingMetricPointDO{
+			Step:  item.Step,
+			Value: item.Value,
+		})
+	}
+
+	err := impl.mapper.Add(&index, series, domain.DomainConfig.MaxTrainingMetricPointNum)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl trainingMetric) Find(info *domain.TrainingIndex, names []string) (
+	r []domain.TrainingMetricSeries, err error,
+) {
+	index := training{}.toTrainingIndexDO(info)
+
+	v, err := impl.mapper.Find(&index, names)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	r = make([]domain.TrainingMetricSeries, len(v))
+	for i := range v {
+		if r[i].Name, err = domain.NewCustomizedKey(v[i].Name); err != nil {
+			return
+		}
+
+		r[i].Points = v[i].Points
+	}
+
+	return
+}
+
+func (impl trainingMetric) FindLast(info *domain.TrainingIndex) (
+	r []domain.TrainingMetric, err error,
+) {
+	index := training{}.toTrainingIndexDO(info)
+
+	v, err := impl.mapper.FindLast(&index)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	r = make([]domain.TrainingMetric, 0, len(v))
+	for i := range v {
+		item := &v[i]
+		if len(item.Points) == 0 {
+			continue
+		}
+
+		name, err1 := domain.NewCustomizedKey(item.Name)
+		if err1 != nil {
+			return nil, err1
+		}
+
+		p := &item.Points[len(item.Points)-1]
+
+		r = append(r, domain.TrainingMetric{
+			Name:  name,
+			Step:  p.Step,
+			Value: p.Value,
+		})
+	}
+
+	return
+}
+
+type TrainingMetricSeriesDO struct {
+	Name   string
+	Points []TrainingMetricPointDO
+}
+
+type TrainingMetricPointDO = domain.TrainingMetricPoint
+
+func (impl trainingMetric) Delete(info *domain.TrainingIndex) error {
+	index := training{}.toTrainingIndexDO(info)
+
+	if err := impl.mapper.Delete(&index); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package repositories
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/repository"
+)
+
+type TrainingScheduleMapper interface {
+	Insert(*TrainingScheduleDO, int) (string, error)
+	Get(*TrainingScheduleIndexDO) (TrainingScheduleDO, error)
+	List(user, projectId string) ([]TrainingScheduleDO, int, error)
+	Update(*TrainingScheduleDO) error
+	Delete(*TrainingScheduleIndexDO) error
+	FindDue(int64) ([]TrainingScheduleDO, error)
+
+	AddRun(*TrainingScheduleIndexDO, *TrainingScheduleRunDO, int) error
+	ListRuns(*TrainingScheduleIndexDO) ([]TrainingScheduleRunDO, error)
+}
+
+func NewTrainingScheduleRepository(mapper TrainingScheduleMapper) repository.TrainingSchedule {
+	return trainingSchedule{mapper}
+}
+
+type trainingSchedule struct {
+	mapper TrainingScheduleMapper
+}
+
+func (impl trainingSchedule) Save(s *domain.TrainingSchedule, version int) (string, error) {
+	if s.Id != "" {
+		return "", errors.New("must be a new training schedule")
+	}
+
+	do := impl.toTrainingScheduleDO(s)
+
+	v, err := impl.mapper.Insert(&do, version)
+	if err != nil {
+		return "", convertError(err)
+	}
+
+	return v, nil
+}
+
+func (impl trainingSchedule) Get(index *domain.TrainingScheduleIndex) (
+	r domain.TrainingSchedule, err error,
+) {
+	do := impl.toTrainingScheduleIndexDO(index)
+
+	v, err := impl.mapper.Get(&do)
+	if err != nil {
+		err = convertError(err)
+	} else {
+		err = v.toTrainingSchedule(&r)
+	}
+
+	return
+}
+
+func (impl trainingSchedule) List(project *domain.ResourceIndex) (
+	r []domain.TrainingSchedule, version int, err error,
+) {
+	v, version, err := impl.mapper.List(project.Owner.Account(), project.Id)
+	if err != nil {
+		err = convertError(err)
+
+		return
+	}
+
+	r, err = impl.toTrainingSchedules(v)
+
+	return
+}
+
+func (impl trainingSchedule) Update(s *domain.TrainingSchedule) error {
+	do := impl.toTrainingScheduleDO(s)
+
+	if err := impl.mapper.Update(&do); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl trainingSchedule) Delete(index *domain.TrainingScheduleIndex) error {
+	do := impl.toTrainingScheduleIndexDO(index)
+
+	if err := impl.mapper.Delete(&do); err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl trainingSchedule) FindDue(t int64) ([]domain.TrainingSchedule, error) {
+	v, err := impl.mapper.FindDue(t)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	return impl.toTrainingSchedules(v)
+}
+
+func (impl trainingSchedule) AddRun(
+	index *domain.TrainingScheduleIndex, run *domain.TrainingScheduleRun,
+) error {
+	do := impl.toTrainingScheduleIndexDO(index)
+
+	err := impl.mapper.AddRun(
+		&do,
+		&TrainingScheduleRunDO{
+			ScheduleId:  index.ScheduleId,
+			TriggeredAt: run.TriggeredAt,
+			TrainingId:  run.TrainingId,
+			Status:      run.Status,
+			Reason:      run.Reason,
+		},
+		domain.DomainConfig.MaxTrainingScheduleRunNum,
+	)
+	if err != nil {
+		return convertError(err)
+	}
+
+	return nil
+}
+
+func (impl trainingSchedule) ListRuns(index *domain.TrainingScheduleIndex) (
+	[]domain.TrainingScheduleRun, error,
+) {
+	do := impl.toTrainingScheduleIndexDO(index)
+
+	v, err := impl.mapper.ListRuns(&do)
+	if err != nil {
+		return nil, convertError(err)
+	}
+
+	r := make([]domain.TrainingScheduleRun, len(v))
+	for i := range v {
+		r[i] = domain.TrainingScheduleRun{
+			TriggeredAt: v[i].TriggeredAt,
+			TrainingId:  v[i].TrainingId,
+			Status:      v[i].Status,
+			Reason:      v[i].Reason,
+		}
+	}
+
+	return r, nil
+}
+
+func (impl trainingSchedule) toTrainingSchedules(v []TrainingScheduleDO) (
+	[]domain.TrainingSchedule, error,
+) {
+	if len(v) == 0 {
+		return nil, nil
+	}
+
+	r := make([]domain.TrainingSchedule, len(v))
+	for i := range v {
+		if err := v[i].toTrainingSchedule(&r[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
+
+func (impl trainingSchedule) toTrainingScheduleIndexDO(
+	index *domain.TrainingScheduleIndex,
+) TrainingScheduleIndexDO {
+	return TrainingScheduleIndexDO{
+		User:       index.Project.Owner.Account(),
+		ProjectId:  index.Project.Id,
+		ScheduleId: index.ScheduleId,
+	}
+}
+
+func (impl trainingSchedule) toTrainingScheduleDO(s *domain.TrainingSchedule) TrainingScheduleDO {
+	return TrainingScheduleDO{
+		Id:         s.Id,
+		Owner:      s.Project.Owner.Account(),
+		ProjectId:  s.Project.Id,
+		TrainingId: s.TrainingId,
+		Config:     training{}.toTrainingConfigDO(&s.Config),
+		Cron:       s.Cron.CronExpr(),
+		Policy:     s.Policy.TrainingSchedulePolicy(),
+		Enabled:    s.Enabled,
+		NextRunAt:  s.NextRunAt,
+		CreatedAt:  s.CreatedAt,
+		Version:    s.Version,
+	}
+}
+
+type TrainingScheduleIndexDO struct {
+	User       string
+	ProjectId  string
+	ScheduleId string
+}
+
+type TrainingScheduleDO struct {
+	Id         string
+	Owner      string
+	ProjectId  string
+	TrainingId string
+	Config     TrainingConfigDO
+	Cron       string
+	Policy     string
+	Enabled    bool
+	NextRunAt  int64
+	CreatedAt  int64
+	Version    int
+}
+
+func (do *TrainingScheduleDO) toTrainingSchedule(r *domain.TrainingSchedule) (err error) {
+	r.Id = do.Id
+	r.TrainingId = do.TrainingId
+	r.Enabled = do.Enabled
+	r.NextRunAt = do.NextRunAt
+	r.CreatedAt = do.CreatedAt
+	r.Version = do.Version
+	r.Project.Id = do.ProjectId
+
+	if r.Project.Owner, err = domain.NewAccount(do.Owner); err != nil {
+		return
+	}
+
+	if r.Config, err = do.Config.toTrainingConfig(); err != nil {
+		return
+	}
+
+	if r.Cron, err = domain.NewCronExpr(do.Cron); err != nil {
+		return
+	}
+
+	r.Policy, err = domain.NewTrainingSchedulePolicy(do.Policy)
+
+	return
+}
+
+type TrainingScheduleRunDO struct {
+	ScheduleId  string
+	TriggeredAt int64
+	TrainingId  string
+	Status      string
+	Reason      string
+}
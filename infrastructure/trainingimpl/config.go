@@ -1,5 +1,15 @@
 package trainingimpl
 
 type Config struct {
-	JobDoneStatus []string `json:"job_done_status"  required:"true"`
+	// JobDoneStatus is the list of job status reported by the training center
+	// which means the job is done. It is used to decide when to dispatch the
+	// next queued training, so it must be same in all the servers which update
+	// the status of training.
+	JobDoneStatus []string `json:"job_done_status"`
+}
+
+func (cfg *Config) SetDefault() {
+	if len(cfg.JobDoneStatus) == 0 {
+		cfg.JobDoneStatus = []string{"Completed", "Failed", "Abnormal", "Terminated"}
+	}
 }
@@ -1,6 +1,10 @@
 package trainingimpl
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
 	"github.com/opensourceways/xihe-training-center/sdk"
@@ -106,6 +110,43 @@ func (impl *trainingImpl) GetFileDownloadURL(endpoint, file string) (string, err
 	return v.URL, nil
 }
 
+func (impl *trainingImpl) DownloadFile(endpoint, file string, maxSize int) ([]byte, error) {
+	link, err := impl.GetFileDownloadURL(endpoint, file)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(link)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if code := resp.StatusCode; code < 200 || code > 299 {
+		return nil, fmt.Errorf("download file failed, status:%s", resp.Status)
+	}
+
+	tooBig := training.NewErrorFileTooBig(errors.New("the file is too big"))
+
+	if resp.ContentLength > int64(maxSize) {
+		return nil, tooBig
+	}
+
+	// read one more byte to know whether it is too big
+	// when the size is unknown.
+	v, err := io.ReadAll(io.LimitReader(resp.Body, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(v) > maxSize {
+		return nil, tooBig
+	}
+
+	return v, nil
+}
+
 func (impl *trainingImpl) toCompute(c *domain.Compute) sdk.Compute {
 	return sdk.Compute{
 		Type:    c.Type.ComputeType(),
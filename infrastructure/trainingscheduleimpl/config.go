@@ -0,0 +1,12 @@
+package trainingscheduleimpl
+
+type Config struct {
+	// Interval is the seconds between two scans of the due schedules.
+	Interval int64 `json:"interval"`
+}
+
+func (cfg *Config) SetDefault() {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 60
+	}
+}
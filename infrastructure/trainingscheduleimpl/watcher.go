@@ -0,0 +1,9 @@
+package trainingscheduleimpl
+
+import (
+	"github.com/opensourceways/xihe-server/common/infrastructure/ticker"
+)
+
+func NewWatcher(cfg *Config, handle func(int64) error) *ticker.Ticker {
+	return ticker.NewTicker("trigger training schedules", cfg.Interval, handle)
+}
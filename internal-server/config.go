@@ -1,16 +1,26 @@
 package main
 
 import (
+	"github.com/opensourceways/community-robot-lib/mq"
 	"github.com/opensourceways/community-robot-lib/utils"
 
 	"github.com/opensourceways/xihe-server/config"
 	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
 )
 
 type configuration struct {
 	Mongodb    config.Mongodb          `json:"mongodb"      required:"true"`
 	Postgresql config.PostgresqlConfig `json:"postgresql"   required:"true"`
 	Domain     domain.Config           `json:"domain"       required:"true"`
+	Training   trainingimpl.Config     `json:"training"     required:"true"`
+	MQ         config.MQ               `json:"mq"           required:"true"`
+}
+
+func (cfg *configuration) getMQConfig() mq.MQConfig {
+	return mq.MQConfig{
+		Addresses: cfg.MQ.ParseAddress(),
+	}
 }
 
 func (cfg *configuration) configItems() []interface{} {
@@ -18,6 +28,8 @@ func (cfg *configuration) configItems() []interface{} {
 		&cfg.Mongodb,
 		&cfg.Domain,
 		&cfg.Postgresql.DB,
+		&cfg.Training,
+		&cfg.MQ,
 	}
 }
 
@@ -26,8 +26,10 @@ import (
 	competitionrepo "github.com/opensourceways/xihe-server/competition/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/config"
 	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/infrastructure/messages"
 	"github.com/opensourceways/xihe-server/infrastructure/mongodb"
 	"github.com/opensourceways/xihe-server/infrastructure/repositories"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
 )
 
 type options struct {
@@ -85,6 +87,13 @@ func main() {
 		log.Fatalf("load config, err:%s", err.Error())
 	}
 
+	// mq
+	if err := messages.Init(cfg.getMQConfig(), log, cfg.MQ.Topics); err != nil {
+		log.Fatalf("initialize mq failed, err:%v", err)
+	}
+
+	defer messages.Exit(log)
+
 	// mongo
 	m := &cfg.Mongodb
 	if err := mongodb.Initialize(m.DBConn, m.DBName, m.DBCert); err != nil {
@@ -101,15 +110,23 @@ func main() {
 	collections := &cfg.Mongodb.Collections
 
 	// training
+	trainingRepo := repositories.NewTrainingRepository(
+		mongodb.NewTrainingMapper(collections.Training),
+	)
+
+	trainingMetricRepo := repositories.NewTrainingMetricRepository(
+		mongodb.NewTrainingMetricMapper(collections.TrainingMetric),
+	)
+
 	train := app.NewTrainingService(
 		log,
-		nil,
-		repositories.NewTrainingRepository(
-			mongodb.NewTrainingMapper(collections.Training),
-		),
-		nil, 0,
+		trainingimpl.NewTraining(&cfg.Training),
+		trainingRepo, trainingMetricRepo,
+		messages.NewMessageSender(), 0,
 	)
 
+	trainingMetric := app.NewTrainingMetricService(trainingMetricRepo, trainingRepo)
+
 	// finetune
 	finetuneService := app.NewFinetuneInternalService(
 		repositories.NewFinetuneRepository(
@@ -150,7 +167,7 @@ func main() {
 	s := server.NewServer()
 
 	s.RegisterFinetuneServer(finetuneServer{finetuneService})
-	s.RegisterTrainingServer(trainingServer{train})
+	s.RegisterTrainingServer(trainingServer{train, trainingMetric})
 	s.RegisterEvaluateServer(evaluateServer{evaluateService})
 	s.RegisterInferenceServer(inferenceServer{inferenceService})
 	s.RegisterCloudServer(cloudServer{cloudService})
@@ -163,6 +180,7 @@ func main() {
 
 type trainingServer struct {
 	service app.TrainingService
+	metric  app.TrainingMetricService
 }
 
 func (t trainingServer) SetTrainingInfo(index *training.TrainingIndex, v *training.TrainingInfo) error {
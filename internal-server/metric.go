@@ -0,0 +1,54 @@
+package main
+
+import (
+	"github.com/opensourceways/xihe-grpc-protocol/grpc/training"
+	"github.com/sirupsen/logrus"
+
+	"github.com/opensourceways/xihe-server/domain"
+)
+
+// trainingMetric is a scalar reported by the training job.
+type trainingMetric struct {
+	Name  string
+	Step  int
+	Value float64
+}
+
+// SetTrainingMetrics saves the metrics streamed by the training job.
+// The TrainingInfo of xihe-grpc-protocol can't carry the metrics yet,
+// so it will be called by SetTrainingInfo once the protocol supports it.
+func (t trainingServer) SetTrainingMetrics(index *training.TrainingIndex, v []trainingMetric) error {
+	u, err := domain.NewAccount(index.User)
+	if err != nil {
+		return nil
+	}
+
+	metrics := make([]domain.TrainingMetric, 0, len(v))
+	for i := range v {
+		item := &v[i]
+
+		name, err := domain.NewCustomizedKey(item.Name)
+		if err != nil {
+			logrus.Errorf("invalid metric name:%s, err:%s", item.Name, err.Error())
+
+			continue
+		}
+
+		metrics = append(metrics, domain.TrainingMetric{
+			Name:  name,
+			Step:  item.Step,
+			Value: item.Value,
+		})
+	}
+
+	return t.metric.Add(
+		&domain.TrainingIndex{
+			Project: domain.ResourceIndex{
+				Owner: u,
+				Id:    index.ProjectId,
+			},
+			TrainingId: index.Id,
+		},
+		metrics,
+	)
+}
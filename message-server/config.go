@@ -7,12 +7,14 @@ import (
 	asyncrepoimpl "github.com/opensourceways/xihe-server/async-server/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/cloud/infrastructure/cloudimpl"
 	cloudrepoimpl "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
 	"github.com/opensourceways/xihe-server/config"
 	"github.com/opensourceways/xihe-server/domain"
 	"github.com/opensourceways/xihe-server/infrastructure/evaluateimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/finetuneimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/inferenceimpl"
+	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
 )
 
 type configuration struct {
@@ -20,6 +22,7 @@ type configuration struct {
 	TrainingEndpoint string `json:"training_endpoint"  required:"true"`
 	FinetuneEndpoint string `json:"finetune_endpoint"  required:"true"`
 
+	Training   trainingimpl.Config  `json:"training"     required:"true"`
 	Inference  inferenceimpl.Config `json:"inference"    required:"true"`
 	Evaluate   evaluateConfig       `json:"evaluate"     required:"true"`
 	Cloud      cloudConfig          `json:"cloud"        required:"true"`
@@ -44,6 +47,7 @@ func (cfg *configuration) getMQConfig() mq.MQConfig {
 
 func (cfg *configuration) configItems() []interface{} {
 	return []interface{}{
+		&cfg.Training,
 		&cfg.Inference,
 		&cfg.Evaluate,
 		&cfg.Mongodb,
@@ -130,6 +134,8 @@ func (cfg *evaluateConfig) Validate() error {
 type cloudConfig struct {
 	SurvivalTime int `json:"survival_time"`
 
+	Workspace workspaceimpl.Config `json:"workspace" required:"true"`
+
 	cloudimpl.Config
 }
 
@@ -138,6 +144,8 @@ func (cfg *cloudConfig) SetDefault() {
 		cfg.SurvivalTime = 5 * 3600
 	}
 
+	cfg.Workspace.SetDefault()
+
 	var i interface{}
 	i = &cfg.Config
 
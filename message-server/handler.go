@@ -16,7 +16,9 @@ import (
 	bigmodelmessage "github.com/opensourceways/xihe-server/bigmodel/domain/message"
 	cloudapp "github.com/opensourceways/xihe-server/cloud/app"
 	cloudtypes "github.com/opensourceways/xihe-server/cloud/domain"
+	"github.com/opensourceways/xihe-server/cloud/domain/cloud"
 	"github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/inference"
 	"github.com/opensourceways/xihe-server/domain/message"
 	"github.com/opensourceways/xihe-server/domain/repository"
 	userapp "github.com/opensourceways/xihe-server/user/app"
@@ -49,6 +51,7 @@ type handler struct {
 	dataset   app.DatasetMessageService
 	project   app.ProjectMessageService
 	training  app.TrainingService
+	sweep     app.SweepMessageService
 	finetune  app.FinetuneMessageService
 	evaluate  app.EvaluateMessageService
 	inference app.InferenceMessageService
@@ -268,6 +271,25 @@ func (h *handler) HandleEventCreateTraining(info *domain.TrainingIndex) error {
 	)
 }
 
+func (h *handler) HandleEventTrainingDone(info *domain.TrainingIndex) error {
+	return h.do(func(bool) error {
+		err := h.sweep.HandleTrainingDone(info)
+		if err == nil {
+			err = h.training.DispatchQueued(&info.Project)
+		}
+
+		if err != nil {
+			h.log.Errorf(
+				"handle training(%s/%s/%s) done failed, err:%s",
+				info.Project.Owner.Account(), info.Project.Id,
+				info.TrainingId, err.Error(),
+			)
+		}
+
+		return err
+	})
+}
+
 func (h *handler) HandleEventCreateFinetune(index *domain.FinetuneIndex) error {
 	h.log.Debugf("start handle finetune: %s/%s", index.Owner.Account(), index.Id)
 
@@ -313,6 +335,23 @@ func (h *handler) HandleEventExtendInferenceSurvivalTime(info *message.Inference
 	})
 }
 
+func (h *handler) HandleEventStopInference(index *domain.InferenceIndex) error {
+	return h.do(func(bool) error {
+		err := h.inference.StopInferenceInstance(index)
+		if err != nil {
+			h.log.Error(err)
+
+			// the instance has expired, and it will be released
+			// by the container manager when its survival time is over.
+			if inference.IsErrorUnsupported(err) {
+				return nil
+			}
+		}
+
+		return err
+	})
+}
+
 func (h *handler) HandleEventCreateEvaluate(info *message.EvaluateInfo) error {
 	return h.do(func(bool) error {
 		err := h.evaluate.CreateEvaluateInstance(info)
@@ -336,6 +375,39 @@ func (h *handler) HandleEventPodSubscribe(info *cloudtypes.PodInfo) error {
 	})
 }
 
+func (h *handler) HandleEventPodRelease(info *cloudtypes.PodInfo) error {
+	return h.do(func(bool) error {
+		err := h.cloud.ReleasePodInstance(info)
+		if err != nil {
+			h.log.Error(err)
+
+			// the pod keeps occupying the resource until it expires,
+			// and then it will be terminated by the watcher.
+			if cloud.IsErrorUnsupported(err) {
+				return nil
+			}
+		}
+
+		return err
+	})
+}
+
+func (h *handler) HandleEventPodExtend(info *cloudtypes.PodInfo) error {
+	return h.do(func(bool) error {
+		err := h.cloud.ExtendPodInstance(info)
+		if err != nil {
+			h.log.Error(err)
+
+			// the pod is not extended and it is not charged either.
+			if cloud.IsErrorUnsupported(err) {
+				return nil
+			}
+		}
+
+		return err
+	})
+}
+
 // bigmodel
 func (h *handler) HandleEventBigModelWuKongInferenceStart(msg *bigmodelmessage.MsgTask) error {
 	user, err := domain.NewAccount(msg.User)
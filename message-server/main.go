@@ -18,6 +18,7 @@ import (
 	cloudapp "github.com/opensourceways/xihe-server/cloud/app"
 	"github.com/opensourceways/xihe-server/cloud/infrastructure/cloudimpl"
 	cloudrepo "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
 	"github.com/opensourceways/xihe-server/common/infrastructure/pgsql"
 	"github.com/opensourceways/xihe-server/config"
 	"github.com/opensourceways/xihe-server/infrastructure/evaluateimpl"
@@ -112,13 +113,17 @@ func newHandler(cfg *configuration, log *logrus.Entry) *handler {
 	collections := &cfg.Mongodb.Collections
 
 	userRepo := userrepo.NewUserRepo(mongodb.NewCollection(collections.User))
+	trainingRepo := repositories.NewTrainingRepository(
+		mongodb.NewTrainingMapper(collections.Training),
+	)
+	sender := messages.NewMessageSender()
 
 	h := &handler{
 		log:              log,
 		maxRetry:         cfg.MaxRetry,
 		trainingEndpoint: cfg.TrainingEndpoint,
 
-		user: userapp.NewUserService(userRepo, nil, nil, nil),
+		user: userapp.NewUserService(userRepo, nil, nil, nil, nil),
 
 		project: app.NewProjectMessageService(
 			repositories.NewProjectRepository(
@@ -140,11 +145,22 @@ func newHandler(cfg *configuration, log *logrus.Entry) *handler {
 
 		training: app.NewTrainingService(
 			log,
-			trainingimpl.NewTraining(&trainingimpl.Config{}),
-			repositories.NewTrainingRepository(
-				mongodb.NewTrainingMapper(collections.Training),
+			trainingimpl.NewTraining(&cfg.Training),
+			trainingRepo,
+			repositories.NewTrainingMetricRepository(
+				mongodb.NewTrainingMetricMapper(collections.TrainingMetric),
+			),
+			sender, 0,
+		),
+
+		sweep: app.NewSweepMessageService(
+			log,
+			trainingimpl.NewTraining(&cfg.Training),
+			trainingRepo,
+			repositories.NewSweepRepository(
+				mongodb.NewSweepMapper(collections.Sweep),
 			),
-			nil, 0,
+			sender,
 		),
 
 		inference: app.NewInferenceMessageService(
@@ -164,8 +180,12 @@ func newHandler(cfg *configuration, log *logrus.Entry) *handler {
 		),
 
 		cloud: cloudapp.NewCloudMessageService(
+			cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf)),
 			cloudrepo.NewPodRepo(&cfg.Postgresql.cloudconf),
+			cloudrepo.NewCreditRepo(&cfg.Postgresql.cloudconf),
 			cloudimpl.NewCloud(&cfg.Cloud.Config),
+			workspaceimpl.NewWorkspace(&cfg.Cloud.Workspace),
+			sender,
 			int64(cfg.Cloud.SurvivalTime),
 		),
 
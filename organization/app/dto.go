@@ -0,0 +1,113 @@
+package app
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/organization/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+type OrgCreateCmd struct {
+	Name    types.Account
+	Desc    domain.OrgDesc
+	Creator types.Account
+}
+
+func (cmd *OrgCreateCmd) Validate() error {
+	b := cmd.Name != nil &&
+		cmd.Desc != nil &&
+		cmd.Creator != nil
+
+	if !b {
+		return errors.New("invalid cmd of creating organization")
+	}
+
+	return nil
+}
+
+func (cmd *OrgCreateCmd) toOrganization(o *domain.Organization) {
+	now := utils.Now()
+
+	*o = domain.Organization{
+		Name:  cmd.Name,
+		Desc:  cmd.Desc,
+		Owner: cmd.Creator,
+		Members: []domain.Member{
+			{
+				Account:  cmd.Creator,
+				Role:     domain.NewOrgRoleOwner(),
+				JoinedAt: now,
+			},
+		},
+		CreatedAt: now,
+	}
+}
+
+type OrgMemberCmd struct {
+	Org      types.Account
+	Operator types.Account
+	Member   types.Account
+	Role     domain.OrgRole
+}
+
+func (cmd *OrgMemberCmd) Validate() error {
+	b := cmd.Org != nil &&
+		cmd.Operator != nil &&
+		cmd.Member != nil &&
+		cmd.Role != nil
+
+	if !b {
+		return errors.New("invalid cmd of org member")
+	}
+
+	return nil
+}
+
+type OrgRemoveMemberCmd struct {
+	Org      types.Account
+	Operator types.Account
+	Member   types.Account
+}
+
+type OrgMemberDTO struct {
+	Account  string `json:"account"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+type OrgDTO struct {
+	Id        string         `json:"id"`
+	Name      string         `json:"name"`
+	Desc      string         `json:"desc"`
+	Owner     string         `json:"owner"`
+	Members   []OrgMemberDTO `json:"members"`
+	CreatedAt string         `json:"created_at"`
+
+	NamespaceId string `json:"-"`
+}
+
+func toOrgDTO(o *domain.Organization, dto *OrgDTO) {
+	*dto = OrgDTO{
+		Id:          o.Id,
+		Name:        o.Name.Account(),
+		Owner:       o.Owner.Account(),
+		CreatedAt:   utils.ToDate(o.CreatedAt),
+		NamespaceId: o.PlatformGroup.NamespaceId,
+	}
+
+	if o.Desc != nil {
+		dto.Desc = o.Desc.OrgDesc()
+	}
+
+	dto.Members = make([]OrgMemberDTO, len(o.Members))
+	for i := range o.Members {
+		m := &o.Members[i]
+
+		dto.Members[i] = OrgMemberDTO{
+			Account:  m.Account.Account(),
+			Role:     m.Role.OrgRole(),
+			JoinedAt: utils.ToDate(m.JoinedAt),
+		}
+	}
+}
@@ -0,0 +1,11 @@
+package app
+
+const (
+	errorNameUnavailable = "org_name_unavailable"
+	errorNoPermission    = "org_no_permission"
+	errorMemberExists    = "org_member_exists"
+	errorMemberNotExists = "org_member_not_exists"
+	errorLastOwner       = "org_last_owner"
+	errorExceedMaxMember = "org_exceed_max_member"
+	errorNoPlatformUser  = "org_no_platform_user"
+)
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/opensourceways/xihe-server/domain/platform"
+	"github.com/opensourceways/xihe-server/organization/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+func (s *orgService) getForManaging(cmd *OrgRemoveMemberCmd) (
+	o domain.Organization, code string, err error,
+) {
+	if o, err = s.repo.Get(cmd.Org); err != nil {
+		return
+	}
+
+	if !o.CanManage(cmd.Operator) {
+		code = errorNoPermission
+		err = errors.New("only the owner can manage members")
+	}
+
+	return
+}
+
+func (s *orgService) platformUserId(cmd *OrgRemoveMemberCmd) (
+	id string, code string, err error,
+) {
+	u, err := s.user.GetByAccount(cmd.Member)
+	if err != nil {
+		return
+	}
+
+	if id = u.PlatformUser.Id; id == "" {
+		code = errorNoPlatformUser
+		err = errors.New("member has no platform account")
+	}
+
+	return
+}
+
+func (s *orgService) AddMember(cmd *OrgMemberCmd) (code string, err error) {
+	c := OrgRemoveMemberCmd{cmd.Org, cmd.Operator, cmd.Member}
+
+	o, code, err := s.getForManaging(&c)
+	if err != nil {
+		return
+	}
+
+	uid, code, err := s.platformUserId(&c)
+	if err != nil {
+		return
+	}
+
+	m := domain.Member{
+		Account:  cmd.Member,
+		Role:     cmd.Role,
+		JoinedAt: utils.Now(),
+	}
+
+	if err = o.AddMember(&m); err != nil {
+		if o.IsMember(cmd.Member) {
+			code = errorMemberExists
+		} else {
+			code = errorExceedMaxMember
+		}
+
+		return
+	}
+
+	err = s.group.AddMember(&platform.GroupMemberOption{
+		GroupId: o.PlatformGroup.Id,
+		UserId:  uid,
+		Role:    toGroupRole(cmd.Role),
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = s.repo.Save(&o)
+
+	return
+}
+
+func (s *orgService) UpdateMember(cmd *OrgMemberCmd) (code string, err error) {
+	c := OrgRemoveMemberCmd{cmd.Org, cmd.Operator, cmd.Member}
+
+	o, code, err := s.getForManaging(&c)
+	if err != nil {
+		return
+	}
+
+	if !o.IsMember(cmd.Member) {
+		code = errorMemberNotExists
+		err = errors.New("not a member")
+
+		return
+	}
+
+	if err = o.ChangeRole(cmd.Member, cmd.Role); err != nil {
+		code = errorLastOwner
+
+		return
+	}
+
+	uid, code, err := s.platformUserId(&c)
+	if err != nil {
+		return
+	}
+
+	err = s.group.UpdateMember(&platform.GroupMemberOption{
+		GroupId: o.PlatformGroup.Id,
+		UserId:  uid,
+		Role:    toGroupRole(cmd.Role),
+	})
+	if err != nil {
+		return
+	}
+
+	_, err = s.repo.Save(&o)
+
+	return
+}
+
+func (s *orgService) RemoveMember(cmd *OrgRemoveMemberCmd) (code string, err error) {
+	o, err := s.repo.Get(cmd.Org)
+	if err != nil {
+		return
+	}
+
+	// a member can leave the org on their own
+	if cmd.Operator.Account() != cmd.Member.Account() && !o.CanManage(cmd.Operator) {
+		code = errorNoPermission
+		err = errors.New("only the owner can manage members")
+
+		return
+	}
+
+	if !o.IsMember(cmd.Member) {
+		code = errorMemberNotExists
+		err = errors.New("not a member")
+
+		return
+	}
+
+	if err = o.RemoveMember(cmd.Member); err != nil {
+		code = errorLastOwner
+
+		return
+	}
+
+	uid, code, err := s.platformUserId(cmd)
+	if err != nil {
+		return
+	}
+
+	if err = s.group.RemoveMember(o.PlatformGroup.Id, uid); err != nil {
+		return
+	}
+
+	_, err = s.repo.Save(&o)
+
+	return
+}
+
+func toGroupRole(r domain.OrgRole) string {
+	if r.IsOwner() {
+		return platform.GroupRoleOwner
+	}
+
+	if r.CanWrite() {
+		return platform.GroupRoleWriter
+	}
+
+	return platform.GroupRoleReader
+}
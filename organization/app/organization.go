@@ -0,0 +1,202 @@
+package app
+
+import (
+	"errors"
+
+	"github.com/sirupsen/logrus"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/domain/platform"
+	typerepo "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/organization/domain"
+	"github.com/opensourceways/xihe-server/organization/domain/repository"
+	userrepo "github.com/opensourceways/xihe-server/user/domain/repository"
+)
+
+type OrgService interface {
+	Create(*OrgCreateCmd) (OrgDTO, string, error)
+	Get(types.Account) (OrgDTO, error)
+	ListByMember(types.Account) ([]OrgDTO, error)
+
+	AddMember(*OrgMemberCmd) (string, error)
+	UpdateMember(*OrgMemberCmd) (string, error)
+	RemoveMember(*OrgRemoveMemberCmd) (string, error)
+
+	// CanRead and CanWrite return false if the org is not exist,
+	// because the owner of resource may be a user.
+	CanRead(org, user types.Account) (bool, error)
+	CanWrite(org, user types.Account) (bool, error)
+	CanManage(org, user types.Account) (bool, error)
+}
+
+var _ OrgService = (*orgService)(nil)
+
+func NewOrgService(
+	repo repository.Organization,
+	user userrepo.User,
+	group platform.Group,
+) *orgService {
+	return &orgService{
+		repo:  repo,
+		user:  user,
+		group: group,
+	}
+}
+
+type orgService struct {
+	repo  repository.Organization
+	user  userrepo.User
+	group platform.Group
+}
+
+func (s *orgService) Create(cmd *OrgCreateCmd) (dto OrgDTO, code string, err error) {
+	// the name of org can't be same as any user
+	if _, err = s.user.GetByAccount(cmd.Name); err == nil {
+		code = errorNameUnavailable
+		err = errors.New("name is used by a user")
+
+		return
+	}
+
+	if !typerepo.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	// check the name before creating the group to avoid leaving an orphan
+	if _, err = s.repo.Get(cmd.Name); err == nil {
+		code = errorNameUnavailable
+		err = errors.New("name is used by an organization")
+
+		return
+	}
+
+	if !typerepo.IsErrorResourceNotExists(err) {
+		return
+	}
+
+	creator, err := s.user.GetByAccount(cmd.Creator)
+	if err != nil {
+		return
+	}
+
+	if creator.PlatformUser.Id == "" {
+		code = errorNoPlatformUser
+		err = errors.New("creator has no platform account")
+
+		return
+	}
+
+	o := new(domain.Organization)
+	cmd.toOrganization(o)
+
+	opt := platform.GroupOption{Name: cmd.Name}
+	if cmd.Desc != nil {
+		opt.Desc = cmd.Desc.OrgDesc()
+	}
+
+	g, err := s.group.New(&opt)
+	if err != nil {
+		return
+	}
+
+	o.PlatformGroup = domain.PlatformGroup{
+		Id:          g.Id,
+		NamespaceId: g.NamespaceId,
+	}
+
+	v, err := s.save(o, creator.PlatformUser.Id)
+	if err != nil {
+		if typerepo.IsErrorDuplicateCreating(err) {
+			code = errorNameUnavailable
+		}
+
+		if err1 := s.group.Delete(g.Id); err1 != nil {
+			logrus.Errorf("delete the group(%s) of org failed, err:%s", g.Id, err1.Error())
+		}
+
+		return
+	}
+
+	toOrgDTO(&v, &dto)
+
+	return
+}
+
+// save adds the creator to the group and saves the org.
+func (s *orgService) save(o *domain.Organization, creator string) (domain.Organization, error) {
+	err := s.group.AddMember(&platform.GroupMemberOption{
+		GroupId: o.PlatformGroup.Id,
+		UserId:  creator,
+		Role:    platform.GroupRoleOwner,
+	})
+	if err != nil {
+		return domain.Organization{}, err
+	}
+
+	return s.repo.Save(o)
+}
+
+func (s *orgService) Get(name types.Account) (dto OrgDTO, err error) {
+	v, err := s.repo.Get(name)
+	if err != nil {
+		return
+	}
+
+	toOrgDTO(&v, &dto)
+
+	return
+}
+
+func (s *orgService) ListByMember(user types.Account) (dtos []OrgDTO, err error) {
+	v, err := s.repo.ListByMember(user)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]OrgDTO, len(v))
+	for i := range v {
+		toOrgDTO(&v[i], &dtos[i])
+	}
+
+	return
+}
+
+func (s *orgService) CanRead(org, user types.Account) (bool, error) {
+	o, err := s.get(org)
+	if err != nil || o == nil {
+		return false, err
+	}
+
+	return o.CanRead(user), nil
+}
+
+func (s *orgService) CanWrite(org, user types.Account) (bool, error) {
+	o, err := s.get(org)
+	if err != nil || o == nil {
+		return false, err
+	}
+
+	return o.CanWrite(user), nil
+}
+
+func (s *orgService) CanManage(org, user types.Account) (bool, error) {
+	o, err := s.get(org)
+	if err != nil || o == nil {
+		return false, err
+	}
+
+	return o.CanManage(user), nil
+}
+
+func (s *orgService) get(org types.Account) (*domain.Organization, error) {
+	o, err := s.repo.Get(org)
+	if err != nil {
+		if typerepo.IsErrorResourceNotExists(err) {
+			err = nil
+		}
+
+		return nil, err
+	}
+
+	return &o, nil
+}
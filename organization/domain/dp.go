@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	orgRoleOwner      = "owner"
+	orgRoleMaintainer = "maintainer"
+	orgRoleViewer     = "viewer"
+)
+
+// OrgRole
+type OrgRole interface {
+	OrgRole() string
+	IsOwner() bool
+	CanWrite() bool
+}
+
+func NewOrgRole(v string) (OrgRole, error) {
+	switch v {
+	case orgRoleOwner, orgRoleMaintainer, orgRoleViewer:
+	default:
+		return nil, errors.New("invalid org role")
+	}
+
+	return orgRole(v), nil
+}
+
+func NewOrgRoleOwner() OrgRole {
+	return orgRole(orgRoleOwner)
+}
+
+type orgRole string
+
+func (r orgRole) OrgRole() string {
+	return string(r)
+}
+
+func (r orgRole) IsOwner() bool {
+	return string(r) == orgRoleOwner
+}
+
+func (r orgRole) CanWrite() bool {
+	return string(r) == orgRoleOwner || string(r) == orgRoleMaintainer
+}
+
+// OrgDesc
+type OrgDesc interface {
+	OrgDesc() string
+}
+
+func NewOrgDesc(v string) (OrgDesc, error) {
+	max := types.DomainConfig.MaxDescLength
+	if v != "" && utils.StrLen(v) > max {
+		return nil, errors.New("invalid org desc")
+	}
+
+	return orgDesc(v), nil
+}
+
+type orgDesc string
+
+func (r orgDesc) OrgDesc() string {
+	return string(r)
+}
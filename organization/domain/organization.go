@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"errors"
+
+	types "github.com/opensourceways/xihe-server/domain"
+)
+
+// Organization shares the namespace of user accounts, so that
+// it can own projects, models and datasets just like a user.
+type Organization struct {
+	Id    string
+	Name  types.Account
+	Desc  OrgDesc
+	Owner types.Account
+
+	Members       []Member
+	PlatformGroup PlatformGroup
+
+	CreatedAt int64
+
+	Version int
+}
+
+type PlatformGroup struct {
+	Id          string
+	NamespaceId string
+}
+
+type Member struct {
+	Account  types.Account
+	Role     OrgRole
+	JoinedAt int64
+}
+
+func (o *Organization) member(u types.Account) int {
+	for i := range o.Members {
+		if o.Members[i].Account.Account() == u.Account() {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (o *Organization) countOwner() int {
+	n := 0
+	for i := range o.Members {
+		if o.Members[i].Role.IsOwner() {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (o *Organization) IsMember(u types.Account) bool {
+	return o.member(u) >= 0
+}
+
+func (o *Organization) RoleOf(u types.Account) (OrgRole, bool) {
+	if i := o.member(u); i >= 0 {
+		return o.Members[i].Role, true
+	}
+
+	return nil, false
+}
+
+func (o *Organization) CanRead(u types.Account) bool {
+	return o.IsMember(u)
+}
+
+func (o *Organization) CanWrite(u types.Account) bool {
+	r, ok := o.RoleOf(u)
+
+	return ok && r.CanWrite()
+}
+
+func (o *Organization) CanManage(u types.Account) bool {
+	r, ok := o.RoleOf(u)
+
+	return ok && r.IsOwner()
+}
+
+func (o *Organization) AddMember(m *Member) error {
+	if o.IsMember(m.Account) {
+		return errors.New("already a member")
+	}
+
+	if len(o.Members) >= types.DomainConfig.MaxOrgMemberNum {
+		return errors.New("exceed max member num")
+	}
+
+	o.Members = append(o.Members, *m)
+
+	return nil
+}
+
+func (o *Organization) RemoveMember(u types.Account) error {
+	i := o.member(u)
+	if i < 0 {
+		return errors.New("not a member")
+	}
+
+	if o.Members[i].Role.IsOwner() && o.countOwner() == 1 {
+		return errors.New("can't remove the last owner")
+	}
+
+	o.Members = append(o.Members[:i], o.Members[i+1:]...)
+
+	return nil
+}
+
+func (o *Organization) ChangeRole(u types.Account, role OrgRole) error {
+	i := o.member(u)
+	if i < 0 {
+		return errors.New("not a member")
+	}
+
+	m := &o.Members[i]
+	if m.Role.IsOwner() && !role.IsOwner() && o.countOwner() == 1 {
+		return errors.New("can't demote the last owner")
+	}
+
+	m.Role = role
+
+	return nil
+}
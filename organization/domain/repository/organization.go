@@ -0,0 +1,12 @@
+package repository
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/organization/domain"
+)
+
+type Organization interface {
+	Save(*domain.Organization) (domain.Organization, error)
+	Get(types.Account) (domain.Organization, error)
+	ListByMember(types.Account) ([]domain.Organization, error)
+}
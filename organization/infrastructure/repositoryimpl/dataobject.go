@@ -0,0 +1,75 @@
+package repositoryimpl
+
+import (
+	types "github.com/opensourceways/xihe-server/domain"
+	"github.com/opensourceways/xihe-server/organization/domain"
+)
+
+func toOrganizationDoc(o *domain.Organization, doc *DOrganization) {
+	members := make([]dMember, len(o.Members))
+	for i := range o.Members {
+		m := &o.Members[i]
+
+		members[i] = dMember{
+			Account:  m.Account.Account(),
+			Role:     m.Role.OrgRole(),
+			JoinedAt: m.JoinedAt,
+		}
+	}
+
+	*doc = DOrganization{
+		Name:                o.Name.Account(),
+		Owner:               o.Owner.Account(),
+		Members:             members,
+		PlatformGroupId:     o.PlatformGroup.Id,
+		PlatformNamespaceId: o.PlatformGroup.NamespaceId,
+		CreatedAt:           o.CreatedAt,
+	}
+
+	if o.Desc != nil {
+		doc.Desc = o.Desc.OrgDesc()
+	}
+}
+
+func (doc *DOrganization) toOrganization(o *domain.Organization) (err error) {
+	if o.Name, err = types.NewAccount(doc.Name); err != nil {
+		return
+	}
+
+	if o.Owner, err = types.NewAccount(doc.Owner); err != nil {
+		return
+	}
+
+	if o.Desc, err = domain.NewOrgDesc(doc.Desc); err != nil {
+		return
+	}
+
+	o.Members = make([]domain.Member, len(doc.Members))
+	for i := range doc.Members {
+		if err = doc.Members[i].toMember(&o.Members[i]); err != nil {
+			return
+		}
+	}
+
+	o.Id = doc.Id.Hex()
+	o.PlatformGroup.Id = doc.PlatformGroupId
+	o.PlatformGroup.NamespaceId = doc.PlatformNamespaceId
+	o.CreatedAt = doc.CreatedAt
+	o.Version = doc.Version
+
+	return
+}
+
+func (doc *dMember) toMember(m *domain.Member) (err error) {
+	if m.Account, err = types.NewAccount(doc.Account); err != nil {
+		return
+	}
+
+	if m.Role, err = domain.NewOrgRole(doc.Role); err != nil {
+		return
+	}
+
+	m.JoinedAt = doc.JoinedAt
+
+	return
+}
@@ -0,0 +1,32 @@
+package repositoryimpl
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+const (
+	fieldName    = "name"
+	fieldMembers = "members"
+	fieldAccount = "account"
+	fieldVersion = "version"
+)
+
+type DOrganization struct {
+	Id primitive.ObjectID `bson:"_id"        json:"-"`
+
+	Name                string    `bson:"name"       json:"name"`
+	Desc                string    `bson:"desc"       json:"desc"`
+	Owner               string    `bson:"owner"      json:"owner"`
+	Members             []dMember `bson:"members"    json:"members"`
+	PlatformGroupId     string    `bson:"gid"        json:"gid"`
+	PlatformNamespaceId string    `bson:"nid"        json:"nid"`
+	CreatedAt           int64     `bson:"created_at" json:"created_at"`
+
+	// Version will be increased by 1 automatically.
+	// So, don't marshal it to avoid setting it occasionally.
+	Version int `bson:"version"    json:"-"`
+}
+
+type dMember struct {
+	Account  string `bson:"account"   json:"account"`
+	Role     string `bson:"role"      json:"role"`
+	JoinedAt int64  `bson:"joined_at" json:"joined_at"`
+}
@@ -0,0 +1,142 @@
+package repositoryimpl
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	types "github.com/opensourceways/xihe-server/domain"
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/organization/domain"
+	"github.com/opensourceways/xihe-server/organization/domain/repository"
+)
+
+func NewOrganizationRepo(m mongodbClient) repository.Organization {
+	return &orgRepoImpl{m}
+}
+
+type orgRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *orgRepoImpl) docFilter(name string) bson.M {
+	return bson.M{
+		fieldName: name,
+	}
+}
+
+func (impl *orgRepoImpl) Save(o *domain.Organization) (r domain.Organization, err error) {
+	if o.Id != "" {
+		if err = impl.update(o); err == nil {
+			r = *o
+			r.Version += 1
+		}
+
+		return
+	}
+
+	v, err := impl.insert(o)
+	if err == nil {
+		r = *o
+		r.Id = v
+	}
+
+	return
+}
+
+func (impl *orgRepoImpl) insert(o *domain.Organization) (id string, err error) {
+	var obj DOrganization
+	toOrganizationDoc(o, &obj)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return
+	}
+	doc[fieldVersion] = 0
+
+	f := func(ctx context.Context) error {
+		v, err := impl.cli.NewDocIfNotExist(
+			ctx, impl.docFilter(o.Name.Account()), doc,
+		)
+
+		id = v
+
+		return err
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocExists(err) {
+		err = repoerr.NewErrorDuplicateCreating(err)
+	}
+
+	return
+}
+
+func (impl *orgRepoImpl) update(o *domain.Organization) (err error) {
+	var obj DOrganization
+	toOrganizationDoc(o, &obj)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return
+	}
+
+	filter, err := impl.cli.ObjectIdFilter(o.Id)
+	if err != nil {
+		return
+	}
+
+	f := func(ctx context.Context) error {
+		return impl.cli.UpdateDoc(ctx, filter, doc, mongoCmdSet, o.Version)
+	}
+
+	if err = withContext(f); err != nil && impl.cli.IsDocNotExists(err) {
+		err = repoerr.NewErrorConcurrentUpdating(err)
+	}
+
+	return
+}
+
+func (impl *orgRepoImpl) Get(name types.Account) (r domain.Organization, err error) {
+	var v DOrganization
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, impl.docFilter(name.Account()), nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	err = v.toOrganization(&r)
+
+	return
+}
+
+func (impl *orgRepoImpl) ListByMember(user types.Account) (r []domain.Organization, err error) {
+	var v []DOrganization
+
+	f := func(ctx context.Context) error {
+		filter := bson.M{
+			fieldMembers + "." + fieldAccount: user.Account(),
+		}
+
+		return impl.cli.GetDocs(ctx, filter, nil, &v)
+	}
+
+	if err = withContext(f); err != nil || len(v) == 0 {
+		return
+	}
+
+	r = make([]domain.Organization, len(v))
+	for i := range v {
+		if err = v[i].toOrganization(&r[i]); err != nil {
+			return
+		}
+	}
+
+	return
+}
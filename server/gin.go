@@ -20,6 +20,9 @@ import (
 	bigmodelrepo "github.com/opensourceways/xihe-server/bigmodel/infrastructure/repositoryimpl"
 	cloudapp "github.com/opensourceways/xihe-server/cloud/app"
 	cloudrepo "github.com/opensourceways/xihe-server/cloud/infrastructure/repositoryimpl"
+	"github.com/opensourceways/xihe-server/cloud/infrastructure/workspaceimpl"
+	collaboratorapp "github.com/opensourceways/xihe-server/collaborator/app"
+	collaboratorrepo "github.com/opensourceways/xihe-server/collaborator/infrastructure/repositoryimpl"
 	competitionapp "github.com/opensourceways/xihe-server/competition/app"
 	competitionrepo "github.com/opensourceways/xihe-server/competition/infrastructure/repositoryimpl"
 	"github.com/opensourceways/xihe-server/config"
@@ -34,10 +37,13 @@ import (
 	"github.com/opensourceways/xihe-server/infrastructure/competitionimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/finetuneimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/gitlab"
+	"github.com/opensourceways/xihe-server/infrastructure/inferenceimpl"
 	"github.com/opensourceways/xihe-server/infrastructure/messages"
 	"github.com/opensourceways/xihe-server/infrastructure/mongodb"
 	"github.com/opensourceways/xihe-server/infrastructure/repositories"
 	"github.com/opensourceways/xihe-server/infrastructure/trainingimpl"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	orgrepo "github.com/opensourceways/xihe-server/organization/infrastructure/repositoryimpl"
 	userapp "github.com/opensourceways/xihe-server/user/app"
 	userrepoimpl "github.com/opensourceways/xihe-server/user/infrastructure/repositoryimpl"
 )
@@ -114,6 +120,30 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		),
 	)
 
+	sweep := repositories.NewSweepRepository(
+		mongodb.NewSweepMapper(
+			collections.Sweep,
+		),
+	)
+
+	trainingMetric := repositories.NewTrainingMetricRepository(
+		mongodb.NewTrainingMetricMapper(
+			collections.TrainingMetric,
+		),
+	)
+
+	modelLineage := repositories.NewModelLineageRepository(
+		mongodb.NewModelLineageMapper(
+			collections.ModelLineage,
+		),
+	)
+
+	trainingSchedule := repositories.NewTrainingScheduleRepository(
+		mongodb.NewTrainingScheduleMapper(
+			collections.TrainingSchedule,
+		),
+	)
+
 	finetune := repositories.NewFinetuneRepository(
 		mongodb.NewFinetuneMapper(
 			collections.Finetune,
@@ -126,6 +156,26 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		),
 	)
 
+	inferenceDeployment := repositories.NewInferenceDeploymentRepository(
+		mongodb.NewInferenceDeploymentMapper(
+			collections.InferenceDeploy,
+		),
+	)
+
+	evaluate := repositories.NewEvaluateRepository(
+		mongodb.NewEvaluateMapper(collections.Evaluate),
+	)
+
+	evaluateReport := repositories.NewEvaluateReportRepository(
+		mongodb.NewEvaluateReportMapper(collections.EvaluateReport),
+	)
+
+	inferenceUsage := repositories.NewInferenceUsageRepository(
+		mongodb.NewInferenceUsageMapper(
+			collections.InferenceUsage,
+		),
+	)
+
 	tags := repositories.NewTagsRepository(
 		mongodb.NewTagsMapper(collections.Tag),
 	)
@@ -164,9 +214,17 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
 		competitionrepo.NewWorkRepo(mongodb.NewCollection(collections.CompetitionWork)),
 		competitionrepo.NewPlayerRepo(mongodb.NewCollection(collections.CompetitionPlayer)),
+		competitionrepo.NewInvitationRepo(mongodb.NewCollection(collections.CompetitionInvite)),
 		sender, uploader,
 	)
 
+	competitionAdminService := competitionapp.NewCompetitionAdminService(
+		competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
+		competitionrepo.NewPlayerRepo(mongodb.NewCollection(collections.CompetitionPlayer)),
+		competitionrepo.NewAuditLogRepo(mongodb.NewCollection(collections.CompetitionAudit)),
+		uploader,
+	)
+
 	courseAppService := courseapp.NewCourseService(
 		usercli.NewUserCli(userRegService),
 		proj,
@@ -176,10 +234,14 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		courserepo.NewRecordRepo(mongodb.NewCollection(collections.CourseRecord)),
 	)
 
+	podRepo := cloudrepo.NewPodRepo(&cfg.Postgresql.Cloud)
+	creditRepo := cloudrepo.NewCreditRepo(&cfg.Postgresql.Cloud)
+	waitlistRepo := cloudrepo.NewWaitlistRepo(&cfg.Postgresql.Cloud)
+	cloudRepo := cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf))
+	workspace := workspaceimpl.NewWorkspace(&cfg.Workspace)
+
 	cloudAppService := cloudapp.NewCloudService(
-		cloudrepo.NewCloudRepo(mongodb.NewCollection(collections.CloudConf)),
-		cloudrepo.NewPodRepo(&cfg.Postgresql.Cloud),
-		sender,
+		cloudRepo, podRepo, creditRepo, waitlistRepo, workspace, sender,
 	)
 
 	bigmodelAppService := bigmodelapp.NewBigModelService(
@@ -191,36 +253,64 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		sender,
 	)
 
-	projectService := app.NewProjectService(user, proj, model, dataset, activity, nil, sender)
+	orgService := orgapp.NewOrgService(
+		orgrepo.NewOrganizationRepo(mongodb.NewCollection(collections.Organization)),
+		user, gitlab.NewGroupService(),
+	)
+
+	collaboratorService := collaboratorapp.NewCollaboratorService(
+		collaboratorrepo.NewResourceCollaboratorsRepo(
+			mongodb.NewCollection(collections.Collaborator),
+		),
+		user, gitlab.NewRepoMemberService(),
+	)
+
+	projectFork := repositories.NewProjectForkRepository(
+		mongodb.NewProjectForkMapper(
+			collections.ProjectFork,
+		),
+	)
+
+	projectService := app.NewProjectService(
+		user, proj, model, dataset, activity, projectFork, nil, sender,
+	)
 
 	modelService := app.NewModelService(user, model, proj, dataset, activity, nil, sender)
 
 	datasetService := app.NewDatasetService(user, dataset, proj, model, activity, nil, sender)
 
+	accessToken := userrepoimpl.NewAccessTokenRepo(
+		mongodb.NewCollection(collections.AccessToken),
+	)
+
+	accessTokenChecker := controller.NewAccessTokenChecker(
+		accessToken, user, gitlabUser, sender, orgService,
+	)
+
 	v1 := engine.Group(docs.SwaggerInfo.BasePath)
 	{
 		controller.AddRouterForProjectController(
-			v1, user, proj, model, dataset, activity, tags, like, sender,
-			newPlatformRepository,
+			v1, user, proj, model, dataset, activity, projectFork, tags, like, sender,
+			newPlatformRepository, orgService, collaboratorService,
 		)
 
 		controller.AddRouterForModelController(
 			v1, user, model, proj, dataset, activity, tags, like, sender,
-			newPlatformRepository,
+			newPlatformRepository, orgService, collaboratorService,
 		)
 
 		controller.AddRouterForDatasetController(
 			v1, user, dataset, model, proj, activity, tags, like, sender,
-			newPlatformRepository,
+			newPlatformRepository, orgService, collaboratorService,
 		)
 
 		controller.AddRouterForUserController(
 			v1, user, gitlabUser,
-			authingUser, loginService, sender,
+			authingUser, loginService, sender, accessToken, orgService,
 		)
 
 		controller.AddRouterForLoginController(
-			v1, user, gitlabUser, authingUser, login, sender,
+			v1, user, gitlabUser, authingUser, login, sender, orgService,
 		)
 
 		controller.AddRouterForLikeController(
@@ -240,27 +330,52 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 		)
 
 		controller.AddRouterForTrainingController(
-			v1, trainingAdapter, training, model, proj, dataset, sender,
+			v1, trainingAdapter, training, sweep, trainingMetric, modelLineage,
+			user, model, proj, dataset, activity, gitlabRepo, sender,
+			newPlatformRepository, orgService, collaboratorService, trainingSchedule,
+			evaluate, evaluateReport, accessTokenChecker,
 		)
 
 		controller.AddRouterForFinetuneController(
-			v1, finetuneImpl, finetune, sender,
+			v1, finetuneImpl, finetune, sender, gitlabRepo,
+			user, model, proj, dataset, activity, newPlatformRepository,
 		)
 
 		controller.AddRouterForRepoFileController(
 			v1, gitlabRepo, model, proj, dataset, sender, user, gitlabUser,
+			orgService, collaboratorService, accessTokenChecker,
+		)
+
+		controller.AddRouterForCollaboratorController(
+			v1, model, proj, dataset, orgService, collaboratorService,
+		)
+
+		controller.AddRouterForOrganizationController(
+			v1, orgService,
 		)
 
 		controller.AddRouterForInferenceController(
-			v1, gitlabRepo, inference, proj, sender,
+			v1, gitlabRepo, inference, inferenceDeployment, inferenceUsage, proj,
+			inferenceimpl.NewPredictor(&cfg.Predict), sender,
+			orgService, collaboratorService, accessTokenChecker,
 		)
 
 		controller.AddRouterForSearchController(
 			v1, user, proj, model, dataset,
 		)
 
+		controller.AddRouterForLineageController(
+			v1, proj, model, dataset, training, modelLineage, projectFork,
+			competitionrepo.NewCompetitionRepo(mongodb.NewCollection(collections.Competition)),
+			competitionrepo.NewWorkRepo(mongodb.NewCollection(collections.CompetitionWork)),
+			courserepo.NewCourseRepo(mongodb.NewCollection(collections.Course)),
+			courserepo.NewPlayerRepo(mongodb.NewCollection(collections.CoursePlayer)),
+			orgService, collaboratorService,
+		)
+
 		controller.AddRouterForCompetitionController(
-			v1, competitionAppService, proj,
+			v1, competitionAppService, competitionAdminService, proj,
+			accessTokenChecker,
 		)
 
 		controller.AddRouterForChallengeController(
@@ -277,6 +392,9 @@ func setRouter(engine *gin.Engine, cfg *config.Config) {
 
 		controller.AddRouterForCloudController(
 			v1, cloudAppService,
+			cloudapp.NewCreditService(creditRepo),
+			cloudapp.NewWorkspaceService(workspace, gitlabRepo),
+			proj,
 		)
 
 	}
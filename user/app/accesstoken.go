@@ -0,0 +1,156 @@
+package app
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	typerepo "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/user/domain"
+	"github.com/opensourceways/xihe-server/user/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
+)
+
+const (
+	accessTokenPrefix    = "xihe_"
+	maxAccessTokenNum    = 20
+	maxAccessTokenExpiry = 365
+)
+
+type AccessTokenService interface {
+	Create(*AccessTokenCreateCmd) (AccessTokenCreateDTO, string, error)
+	List(domain.Account) ([]AccessTokenDTO, error)
+	Delete(owner domain.Account, id string) error
+
+	// Check returns the owner of token if it has the scope.
+	Check(token string, scope domain.AccessTokenScope) (domain.Account, string, error)
+}
+
+func NewAccessTokenService(repo repository.AccessToken) AccessTokenService {
+	return accessTokenService{repo}
+}
+
+type accessTokenService struct {
+	repo repository.AccessToken
+}
+
+func (s accessTokenService) Create(cmd *AccessTokenCreateCmd) (
+	dto AccessTokenCreateDTO, code string, err error,
+) {
+	v, err := s.repo.List(cmd.Owner)
+	if err != nil {
+		return
+	}
+
+	if len(v) >= maxAccessTokenNum {
+		code = errorAccessTokenExceedLimit
+		err = errors.New("exceed max access token num")
+
+		return
+	}
+
+	for i := range v {
+		if v[i].Name.AccessTokenName() == cmd.Name.AccessTokenName() {
+			code = errorAccessTokenNameExists
+			err = errors.New("access token name exists")
+
+			return
+		}
+	}
+
+	token, err := genRandomHex(20)
+	if err != nil {
+		return
+	}
+	token = accessTokenPrefix + token
+
+	id, err := genRandomHex(8)
+	if err != nil {
+		return
+	}
+
+	now := utils.Now()
+	t := domain.AccessToken{
+		Id:        id,
+		Owner:     cmd.Owner,
+		Name:      cmd.Name,
+		Scopes:    cmd.Scopes,
+		Hash:      hashAccessToken(token),
+		Expiry:    now + int64(cmd.ExpiryDays)*24*3600,
+		CreatedAt: now,
+	}
+
+	if err = s.repo.Add(&t); err != nil {
+		return
+	}
+
+	dto.AccessTokenDTO = toAccessTokenDTO(&t)
+	dto.Token = token
+
+	return
+}
+
+func (s accessTokenService) List(owner domain.Account) (dtos []AccessTokenDTO, err error) {
+	v, err := s.repo.List(owner)
+	if err != nil || len(v) == 0 {
+		return
+	}
+
+	dtos = make([]AccessTokenDTO, len(v))
+	for i := range v {
+		dtos[i] = toAccessTokenDTO(&v[i])
+	}
+
+	return
+}
+
+func (s accessTokenService) Delete(owner domain.Account, id string) error {
+	return s.repo.Remove(owner, id)
+}
+
+func (s accessTokenService) Check(token string, scope domain.AccessTokenScope) (
+	owner domain.Account, code string, err error,
+) {
+	t, err := s.repo.FindByHash(hashAccessToken(token))
+	if err != nil {
+		if typerepo.IsErrorResourceNotExists(err) {
+			code = errorAccessTokenInvalid
+		}
+
+		return
+	}
+
+	if t.IsExpired() {
+		code = errorAccessTokenExpired
+		err = errors.New("access token is expired")
+
+		return
+	}
+
+	if !t.HasScope(scope) {
+		code = errorAccessTokenNoScope
+		err = errors.New("access token has no such scope")
+
+		return
+	}
+
+	owner = t.Owner
+
+	return
+}
+
+func hashAccessToken(token string) string {
+	v := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(v[:])
+}
+
+func genRandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/opensourceways/xihe-server/user/domain"
 	"github.com/opensourceways/xihe-server/user/domain/repository"
+	"github.com/opensourceways/xihe-server/utils"
 )
 
 // user
@@ -154,3 +155,56 @@ type RefreshTokenCmd struct {
 	Id          string
 	NamespaceId string
 }
+
+type AccessTokenCreateCmd struct {
+	Owner  domain.Account
+	Name   domain.AccessTokenName
+	Scopes []domain.AccessTokenScope
+
+	// ExpiryDays is the number of days the token is valid for.
+	ExpiryDays int
+}
+
+func (cmd *AccessTokenCreateCmd) Validate() error {
+	b := cmd.Owner != nil &&
+		cmd.Name != nil &&
+		len(cmd.Scopes) > 0 &&
+		cmd.ExpiryDays > 0 &&
+		cmd.ExpiryDays <= maxAccessTokenExpiry
+
+	if !b {
+		return errors.New("invalid cmd of creating access token")
+	}
+
+	return nil
+}
+
+type AccessTokenDTO struct {
+	Id        string   `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	Expiry    string   `json:"expiry"`
+	CreatedAt string   `json:"created_at"`
+}
+
+type AccessTokenCreateDTO struct {
+	AccessTokenDTO
+
+	// Token is returned only once when it is created.
+	Token string `json:"token"`
+}
+
+func toAccessTokenDTO(t *domain.AccessToken) AccessTokenDTO {
+	scopes := make([]string, len(t.Scopes))
+	for i := range t.Scopes {
+		scopes[i] = t.Scopes[i].AccessTokenScope()
+	}
+
+	return AccessTokenDTO{
+		Id:        t.Id,
+		Name:      t.Name.AccessTokenName(),
+		Scopes:    scopes,
+		Expiry:    utils.ToDate(t.Expiry),
+		CreatedAt: utils.ToDate(t.CreatedAt),
+	}
+}
@@ -5,6 +5,12 @@ import "github.com/opensourceways/xihe-server/infrastructure/authingimpl"
 const (
 	errorNoUserId      = "user_no_userid"
 	errorNoAccessToken = "user_no_accesstoken"
+
+	errorAccessTokenInvalid     = "user_access_token_invalid"
+	errorAccessTokenExpired     = "user_access_token_expired"
+	errorAccessTokenNoScope     = "user_access_token_no_scope"
+	errorAccessTokenNameExists  = "user_access_token_name_exists"
+	errorAccessTokenExceedLimit = "user_access_token_exceed_limit"
 )
 
 func isCodeUserDuplicateBind(code string) bool {
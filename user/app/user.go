@@ -2,11 +2,13 @@ package app
 
 import (
 	"encoding/hex"
+	"errors"
 
 	"github.com/opensourceways/xihe-server/domain/message"
 	platform "github.com/opensourceways/xihe-server/domain/platform"
 	typerepo "github.com/opensourceways/xihe-server/domain/repository"
 	"github.com/opensourceways/xihe-server/user/domain"
+	"github.com/opensourceways/xihe-server/user/domain/org"
 	"github.com/opensourceways/xihe-server/user/domain/repository"
 	"github.com/opensourceways/xihe-server/utils"
 )
@@ -40,9 +42,11 @@ func NewUserService(
 	ps platform.User,
 	sender message.Sender,
 	encryption utils.SymmetricEncryption,
+	org org.Organization,
 ) UserService {
 	return userService{
 		ps:         ps,
+		org:        org,
 		repo:       repo,
 		sender:     sender,
 		encryption: encryption,
@@ -51,6 +55,7 @@ func NewUserService(
 
 type userService struct {
 	ps         platform.User
+	org        org.Organization
 	repo       repository.User
 	sender     message.Sender
 	encryption utils.SymmetricEncryption
@@ -59,6 +64,20 @@ type userService struct {
 func (s userService) Create(cmd *UserCreateCmd) (dto UserDTO, err error) {
 	// TODO keep transaction
 
+	// the name of user can't be same as any organization
+	isOrg, err := s.org.IsOrg(cmd.Account)
+	if err != nil {
+		return
+	}
+
+	if isOrg {
+		err = typerepo.NewErrorDuplicateCreating(
+			errors.New("name is used by an organization"),
+		)
+
+		return
+	}
+
 	v := cmd.toUser()
 
 	// update user
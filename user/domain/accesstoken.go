@@ -0,0 +1,29 @@
+package domain
+
+import "github.com/opensourceways/xihe-server/utils"
+
+// AccessToken is the personal access token which is used by
+// scripts to access the api on behalf of the owner.
+type AccessToken struct {
+	Id        string
+	Owner     Account
+	Name      AccessTokenName
+	Scopes    []AccessTokenScope
+	Hash      string
+	Expiry    int64
+	CreatedAt int64
+}
+
+func (t *AccessToken) IsExpired() bool {
+	return t.Expiry > 0 && t.Expiry < utils.Now()
+}
+
+func (t *AccessToken) HasScope(scope AccessTokenScope) bool {
+	for _, v := range t.Scopes {
+		if v.AccessTokenScope() == scope.AccessTokenScope() {
+			return true
+		}
+	}
+
+	return false
+}
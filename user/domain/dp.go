@@ -271,3 +271,52 @@ type province string
 func (r province) Province() string {
 	return string(r)
 }
+
+// AccessTokenName
+type AccessTokenName interface {
+	AccessTokenName() string
+}
+
+func NewAccessTokenName(v string) (AccessTokenName, error) {
+	max := codomain.DomainConfig.MaxNameLength
+	if v == "" || utils.StrLen(v) > max || !reName.MatchString(v) {
+		return nil, errors.New("invalid access token name")
+	}
+
+	return accessTokenName(v), nil
+}
+
+type accessTokenName string
+
+func (r accessTokenName) AccessTokenName() string {
+	return string(r)
+}
+
+// AccessTokenScope
+const (
+	AccessTokenScopeTraining    = accessTokenScope("training")
+	AccessTokenScopeRepo        = accessTokenScope("repo")
+	AccessTokenScopeCompetition = accessTokenScope("competition")
+	AccessTokenScopeInference   = accessTokenScope("inference")
+)
+
+type AccessTokenScope interface {
+	AccessTokenScope() string
+}
+
+func NewAccessTokenScope(v string) (AccessTokenScope, error) {
+	switch accessTokenScope(v) {
+	case AccessTokenScopeTraining, AccessTokenScopeRepo, AccessTokenScopeCompetition,
+		AccessTokenScopeInference:
+	default:
+		return nil, errors.New("invalid access token scope")
+	}
+
+	return accessTokenScope(v), nil
+}
+
+type accessTokenScope string
+
+func (r accessTokenScope) AccessTokenScope() string {
+	return string(r)
+}
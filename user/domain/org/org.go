@@ -0,0 +1,9 @@
+package org
+
+import "github.com/opensourceways/xihe-server/user/domain"
+
+// Organization shares the namespace with the user, so the name of user
+// can't be same as any organization.
+type Organization interface {
+	IsOrg(domain.Account) (bool, error)
+}
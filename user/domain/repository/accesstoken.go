@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/opensourceways/xihe-server/user/domain"
+)
+
+type AccessToken interface {
+	Add(*domain.AccessToken) error
+	Remove(owner domain.Account, id string) error
+	List(domain.Account) ([]domain.AccessToken, error)
+	FindByHash(string) (domain.AccessToken, error)
+}
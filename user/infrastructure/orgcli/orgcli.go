@@ -0,0 +1,28 @@
+package orgcli
+
+import (
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	orgapp "github.com/opensourceways/xihe-server/organization/app"
+	"github.com/opensourceways/xihe-server/user/domain"
+	"github.com/opensourceways/xihe-server/user/domain/org"
+)
+
+func NewOrgCli(s orgapp.OrgService) org.Organization {
+	return &orgImpl{s}
+}
+
+type orgImpl struct {
+	s orgapp.OrgService
+}
+
+func (impl *orgImpl) IsOrg(name domain.Account) (bool, error) {
+	if _, err := impl.s.Get(name); err != nil {
+		if repoerr.IsErrorResourceNotExists(err) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return true, nil
+}
@@ -0,0 +1,117 @@
+package repositoryimpl
+
+import (
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	repoerr "github.com/opensourceways/xihe-server/domain/repository"
+	"github.com/opensourceways/xihe-server/user/domain"
+	"github.com/opensourceways/xihe-server/user/domain/repository"
+)
+
+func NewAccessTokenRepo(m mongodbClient) repository.AccessToken {
+	return &accessTokenRepoImpl{m}
+}
+
+type accessTokenRepoImpl struct {
+	cli mongodbClient
+}
+
+func (impl *accessTokenRepoImpl) docFilter(account string) bson.M {
+	return bson.M{
+		fieldAccount: account,
+	}
+}
+
+func (impl *accessTokenRepoImpl) Add(t *domain.AccessToken) error {
+	var obj dAccessToken
+	toAccessTokenDoc(t, &obj)
+
+	doc, err := genDoc(&obj)
+	if err != nil {
+		return err
+	}
+
+	filter := impl.docFilter(t.Owner.Account())
+
+	f := func(ctx context.Context) error {
+		_, err := impl.cli.NewDocIfNotExist(ctx, filter, bson.M{
+			fieldAccount: t.Owner.Account(),
+			fieldTokens:  bson.A{},
+		})
+		if err != nil && !impl.cli.IsDocExists(err) {
+			return err
+		}
+
+		return impl.cli.PushArrayElem(ctx, fieldTokens, filter, doc)
+	}
+
+	return withContext(f)
+}
+
+func (impl *accessTokenRepoImpl) Remove(owner domain.Account, id string) error {
+	f := func(ctx context.Context) error {
+		return impl.cli.PullArrayElem(
+			ctx, fieldTokens, impl.docFilter(owner.Account()),
+			bson.M{fieldId: id},
+		)
+	}
+
+	return withContext(f)
+}
+
+func (impl *accessTokenRepoImpl) List(owner domain.Account) (r []domain.AccessToken, err error) {
+	var v DAccessTokens
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(ctx, impl.docFilter(owner.Account()), nil, &v)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = nil
+		}
+
+		return
+	}
+
+	return v.toAccessTokens()
+}
+
+func (impl *accessTokenRepoImpl) FindByHash(hash string) (r domain.AccessToken, err error) {
+	var v DAccessTokens
+
+	f := func(ctx context.Context) error {
+		return impl.cli.GetDoc(
+			ctx,
+			bson.M{fieldTokens + "." + fieldHash: hash},
+			bson.M{fieldAccount: 1, fieldTokens + ".$": 1},
+			&v,
+		)
+	}
+
+	if err = withContext(f); err != nil {
+		if impl.cli.IsDocNotExists(err) {
+			err = repoerr.NewErrorResourceNotExists(err)
+		}
+
+		return
+	}
+
+	items, err := v.toAccessTokens()
+	if err != nil {
+		return
+	}
+
+	if len(items) == 0 {
+		err = repoerr.NewErrorResourceNotExists(
+			errors.New("access token not exists"),
+		)
+	} else {
+		r = items[0]
+	}
+
+	return
+}
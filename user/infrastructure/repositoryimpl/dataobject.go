@@ -132,3 +132,61 @@ func toFollowerUserInfo(doc DUser, info *domain.FollowerUserInfo) (err error) {
 
 	return
 }
+
+func toAccessTokenDoc(t *domain.AccessToken, doc *dAccessToken) {
+	scopes := make([]string, len(t.Scopes))
+	for i := range t.Scopes {
+		scopes[i] = t.Scopes[i].AccessTokenScope()
+	}
+
+	*doc = dAccessToken{
+		Id:        t.Id,
+		Name:      t.Name.AccessTokenName(),
+		Scopes:    scopes,
+		Hash:      t.Hash,
+		Expiry:    t.Expiry,
+		CreatedAt: t.CreatedAt,
+	}
+}
+
+func (doc *DAccessTokens) toAccessTokens() (r []domain.AccessToken, err error) {
+	if len(doc.Tokens) == 0 {
+		return
+	}
+
+	owner, err := domain.NewAccount(doc.Account)
+	if err != nil {
+		return
+	}
+
+	r = make([]domain.AccessToken, len(doc.Tokens))
+	for i := range doc.Tokens {
+		if err = doc.Tokens[i].toAccessToken(&r[i]); err != nil {
+			return
+		}
+
+		r[i].Owner = owner
+	}
+
+	return
+}
+
+func (doc *dAccessToken) toAccessToken(t *domain.AccessToken) (err error) {
+	if t.Name, err = domain.NewAccessTokenName(doc.Name); err != nil {
+		return
+	}
+
+	t.Scopes = make([]domain.AccessTokenScope, len(doc.Scopes))
+	for i := range doc.Scopes {
+		if t.Scopes[i], err = domain.NewAccessTokenScope(doc.Scopes[i]); err != nil {
+			return
+		}
+	}
+
+	t.Id = doc.Id
+	t.Hash = doc.Hash
+	t.Expiry = doc.Expiry
+	t.CreatedAt = doc.CreatedAt
+
+	return
+}
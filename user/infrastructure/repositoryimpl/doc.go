@@ -13,6 +13,9 @@ const (
 	fieldFollowerCount  = "follower_count"
 	fieldFollowingCount = "following_count"
 	fieldIsFollower     = "is_follower"
+	fieldId             = "id"
+	fieldHash           = "hash"
+	fieldTokens         = "tokens"
 )
 
 type DUser struct {
@@ -45,3 +48,17 @@ type DUserRegInfo struct {
 	Detail   map[string]string `bson:"detail"         json:"detail,omitempty"`
 	Version  int               `bson:"version"        json:"-"`
 }
+
+type DAccessTokens struct {
+	Account string         `bson:"account" json:"account"`
+	Tokens  []dAccessToken `bson:"tokens"  json:"-"`
+}
+
+type dAccessToken struct {
+	Id        string   `bson:"id"         json:"id"`
+	Name      string   `bson:"name"       json:"name"`
+	Scopes    []string `bson:"scopes"     json:"scopes"`
+	Hash      string   `bson:"hash"       json:"hash"`
+	Expiry    int64    `bson:"expiry"     json:"expiry"`
+	CreatedAt int64    `bson:"created_at" json:"created_at"`
+}
@@ -26,6 +26,8 @@ type mongodbClient interface {
 	GetDocs(ctx context.Context, filterOfDoc, project bson.M, result interface{}) error
 	AddToSimpleArray(ctx context.Context, array string, filterOfDoc, value interface{}) error
 	RemoveFromSimpleArray(ctx context.Context, array string, filterOfDoc, value interface{}) error
+	PushArrayElem(ctx context.Context, array string, filterOfDoc, value bson.M) error
+	PullArrayElem(ctx context.Context, array string, filterOfDoc, filterOfArray bson.M) error
 }
 
 func withContext(f func(context.Context) error) error {